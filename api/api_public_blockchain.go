@@ -26,7 +26,9 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/klaytn/klaytn/accounts/abi"
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/blockchain/types/accountkey"
@@ -103,16 +105,7 @@ func (s *PublicBlockChainAPI) GetBlockReceipts(ctx context.Context, blockHash co
 	if err != nil {
 		return nil, err
 	}
-	txs := block.Transactions()
-	if receipts.Len() != txs.Len() {
-		return nil, fmt.Errorf("the size of transactions and receipts is different in the block (%s)", blockHash.String())
-	}
-	fieldsList := make([]map[string]interface{}, 0, len(receipts))
-	for index, receipt := range receipts {
-		fields := RpcOutputReceipt(txs[index], blockHash, block.NumberU64(), uint64(index), receipt)
-		fieldsList = append(fieldsList, fields)
-	}
-	return fieldsList, nil
+	return rpcOutputReceipts(block, receipts)
 }
 
 // GetBalance returns the amount of peb for the given address in the state of the
@@ -229,23 +222,69 @@ type CallArgs struct {
 	Data     hexutil.Bytes   `json:"data"`
 }
 
-func DoCall(ctx context.Context, b Backend, args CallArgs, blockNr rpc.BlockNumber, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int) ([]byte, uint64, uint64, bool, error) {
-	defer func(start time.Time) { logger.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
+// OverrideAccount indicates the overriding fields of account during the execution
+// of a message call.
+// Note, state and stateDiff can't be specified at the same time. If state is
+// set, message execution will only use the data in the given state. Otherwise
+// if stateDiff is set, all diff will be applied first and then execute the call
+// message.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   **hexutil.Big                `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
 
-	state, header, err := b.StateAndHeaderByNumber(ctx, blockNr)
-	if state == nil || err != nil {
-		return nil, 0, 0, false, err
+// StateOverride is the collection of overridden accounts.
+type StateOverride map[common.Address]OverrideAccount
+
+// Apply overrides the fields of specified accounts into the given state.
+func (diff *StateOverride) Apply(state *state.StateDB) error {
+	if diff == nil {
+		return nil
 	}
-	// Set sender address or use a default if none specified
-	addr := args.From
-	if addr == (common.Address{}) {
-		if wallets := b.AccountManager().Wallets(); len(wallets) > 0 {
-			if accounts := wallets[0].Accounts(); len(accounts) > 0 {
-				addr = accounts[0].Address
+	for addr, account := range *diff {
+		// Override account(contract) code.
+		if account.Code != nil {
+			// Only a program account can have code, so convert the account if needed.
+			if !state.IsProgramAccount(addr) {
+				nonce := state.GetNonce(addr)
+				state.CreateSmartContractAccount(addr, params.CodeFormatEVM)
+				state.SetNonce(addr, nonce)
+			}
+			if err := state.SetCode(addr, *account.Code); err != nil {
+				return fmt.Errorf("account %s: %v", addr.Hex(), err)
+			}
+		}
+		// Override account nonce.
+		if account.Nonce != nil {
+			state.SetNonce(addr, uint64(*account.Nonce))
+		}
+		// Override account balance.
+		if account.Balance != nil {
+			state.SetBalance(addr, (*big.Int)(*account.Balance))
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		// Replace entire state if caller requires.
+		if account.State != nil {
+			state.SetStorage(addr, *account.State)
+		}
+		// Apply state diff into specified accounts.
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				state.SetState(addr, key, value)
 			}
 		}
 	}
-	// Set default gas & gas price if none were set
+	return nil
+}
+
+// ToMessage converts CallArgs to the Message type used by the core evm.
+// The default gas and gas price are used if none were set.
+func (args *CallArgs) ToMessage(globalGasCap *big.Int) (*types.Transaction, error) {
 	gas, gasPrice := uint64(args.Gas), args.GasPrice.ToInt()
 	if gas == 0 {
 		gas = math.MaxUint64 / 2
@@ -260,11 +299,42 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNr rpc.BlockNumb
 
 	intrinsicGas, err := types.IntrinsicGas(args.Data, args.To == nil, true)
 	if err != nil {
+		return nil, err
+	}
+	return types.NewMessage(args.From, args.To, 0, args.Value.ToInt(), gas, gasPrice, args.Data, false, intrinsicGas), nil
+}
+
+func DoCall(ctx context.Context, b Backend, args CallArgs, blockNr rpc.BlockNumber, overrides *StateOverride, vmCfg vm.Config, timeout time.Duration, globalGasCap *big.Int) ([]byte, uint64, uint64, bool, error) {
+	defer func(start time.Time) { logger.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
+
+	state, header, err := b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, 0, 0, false, err
+	}
+	if err := overrides.Apply(state); err != nil {
 		return nil, 0, 0, false, err
 	}
+	// Set sender address or use a default if none specified
+	addr := args.From
+	if addr == (common.Address{}) {
+		if wallets := b.AccountManager().Wallets(); len(wallets) > 0 {
+			if accounts := wallets[0].Accounts(); len(accounts) > 0 {
+				addr = accounts[0].Address
+			}
+		}
+	}
+	args.From = addr
+
+	// After the magma hard fork, an unpriced call pays the base fee of the block.
+	if args.GasPrice.ToInt().Sign() == 0 && header.BaseFee != nil {
+		args.GasPrice = hexutil.Big(*header.BaseFee)
+	}
 
 	// Create new call message
-	msg := types.NewMessage(addr, args.To, 0, args.Value.ToInt(), gas, gasPrice, args.Data, false, intrinsicGas)
+	msg, err := args.ToMessage(globalGasCap)
+	if err != nil {
+		return nil, 0, 0, false, err
+	}
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -310,22 +380,29 @@ func DoCall(ctx context.Context, b Backend, args CallArgs, blockNr rpc.BlockNumb
 
 // Call executes the given transaction on the state for the given block number.
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
-func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber) (hexutil.Bytes, error) {
-	result, _, _, _, err := DoCall(ctx, s.b, args, blockNr, vm.Config{}, localTxExecutionTime, s.b.RPCGasCap())
+// Additionally, the caller can specify a batch of accounts whose fields are overridden before the execution.
+func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, overrides *StateOverride) (hexutil.Bytes, error) {
+	result, _, _, _, err := DoCall(ctx, s.b, args, blockNr, overrides, vm.Config{}, localTxExecutionTime, s.b.RPCGasCap())
 	return (hexutil.Bytes)(result), err
 }
 
-func (s *PublicBlockChainAPI) EstimateComputationCost(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber) (hexutil.Uint64, error) {
-	_, _, computationCost, _, err := DoCall(ctx, s.b, args, blockNr, vm.Config{UseOpcodeComputationCost: true}, localTxExecutionTime, s.b.RPCGasCap())
+func (s *PublicBlockChainAPI) EstimateComputationCost(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, overrides *StateOverride) (hexutil.Uint64, error) {
+	_, _, computationCost, _, err := DoCall(ctx, s.b, args, blockNr, overrides, vm.Config{UseOpcodeComputationCost: true}, localTxExecutionTime, s.b.RPCGasCap())
 	return (hexutil.Uint64)(computationCost), err
 }
 
-// EstimateGas returns an estimate of the amount of gas needed to execute the given transaction against the latest block.
-func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs) (hexutil.Uint64, error) {
-	return s.DoEstimateGas(ctx, s.b, args, s.b.RPCGasCap())
+// EstimateGas returns an estimate of the amount of gas needed to execute the given transaction
+// against the given block, or the latest block if none is given. The state can be overridden
+// for the estimation as in Call.
+func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs, blockNr *rpc.BlockNumber, overrides *StateOverride) (hexutil.Uint64, error) {
+	bNr := rpc.LatestBlockNumber
+	if blockNr != nil {
+		bNr = *blockNr
+	}
+	return s.DoEstimateGas(ctx, s.b, args, bNr, overrides, s.b.RPCGasCap())
 }
 
-func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args CallArgs, gasCap *big.Int) (hexutil.Uint64, error) {
+func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNr rpc.BlockNumber, overrides *StateOverride, gasCap *big.Int) (hexutil.Uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  uint64 = params.TxGas - 1
@@ -348,7 +425,7 @@ func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args
 	executable := func(gas uint64) bool {
 		args.Gas = hexutil.Uint64(gas)
 
-		_, _, _, failed, err := DoCall(ctx, b, args, rpc.LatestBlockNumber, vm.Config{UseOpcodeComputationCost: true}, localTxExecutionTime, gasCap)
+		_, _, _, failed, err := DoCall(ctx, b, args, blockNr, overrides, vm.Config{UseOpcodeComputationCost: true}, localTxExecutionTime, gasCap)
 		if err != nil || failed {
 			return false
 		}
@@ -372,6 +449,45 @@ func (s *PublicBlockChainAPI) DoEstimateGas(ctx context.Context, b Backend, args
 	return hexutil.Uint64(hi), nil
 }
 
+// AccessListResult is the result of CreateAccessList. It contains the accounts and
+// the storage slots touched by a call, the gas used and the error if the call failed.
+type AccessListResult struct {
+	AccessList vm.AccessList  `json:"accessList"`
+	Error      string         `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+}
+
+// CreateAccessList executes the given call on the state of the given block, or the
+// latest block if none is given, and returns all the accounts and storage slots
+// read or written by the call. The state can be overridden as in Call.
+// A failed call is not returned as an error, but the reason is set to the result.
+func (s *PublicBlockChainAPI) CreateAccessList(ctx context.Context, args CallArgs, blockNr *rpc.BlockNumber, overrides *StateOverride) (*AccessListResult, error) {
+	bNr := rpc.LatestBlockNumber
+	if blockNr != nil {
+		bNr = *blockNr
+	}
+	// The precompiled contracts to exclude depend on the block to run the call on
+	header, err := s.b.HeaderByNumber(ctx, bNr)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	bNr = rpc.BlockNumber(header.Number.Int64())
+
+	tracer := vm.NewAccessListTracer(vm.ActivePrecompiles(s.b.ChainConfig().Rules(header.Number)))
+	ret, gas, _, failed, err := DoCall(ctx, s.b, args, bNr, overrides, vm.Config{Debug: true, Tracer: tracer}, localTxExecutionTime, s.b.RPCGasCap())
+	if err != nil && !failed {
+		return nil, err
+	}
+	result := &AccessListResult{AccessList: tracer.AccessList(), GasUsed: hexutil.Uint64(gas)}
+	if failed {
+		result.Error = err.Error()
+		if reason, errUnpack := abi.UnpackRevert(ret); errUnpack == nil {
+			result.Error += ": " + reason
+		}
+	}
+	return result, nil
+}
+
 // ExecutionResult groups all structured logs emitted by the EVM
 // while replaying a transaction in debug mode as well as transaction
 // execution status, the amount of gas used and the return value
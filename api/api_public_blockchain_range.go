@@ -0,0 +1,190 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// BlockRangeMaxSpan is the maximum number of blocks returned by a block range API (0 = no limit).
+// The block range subscription is not limited since it sends the blocks one by one.
+// It can be overwritten by api.blockrange.maxspan flag.
+var BlockRangeMaxSpan = uint64(100)
+
+var errInvalidBlockRange = errors.New("invalid block range: start is greater than end")
+
+// GetBlockReceiptsByNumber returns all the transaction receipts for the given block number.
+func (s *PublicBlockChainAPI) GetBlockReceiptsByNumber(ctx context.Context, blockNr rpc.BlockNumber) ([]map[string]interface{}, error) {
+	block, err := s.b.BlockByNumber(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	return rpcOutputReceipts(block, s.b.GetBlockReceipts(ctx, block.Hash()))
+}
+
+// GetBlocksByNumberRange returns the blocks in [start, end]. When fullTx is true all transactions
+// in the blocks are returned in full detail, otherwise only the transaction hashes are returned.
+// The blocks after the current block are omitted.
+func (s *PublicBlockChainAPI) GetBlocksByNumberRange(ctx context.Context, start, end rpc.BlockNumber, fullTx bool) ([]map[string]interface{}, error) {
+	begin, last, err := s.resolveBlockRange(start, end, BlockRangeMaxSpan)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]map[string]interface{}, 0, last-begin+1)
+	for number := begin; number <= last; number++ {
+		block, _, err := s.readBlockAndReceipts(ctx, number, false)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := s.rpcOutputBlock(block, true, fullTx)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, fields)
+	}
+	return blocks, nil
+}
+
+// GetBlockReceiptsByNumberRange returns the transaction receipts of the blocks in [start, end]
+// in the order of the blocks. The blocks after the current block are omitted.
+func (s *PublicBlockChainAPI) GetBlockReceiptsByNumberRange(ctx context.Context, start, end rpc.BlockNumber) ([][]map[string]interface{}, error) {
+	begin, last, err := s.resolveBlockRange(start, end, BlockRangeMaxSpan)
+	if err != nil {
+		return nil, err
+	}
+	receiptsList := make([][]map[string]interface{}, 0, last-begin+1)
+	for number := begin; number <= last; number++ {
+		block, receipts, err := s.readBlockAndReceipts(ctx, number, true)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := rpcOutputReceipts(block, receipts)
+		if err != nil {
+			return nil, err
+		}
+		receiptsList = append(receiptsList, fields)
+	}
+	return receiptsList, nil
+}
+
+// BlockRange creates a subscription that sends the blocks in [start, end] with their receipts one by one.
+// Unlike GetBlocksByNumberRange, the range is not limited by BlockRangeMaxSpan.
+func (s *PublicBlockChainAPI) BlockRange(ctx context.Context, start, end rpc.BlockNumber, fullTx bool) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	begin, last, err := s.resolveBlockRange(start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		for number := begin; number <= last; number++ {
+			select {
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			default:
+			}
+
+			fields, err := s.rpcOutputBlockWithReceipts(ctx, number, fullTx)
+			if err != nil {
+				logger.Error("Failed to read a block of the block range subscription", "number", number, "err", err)
+				return
+			}
+			if err := notifier.Notify(rpcSub.ID, fields); err != nil {
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// rpcOutputBlockWithReceipts returns the block of the given number with its receipts.
+func (s *PublicBlockChainAPI) rpcOutputBlockWithReceipts(ctx context.Context, number uint64, fullTx bool) (map[string]interface{}, error) {
+	block, receipts, err := s.readBlockAndReceipts(ctx, number, true)
+	if err != nil {
+		return nil, err
+	}
+	blockFields, err := s.rpcOutputBlock(block, true, fullTx)
+	if err != nil {
+		return nil, err
+	}
+	receiptsFields, err := rpcOutputReceipts(block, receipts)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"block": blockFields, "receipts": receiptsFields}, nil
+}
+
+// resolveBlockRange returns the numbers of the first and the last blocks of the given range.
+// The latest and pending block numbers mean the current block, and the end is capped by the
+// current block. An error is returned if the range has more than maxSpan blocks (0 = no limit).
+func (s *PublicBlockChainAPI) resolveBlockRange(start, end rpc.BlockNumber, maxSpan uint64) (uint64, uint64, error) {
+	head := s.b.CurrentBlock().NumberU64()
+	resolve := func(number rpc.BlockNumber) uint64 {
+		if number < 0 || uint64(number) > head {
+			return head
+		}
+		return uint64(number)
+	}
+	if start >= 0 && uint64(start) > head {
+		return 0, 0, fmt.Errorf("start block %d is beyond the current block %d", start, head)
+	}
+	begin, last := resolve(start), resolve(end)
+	if begin > last {
+		return 0, 0, errInvalidBlockRange
+	}
+	if maxSpan != 0 && last-begin >= maxSpan {
+		return 0, 0, fmt.Errorf("block range too large: %d blocks (max %d)", last-begin+1, maxSpan)
+	}
+	return begin, last, nil
+}
+
+// readBlockAndReceipts reads the canonical block of the given number and, if withReceipts is true, its receipts.
+func (s *PublicBlockChainAPI) readBlockAndReceipts(ctx context.Context, number uint64, withReceipts bool) (*types.Block, types.Receipts, error) {
+	block, err := s.b.BlockByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !withReceipts {
+		return block, nil, nil
+	}
+	return block, s.b.GetBlockReceipts(ctx, block.Hash()), nil
+}
+
+// rpcOutputReceipts returns the RPC representation of the receipts of the given block.
+func rpcOutputReceipts(block *types.Block, receipts types.Receipts) ([]map[string]interface{}, error) {
+	txs := block.Transactions()
+	if receipts.Len() != txs.Len() {
+		return nil, fmt.Errorf("the size of transactions and receipts is different in the block (%s)", block.Hash().String())
+	}
+	fieldsList := make([]map[string]interface{}, 0, len(receipts))
+	for index, receipt := range receipts {
+		fields := RpcOutputReceipt(txs[index], block.Hash(), block.NumberU64(), uint64(index), receipt)
+		fieldsList = append(fieldsList, fields)
+	}
+	return fieldsList, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockRangeTestBackend serves the blocks written in its database.
+type blockRangeTestBackend struct {
+	Backend
+	db   database.DBManager
+	head *types.Block
+}
+
+func (b *blockRangeTestBackend) ChainDB() database.DBManager { return b.db }
+func (b *blockRangeTestBackend) CurrentBlock() *types.Block  { return b.head }
+func (b *blockRangeTestBackend) GetTd(common.Hash) *big.Int  { return big.NewInt(1) }
+func (b *blockRangeTestBackend) BlockByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Block, error) {
+	block := b.db.ReadBlockByNumber(uint64(blockNr))
+	if block == nil {
+		return nil, fmt.Errorf("the block does not exist (block number: %d)", blockNr)
+	}
+	return block, nil
+}
+func (b *blockRangeTestBackend) GetBlockReceipts(ctx context.Context, hash common.Hash) types.Receipts {
+	return b.db.ReadReceiptsByBlockHash(hash)
+}
+
+// newBlockRangeTestBackend writes the blocks of the given numbers of transactions into a memory database.
+func newBlockRangeTestBackend(t *testing.T, txCounts ...int) *blockRangeTestBackend {
+	blockchain.InitDeriveSha(types.ImplDeriveShaOriginal)
+	signer := types.NewEIP155Signer(big.NewInt(1))
+	backend := &blockRangeTestBackend{db: database.NewMemoryDBManager()}
+
+	nonce := uint64(0)
+	for number, txCount := range txCounts {
+		var (
+			txs      types.Transactions
+			receipts types.Receipts
+		)
+		for i := 0; i < txCount; i++ {
+			tx, err := types.SignTx(types.NewTransaction(nonce, testTo, big.NewInt(1), 21000, big.NewInt(1), nil), signer, senderPrvKey)
+			require.NoError(t, err)
+			nonce++
+			txs = append(txs, tx)
+			receipts = append(receipts, &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash(), GasUsed: 21000, Logs: []*types.Log{}})
+		}
+		header := &types.Header{Number: big.NewInt(int64(number)), BlockScore: big.NewInt(1), Time: big.NewInt(int64(number))}
+		if backend.head != nil {
+			header.ParentHash = backend.head.Hash()
+		}
+		block := types.NewBlock(header, txs, receipts)
+		backend.db.WriteBlock(block)
+		backend.db.WriteCanonicalHash(block.Hash(), block.NumberU64())
+		backend.db.WriteReceipts(block.Hash(), block.NumberU64(), receipts)
+		backend.head = block
+	}
+	return backend
+}
+
+func TestPublicBlockChainAPI_BlockRange(t *testing.T) {
+	backend := newBlockRangeTestBackend(t, 1, 0, 2, 3)
+	api := NewPublicBlockChainAPI(backend)
+	ctx := context.Background()
+
+	receipts, err := api.GetBlockReceiptsByNumber(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, receipts, 2)
+
+	blocks, err := api.GetBlocksByNumberRange(ctx, 1, 3, false)
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	for i, block := range blocks {
+		assert.Equal(t, backend.db.ReadCanonicalHash(uint64(i+1)), block["hash"])
+	}
+
+	// The latest block number means the current block.
+	receiptsList, err := api.GetBlockReceiptsByNumberRange(ctx, 0, rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Len(t, receiptsList, 4)
+	for i, count := range []int{1, 0, 2, 3} {
+		assert.Len(t, receiptsList[i], count)
+	}
+	assert.Equal(t, backend.head.Transactions()[2].Hash(), receiptsList[3][2]["transactionHash"])
+
+	// The end is capped by the current block.
+	blocks, err = api.GetBlocksByNumberRange(ctx, 2, 100, true)
+	require.NoError(t, err)
+	assert.Len(t, blocks, 2)
+}
+
+func TestPublicBlockChainAPI_BlockRangeErrors(t *testing.T) {
+	backend := newBlockRangeTestBackend(t, 0, 0, 0, 0)
+	api := NewPublicBlockChainAPI(backend)
+	ctx := context.Background()
+
+	_, err := api.GetBlocksByNumberRange(ctx, 3, 1, false)
+	assert.Equal(t, errInvalidBlockRange, err)
+
+	_, err = api.GetBlocksByNumberRange(ctx, 4, 5, false)
+	assert.Error(t, err)
+
+	defer func(span uint64) { BlockRangeMaxSpan = span }(BlockRangeMaxSpan)
+	BlockRangeMaxSpan = 2
+	_, err = api.GetBlockReceiptsByNumberRange(ctx, 0, 2)
+	assert.Error(t, err)
+	_, err = api.GetBlockReceiptsByNumberRange(ctx, 1, 2)
+	assert.NoError(t, err)
+}
+
+func TestPublicBlockChainAPI_BlockRangeSubscription(t *testing.T) {
+	backend := newBlockRangeTestBackend(t, 1, 2, 0)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("klay", NewPublicBlockChainAPI(backend)))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ch := make(chan map[string]interface{})
+	sub, err := client.KlaySubscribe(context.Background(), ch, "blockRange", "0x0", "latest", false)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for i, count := range []int{1, 2, 0} {
+		select {
+		case fields := <-ch:
+			block := fields["block"].(map[string]interface{})
+			assert.Equal(t, backend.db.ReadCanonicalHash(uint64(i)).Hex(), block["hash"])
+			assert.Len(t, fields["receipts"], count)
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateOverride_Apply(t *testing.T) {
+	var (
+		eoa      = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		contract = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		key1     = common.HexToHash("0x01")
+		key2     = common.HexToHash("0x02")
+	)
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	require.NoError(t, err)
+	statedb.AddBalance(eoa, big.NewInt(10))
+	statedb.SetNonce(eoa, 3)
+	statedb.SetState(contract, key1, common.HexToHash("0x11"))
+	statedb.SetState(contract, key2, common.HexToHash("0x22"))
+
+	// A nil override changes nothing
+	var nilOverride *StateOverride
+	assert.NoError(t, nilOverride.Apply(statedb))
+
+	var overrides StateOverride
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"0x1111111111111111111111111111111111111111": {
+			"balance": "0x64",
+			"code": "0x6080"
+		},
+		"0x2222222222222222222222222222222222222222": {
+			"nonce": "0x7",
+			"stateDiff": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000033"}
+		}
+	}`), &overrides))
+	require.NoError(t, overrides.Apply(statedb))
+
+	// The EOA becomes a program account keeping its nonce
+	assert.Equal(t, big.NewInt(100), statedb.GetBalance(eoa))
+	assert.Equal(t, uint64(3), statedb.GetNonce(eoa))
+	assert.True(t, statedb.IsProgramAccount(eoa))
+	assert.Equal(t, []byte{0x60, 0x80}, statedb.GetCode(eoa))
+
+	// The state diff is applied on top of the existing storage
+	assert.Equal(t, uint64(7), statedb.GetNonce(contract))
+	assert.Equal(t, common.HexToHash("0x33"), statedb.GetState(contract, key1))
+	assert.Equal(t, common.HexToHash("0x22"), statedb.GetState(contract, key2))
+
+	// The state replaces the entire storage
+	overrides = StateOverride{contract: {State: &map[common.Hash]common.Hash{key2: common.HexToHash("0x44")}}}
+	require.NoError(t, overrides.Apply(statedb))
+	assert.Equal(t, common.Hash{}, statedb.GetState(contract, key1))
+	assert.Equal(t, common.HexToHash("0x44"), statedb.GetState(contract, key2))
+
+	// Both the state and the state diff cannot be given
+	storage := map[common.Hash]common.Hash{key1: common.HexToHash("0x55")}
+	overrides = StateOverride{contract: {State: &storage, StateDiff: &storage}}
+	assert.Error(t, overrides.Apply(statedb))
+}
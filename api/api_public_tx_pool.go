@@ -21,12 +21,20 @@
 package api
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
 )
 
+// txPoolEventChanSize is the size of the channel receiving the events of the transaction pool.
+const txPoolEventChanSize = 1024
+
 // PublicTxPoolAPI offers and API for the transaction pool. It only operates on data that is non confidential.
 type PublicTxPoolAPI struct {
 	b Backend
@@ -64,6 +72,23 @@ func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]map[string]
 	return content
 }
 
+// ContentFrom returns the transactions sent from the given account within the transaction pool.
+func (s *PublicTxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]map[string]interface{} {
+	content := map[string]map[string]map[string]interface{}{
+		"pending": make(map[string]map[string]interface{}),
+		"queued":  make(map[string]map[string]interface{}),
+	}
+	pending, queue := s.b.TxPoolContentFrom(addr)
+
+	for _, tx := range pending {
+		content["pending"][fmt.Sprintf("%d", tx.Nonce())] = newRPCPendingTransaction(tx)
+	}
+	for _, tx := range queue {
+		content["queued"][fmt.Sprintf("%d", tx.Nonce())] = newRPCPendingTransaction(tx)
+	}
+	return content
+}
+
 // Status returns the number of pending and queued transaction in the pool.
 func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 	pending, queue := s.b.Stats()
@@ -107,3 +132,125 @@ func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	}
 	return content
 }
+
+// Events creates a subscription that fires when a transaction is admitted, promoted,
+// replaced or evicted in the transaction pool. A replaced or evicted transaction comes
+// with the reason.
+func (s *PublicTxPoolAPI) Events(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	var (
+		rpcSub = notifier.CreateSubscription()
+		events = make(chan blockchain.TxPoolEvent, txPoolEventChanSize)
+	)
+	eventsSub := s.b.SubscribeTxPoolEvent(events)
+
+	go func() {
+		defer eventsSub.Unsubscribe()
+		for {
+			select {
+			case ev := <-events:
+				fields := map[string]interface{}{
+					"type":        ev.Type,
+					"transaction": newRPCPendingTransaction(ev.Tx),
+				}
+				if ev.Reason != "" {
+					fields["reason"] = ev.Reason
+				}
+				notifier.Notify(rpcSub.ID, fields)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-eventsSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// txPoolMirrorBatchSize is the maximum number of transactions in a notification of Mirror.
+const txPoolMirrorBatchSize = 1024
+
+// TxPoolMirrorBatch is a notification of Mirror. If Dropped is true, some transactions
+// admitted to the pool could not be mirrored and no more notification follows, so the
+// subscriber should subscribe again to receive a fresh snapshot of the pool.
+type TxPoolMirrorBatch struct {
+	Txs     []hexutil.Bytes `json:"txs"`
+	Dropped bool            `json:"dropped"`
+}
+
+// Mirror creates a subscription streaming the RLP encoded transactions of the
+// transaction pool to let a standby node keep a mirrored pool for failover.
+// It first sends the pending and queued transactions in the pool and then sends
+// every transaction admitted to the pool afterwards. If the events of the pool
+// are dropped, it notifies the subscriber and stops streaming.
+func (s *PublicTxPoolAPI) Mirror(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	var (
+		rpcSub = notifier.CreateSubscription()
+		events = make(chan blockchain.TxPoolEvent, txPoolEventChanSize)
+	)
+	// Subscribe before reading the content not to miss the transactions admitted meanwhile.
+	eventsSub := s.b.SubscribeTxPoolEvent(events)
+	pending, queue := s.b.TxPoolContent()
+
+	go func() {
+		defer eventsSub.Unsubscribe()
+
+		batch := make([]hexutil.Bytes, 0, txPoolMirrorBatchSize)
+		for _, content := range []map[common.Address]types.Transactions{pending, queue} {
+			for _, txs := range content {
+				for _, tx := range txs {
+					enc, err := rlp.EncodeToBytes(tx)
+					if err != nil {
+						logger.Error("Failed to encode transaction to mirror", "hash", tx.Hash(), "err", err)
+						continue
+					}
+					if batch = append(batch, enc); len(batch) == txPoolMirrorBatchSize {
+						notifier.Notify(rpcSub.ID, &TxPoolMirrorBatch{Txs: batch})
+						batch = make([]hexutil.Bytes, 0, txPoolMirrorBatchSize)
+					}
+				}
+			}
+		}
+		if len(batch) > 0 {
+			notifier.Notify(rpcSub.ID, &TxPoolMirrorBatch{Txs: batch})
+		}
+
+		for {
+			select {
+			case ev := <-events:
+				if ev.Dropped {
+					logger.Warn("Stopped mirroring the transaction pool since its events were dropped", "id", rpcSub.ID)
+					notifier.Notify(rpcSub.ID, &TxPoolMirrorBatch{Dropped: true})
+					return
+				}
+				if ev.Type != blockchain.TxPoolEventAdmitted {
+					continue
+				}
+				enc, err := rlp.EncodeToBytes(ev.Tx)
+				if err != nil {
+					logger.Error("Failed to encode transaction to mirror", "hash", ev.Tx.Hash(), "err", err)
+					continue
+				}
+				notifier.Notify(rpcSub.ID, &TxPoolMirrorBatch{Txs: []hexutil.Bytes{enc}})
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-eventsSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -515,6 +515,20 @@ func (mr *MockBackendMockRecorder) SubscribeNewTxsEvent(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNewTxsEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeNewTxsEvent), arg0)
 }
 
+// SubscribeTxPoolEvent mocks base method
+func (m *MockBackend) SubscribeTxPoolEvent(arg0 chan<- blockchain.TxPoolEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeTxPoolEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeTxPoolEvent indicates an expected call of SubscribeTxPoolEvent
+func (mr *MockBackendMockRecorder) SubscribeTxPoolEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeTxPoolEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeTxPoolEvent), arg0)
+}
+
 // SuggestPrice mocks base method
 func (m *MockBackend) SuggestPrice(arg0 context.Context) (*big.Int, error) {
 	m.ctrl.T.Helper()
@@ -544,3 +558,18 @@ func (mr *MockBackendMockRecorder) TxPoolContent() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContent", reflect.TypeOf((*MockBackend)(nil).TxPoolContent))
 }
+
+// TxPoolContentFrom mocks base method
+func (m *MockBackend) TxPoolContentFrom(arg0 common.Address) (types.Transactions, types.Transactions) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolContentFrom", arg0)
+	ret0, _ := ret[0].(types.Transactions)
+	ret1, _ := ret[1].(types.Transactions)
+	return ret0, ret1
+}
+
+// TxPoolContentFrom indicates an expected call of TxPoolContentFrom
+func (mr *MockBackendMockRecorder) TxPoolContentFrom(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContentFrom", reflect.TypeOf((*MockBackend)(nil).TxPoolContentFrom), arg0)
+}
@@ -37,6 +37,7 @@ import (
 
 	"github.com/go-redis/redis/v7"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/klaytn/klaytn/blockchain/snapshot"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/vm"
@@ -84,6 +85,11 @@ var (
 	ErrNotExistNode         = errors.New("the node does not exist in cached node")
 	ErrQuitBySignal         = errors.New("quit by signal")
 	ErrNotInWarmUp          = errors.New("not in warm up")
+
+	ErrLivePruningArchiveMode   = errors.New("live pruning cannot be enabled in archive mode")
+	ErrLivePruningNonEmptyChain = errors.New("live pruning can only be enabled on an empty chain")
+	ErrLivePruningInMigration   = errors.New("state migration is not supported with live pruning")
+
 	logger                  = log.NewModuleLogger(log.Blockchain)
 	kesCachePrefixBlockLogs = []byte("blockLogs")
 )
@@ -106,6 +112,9 @@ const (
 	BlockChainVersion    = 3
 	DefaultBlockInterval = 128
 	MaxPrefetchTxs       = 20000
+
+	// DefaultLivePruningRetention is the default number of recent blocks whose state tries are retained by the live pruning.
+	DefaultLivePruningRetention = 172800
 )
 
 // CacheConfig contains the configuration values for the 1) stateDB caching and
@@ -118,6 +127,10 @@ type CacheConfig struct {
 	TriesInMemory        uint64                       // Maximum number of recent state tries according to its block number
 	SenderTxHashIndexing bool                         // Enables saving senderTxHash to txHash mapping information to database and cache
 	TrieNodeCacheConfig  *statedb.TrieNodeCacheConfig // Configures trie node cache
+	SnapshotCacheSize    int                          // Memory allowance (MB) to use for caching snapshot entries in memory. The snapshot is disabled if zero
+	SnapshotAsyncGen     bool                         // Enables snapshot data generation in background
+	LivePruning          bool                         // Enables deleting the state trie nodes which are no longer referenced
+	LivePruningRetention uint64                       // Number of recent blocks whose state tries are retained by the live pruning
 }
 
 // gcBlock is used for priority queue for GC.
@@ -167,6 +180,7 @@ type BlockChain struct {
 	currentFastBlock atomic.Value // Current head of the fast-sync chain (may be above the block chain!)
 
 	stateCache   state.Database // State database to reuse between imports (contains state cache)
+	snaps        *snapshot.Tree // Snapshot tree for fast trie leaf access
 	futureBlocks *lru.Cache     // future blocks are blocks added for later processing
 
 	quit    chan struct{} // blockchain quit channel
@@ -272,6 +286,9 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 	if err := bc.loadLastState(); err != nil {
 		return nil, err
 	}
+	if err := bc.setupLivePruning(); err != nil {
+		return nil, err
+	}
 	// Check the current state of the block hashes and make sure that we do not have any of the bad blocks in our chain
 	for hash := range BadHashes {
 		if header := bc.GetHeaderByHash(hash); header != nil {
@@ -286,6 +303,12 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 		}
 	}
 
+	// Load any existing snapshot, regenerating it if loading failed
+	if bc.cacheConfig.SnapshotCacheSize > 0 {
+		head := bc.CurrentBlock()
+		bc.snaps = snapshot.New(bc.db, bc.stateCache.TrieDB(), bc.cacheConfig.SnapshotCacheSize, head.Root(), bc.cacheConfig.SnapshotAsyncGen)
+	}
+
 	for i := 1; i <= bc.cacheConfig.TrieNodeCacheConfig.NumFetcherPrefetchWorker; i++ {
 		bc.wg.Add(1)
 		go bc.prefetchTxWorker(i)
@@ -319,7 +342,7 @@ func (bc *BlockChain) prefetchTxWorker(index int) {
 
 	logger.Debug("prefetchTxWorker is started", "index", index)
 	for followup := range bc.prefetchTxCh {
-		stateDB, err := state.NewForPrefetching(bc.CurrentBlock().Root(), bc.stateCache)
+		stateDB, err := state.NewForPrefetching(bc.CurrentBlock().Root(), bc.stateCache, bc.snaps)
 		if err != nil {
 			logger.Debug("failed to retrieve stateDB for prefetchTxWorker", "err", err)
 			continue
@@ -402,7 +425,7 @@ func (bc *BlockChain) loadLastState() error {
 		return bc.Reset()
 	}
 	// Make sure the state associated with the block is available
-	if _, err := state.New(currentBlock.Root(), bc.stateCache); err != nil {
+	if _, err := state.New(currentBlock.Root(), bc.stateCache, bc.snaps); err != nil {
 		// Dangling block without a state associated, init from scratch
 		logger.Error("Head state missing, repairing chain",
 			"number", currentBlock.NumberU64(), "hash", currentBlock.Hash().String())
@@ -462,6 +485,11 @@ func (bc *BlockChain) SetHead(head uint64) error {
 	bc.hc.SetHead(head, delFn)
 	currentHeader := bc.CurrentHeader()
 
+	// Discard the frozen blocks above the new head
+	if err := bc.db.TruncateAncients(currentHeader.Number.Uint64() + 1); err != nil {
+		return err
+	}
+
 	// Clear out any stale content from the caches
 	bc.futureBlocks.Purge()
 	bc.db.ClearBlockChainCache()
@@ -471,7 +499,7 @@ func (bc *BlockChain) SetHead(head uint64) error {
 		bc.currentBlock.Store(bc.GetBlock(currentHeader.Hash(), currentHeader.Number.Uint64()))
 	}
 	if currentBlock := bc.CurrentBlock(); currentBlock != nil {
-		if _, err := state.New(currentBlock.Root(), bc.stateCache); err != nil {
+		if _, err := state.New(currentBlock.Root(), bc.stateCache, bc.snaps); err != nil {
 			// Rewound state missing, rolled back to before pivot, reset to genesis
 			bc.currentBlock.Store(bc.genesisBlock)
 		}
@@ -493,6 +521,10 @@ func (bc *BlockChain) SetHead(head uint64) error {
 	bc.db.WriteHeadBlockHash(currentBlock.Hash())
 	bc.db.WriteHeadFastBlockHash(currentFastBlock.Hash())
 
+	// The snapshot does not cover the rewound head anymore, regenerate it
+	if bc.snaps != nil && bc.snaps.Snapshot(currentBlock.Root()) == nil {
+		bc.snaps.Rebuild(currentBlock.Root())
+	}
 	return bc.loadLastState()
 }
 
@@ -546,7 +578,7 @@ func (bc *BlockChain) State() (*state.StateDB, error) {
 
 // StateAt returns a new mutable state based on a particular point in time.
 func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
-	return state.New(root, bc.stateCache)
+	return state.New(root, bc.stateCache, bc.snaps)
 }
 
 // StateAtWithPersistent returns a new mutable state based on a particular point in time with persistent trie nodes.
@@ -555,7 +587,7 @@ func (bc *BlockChain) StateAtWithPersistent(root common.Hash) (*state.StateDB, e
 	if !exist {
 		return nil, ErrNotExistNode
 	}
-	return state.New(root, bc.stateCache)
+	return state.New(root, bc.stateCache, bc.snaps)
 }
 
 // StateAtWithGCLock returns a new mutable state based on a particular point in time with read lock of the state nodes.
@@ -568,7 +600,7 @@ func (bc *BlockChain) StateAtWithGCLock(root common.Hash) (*state.StateDB, error
 		return nil, ErrNotExistNode
 	}
 
-	stateDB, err := state.New(root, bc.stateCache)
+	stateDB, err := state.New(root, bc.stateCache, bc.snaps)
 	if err != nil {
 		bc.RUnlockGCCachedNode()
 		return nil, err
@@ -577,6 +609,12 @@ func (bc *BlockChain) StateAtWithGCLock(root common.Hash) (*state.StateDB, error
 	return stateDB, nil
 }
 
+// Snapshots returns the blockchain snapshot tree. This method is mainly used
+// for testing, nil is returned if the snapshot is disabled.
+func (bc *BlockChain) Snapshots() *snapshot.Tree {
+	return bc.snaps
+}
+
 // StateCache returns the caching database underpinning the blockchain instance.
 func (bc *BlockChain) StateCache() state.Database {
 	return bc.stateCache
@@ -620,7 +658,7 @@ func (bc *BlockChain) ResetWithGenesisBlock(genesis *types.Block) error {
 func (bc *BlockChain) repair(head **types.Block) error {
 	for {
 		// Abort if we've rewound to a head block that does have associated state
-		if _, err := state.New((*head).Root(), bc.stateCache); err == nil {
+		if _, err := state.New((*head).Root(), bc.stateCache, bc.snaps); err == nil {
 			logger.Info("Rewound blockchain to past state", "number", (*head).Number(), "hash", (*head).Hash())
 			return nil
 		} else {
@@ -861,6 +899,13 @@ func (bc *BlockChain) Stop() {
 
 	bc.wg.Wait()
 
+	// Ensure that the in-memory snapshot journal is persisted
+	if bc.snaps != nil {
+		if _, err := bc.snaps.Journal(bc.CurrentBlock().Root()); err != nil {
+			logger.Error("Failed to journal state snapshot", "err", err)
+		}
+	}
+
 	triedb := bc.stateCache.TrieDB()
 	if !bc.isArchiveMode() {
 		number := bc.CurrentBlock().NumberU64()
@@ -874,6 +919,7 @@ func (bc *BlockChain) Stop() {
 		if err := triedb.Commit(recent.Root(), true, number); err != nil {
 			logger.Error("Failed to commit recent state trie", "err", err)
 		}
+		triedb.RetainRoot(recent.Root(), number)
 
 		for !bc.triegc.Empty() {
 			triedb.Dereference(bc.triegc.PopItem().(common.Hash))
@@ -1119,6 +1165,14 @@ func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) e
 	if err != nil {
 		return err
 	}
+	// The snapshot tree was updated by the commit above. Keep as many diff layers
+	// as the tries residing in the memory, so the disk layer of the snapshot tree
+	// always has its state trie available.
+	if bc.snaps != nil {
+		if err := bc.snaps.Cap(root, int(bc.triesInMemory())); err != nil {
+			logger.Warn("Failed to cap snapshot tree", "root", root, "layers", bc.triesInMemory(), "err", err)
+		}
+	}
 	trieDB := bc.stateCache.TrieDB()
 	trieDB.UpdateMetricNodes()
 
@@ -1156,6 +1210,7 @@ func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) e
 			if err := trieDB.Commit(block.Header().Root, true, block.NumberU64()); err != nil {
 				return err
 			}
+			trieDB.RetainRoot(block.Header().Root, block.NumberU64())
 
 			if bc.checkStartStateMigration(block.NumberU64(), root) {
 				// flush referenced trie nodes out to new stateTrieDB
@@ -1187,6 +1242,37 @@ func (bc *BlockChain) triesInMemory() uint64 {
 	return bc.cacheConfig.TriesInMemory
 }
 
+// setupLivePruning validates the live pruning configuration against the database
+// and enables the live pruning of the trie database if needed. Once enabled for a
+// database, the live pruning is always enabled since the reference counts of trie
+// nodes have to be maintained thereafter.
+func (bc *BlockChain) setupLivePruning() error {
+	if bc.db.ReadPruningEnabled() {
+		if !bc.cacheConfig.LivePruning {
+			logger.Warn("Live pruning is enabled since it was enabled for the database")
+		}
+		bc.cacheConfig.LivePruning = true
+	} else if bc.cacheConfig.LivePruning {
+		if head := bc.CurrentBlock(); head != nil && head.NumberU64() > 0 {
+			return ErrLivePruningNonEmptyChain
+		}
+	}
+	if !bc.cacheConfig.LivePruning {
+		return nil
+	}
+	if bc.isArchiveMode() {
+		return ErrLivePruningArchiveMode
+	}
+	if min := bc.triesInMemory() + uint64(bc.cacheConfig.BlockInterval); bc.cacheConfig.LivePruningRetention < min {
+		logger.Warn("Live pruning retention is too small, adjusting", "provided", bc.cacheConfig.LivePruningRetention, "updated", min)
+		bc.cacheConfig.LivePruningRetention = min
+	}
+	bc.db.WritePruningEnabled()
+	bc.stateCache.TrieDB().EnablePruning()
+	logger.Info("Live pruning is enabled", "retention", bc.cacheConfig.LivePruningRetention)
+	return nil
+}
+
 // gcCachedNodeLoop runs a loop to gc.
 func (bc *BlockChain) gcCachedNodeLoop() {
 	trieDB := bc.stateCache.TrieDB()
@@ -1218,6 +1304,16 @@ func (bc *BlockChain) gcCachedNodeLoop() {
 					cnt++
 				}
 				logger.Debug("GC cached node", "currentBlk", blkNum, "chosenBlk", chosen, "deferenceCnt", cnt)
+
+				// Delete the state trie nodes which are not referenced anymore
+				if trieDB.PruningEnabled() && blkNum > bc.cacheConfig.LivePruningRetention {
+					limit := blkNum - bc.cacheConfig.LivePruningRetention
+					if deleted, err := trieDB.PruneRoots(limit); err != nil {
+						logger.Error("Failed to prune state trie nodes", "limit", limit, "err", err)
+					} else if deleted > 0 {
+						logger.Debug("Pruned state trie nodes", "currentBlk", blkNum, "limit", limit, "deleted", deleted)
+					}
+				}
 			case <-bc.quit:
 				return
 			}
@@ -1620,7 +1716,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 				// current block is not the last one, so prefetch the right next block
 				followup := chain[i+1]
 				go func(start time.Time) {
-					throwaway, _ := state.NewForPrefetching(parent.Root(), bc.stateCache)
+					throwaway, _ := state.NewForPrefetching(parent.Root(), bc.stateCache, bc.snaps)
 					vmCfg := bc.vmConfig
 					vmCfg.Prefetching = true
 					bc.prefetcher.Prefetch(followup, throwaway, vmCfg, &followupInterrupt)
@@ -2097,6 +2193,11 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 	for _, tx := range diff {
 		bc.db.DeleteTxLookupEntry(tx.Hash())
 	}
+	// If the reorganisation went deeper than the diff layers of the snapshot,
+	// the new head is not covered by the snapshot anymore. Regenerate it.
+	if bc.snaps != nil && bc.snaps.Snapshot(newBlock.Root()) == nil {
+		bc.snaps.Rebuild(newBlock.Root())
+	}
 	if len(deletedLogs) > 0 {
 		go bc.rmLogsFeed.Send(RemovedLogsEvent{deletedLogs})
 	}
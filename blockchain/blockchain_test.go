@@ -158,7 +158,7 @@ func testBlockChainImport(chain types.Blocks, blockchain *BlockChain) error {
 			}
 			return err
 		}
-		statedb, err := state.New(blockchain.GetBlockByHash(block.ParentHash()).Root(), blockchain.stateCache)
+		statedb, err := state.New(blockchain.GetBlockByHash(block.ParentHash()).Root(), blockchain.stateCache, nil)
 		if err != nil {
 			return err
 		}
@@ -1626,3 +1626,130 @@ func TestBlockChain_SetCanonicalBlock(t *testing.T) {
 	assert.Equal(t, targetBlock.Hash(), newHeadBlock.Hash())
 	assert.EqualValues(t, targetBlock, newHeadBlock)
 }
+
+// TestBlockChain_Snapshot tests that the snapshot tree follows the inserted
+// blocks, serves the same state as the trie and is restored from the journal
+// after the chain is restarted.
+func TestBlockChain_Snapshot(t *testing.T) {
+	var (
+		engine  = gxhash.NewFaker()
+		gendb   = database.NewMemoryDBManager()
+		genesis = new(Genesis).MustCommit(gendb)
+	)
+	blocks, _ := GenerateChain(params.TestChainConfig, genesis, engine, gendb, 2*DefaultTriesInMemory, func(i int, b *BlockGen) {
+		b.SetRewardbase(common.Address{byte(i%5 + 1)})
+	})
+
+	db := database.NewMemoryDBManager()
+	new(Genesis).MustCommit(db)
+
+	cacheConfig := &CacheConfig{
+		CacheSize:           512,
+		BlockInterval:       DefaultBlockInterval,
+		TriesInMemory:       DefaultTriesInMemory,
+		TrieNodeCacheConfig: statedb.GetEmptyTrieNodeCacheConfig(),
+		SnapshotCacheSize:   16,
+	}
+	chain, err := NewBlockChain(db, cacheConfig, params.TestChainConfig, engine, vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to process block %d: %v", n, err)
+	}
+	head := chain.CurrentBlock()
+	assert.NotNil(t, chain.Snapshots().Snapshot(head.Root()))
+	assert.NotEqual(t, genesis.Root(), chain.Snapshots().DiskRoot())
+
+	// The snapshot-backed state must agree with the trie-backed one.
+	snapState, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to open state: %v", err)
+	}
+	trieState, err := state.New(head.Root(), chain.StateCache(), nil)
+	if err != nil {
+		t.Fatalf("failed to open state: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		addr := common.Address{byte(i)}
+		assert.Equal(t, trieState.GetBalance(addr), snapState.GetBalance(addr))
+	}
+	chain.Stop()
+
+	// The diff layers should be restored from the journal on restart.
+	chain, err = NewBlockChain(db, cacheConfig, params.TestChainConfig, engine, vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to recreate blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	assert.NotNil(t, chain.Snapshots().Snapshot(head.Root()))
+	assert.NotEqual(t, head.Root(), chain.Snapshots().DiskRoot())
+}
+
+func TestBlockChain_LivePruning(t *testing.T) {
+	var (
+		engine  = gxhash.NewFaker()
+		gendb   = database.NewMemoryDBManager()
+		genesis = new(Genesis).MustCommit(gendb)
+	)
+	blocks, _ := GenerateChain(params.TestChainConfig, genesis, engine, gendb, 40, func(i int, b *BlockGen) {
+		b.SetRewardbase(common.Address{byte(i%5 + 1)})
+	})
+
+	db := database.NewMemoryDBManager()
+	new(Genesis).MustCommit(db)
+
+	cacheConfig := &CacheConfig{
+		CacheSize:            512,
+		BlockInterval:        4,
+		TriesInMemory:        4,
+		TrieNodeCacheConfig:  statedb.GetEmptyTrieNodeCacheConfig(),
+		LivePruning:          true,
+		LivePruningRetention: 8,
+	}
+	chain, err := NewBlockChain(db, cacheConfig, params.TestChainConfig, engine, vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to process block %d: %v", n, err)
+	}
+	assert.True(t, db.ReadPruningEnabled())
+
+	// The state tries committed before the retention window are pruned in background.
+	oldRoot := blocks[3].Root()
+	for i := 0; i < 100; i++ {
+		if has, _ := db.HasStateTrieNode(oldRoot[:]); !has {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	has, _ := db.HasStateTrieNode(oldRoot[:])
+	assert.False(t, has)
+
+	// The state tries within the retention window are kept.
+	for _, block := range []*types.Block{blocks[35], blocks[39]} {
+		if _, err := state.New(block.Root(), state.NewDatabase(db), nil); err != nil {
+			t.Fatalf("failed to open state of block %d: %v", block.NumberU64(), err)
+		}
+	}
+	chain.Stop()
+
+	// Live pruning cannot be enabled on a database with blocks.
+	nonEmptyDB := database.NewMemoryDBManager()
+	new(Genesis).MustCommit(nonEmptyDB)
+	cacheConfig.LivePruning = false
+	chain, err = NewBlockChain(nonEmptyDB, cacheConfig, params.TestChainConfig, engine, vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks[:4]); err != nil {
+		t.Fatalf("failed to process block %d: %v", n, err)
+	}
+	chain.Stop()
+
+	cacheConfig.LivePruning = true
+	_, err = NewBlockChain(nonEmptyDB, cacheConfig, params.TestChainConfig, engine, vm.Config{})
+	assert.Equal(t, ErrLivePruningNonEmptyChain, err)
+}
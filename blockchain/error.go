@@ -49,6 +49,10 @@ var (
 	// one present in the local chain.
 	ErrNonceTooLow = errors.New("nonce too low")
 
+	// ErrGasPriceBelowBaseFee is returned if a transaction's gas price is below
+	// the base fee of the block after the magma fork.
+	ErrGasPriceBelowBaseFee = errors.New("gas price is below the base fee")
+
 	// ErrUnderpriced is returned if a transaction's gas price is below the minimum
 	// configured for the transaction pool.
 	ErrUnderpriced = errors.New("transaction underpriced")
@@ -95,6 +99,18 @@ var (
 	// ErrInvalidChainId is returned if the chain id of transaction is not equal to the chain id of the chain config.
 	ErrInvalidChainId = errors.New("invalid chain id")
 
+	// ErrSenderNotAllowed is returned if the sender of a transaction is not allowed by the admission policy of the tx pool.
+	ErrSenderNotAllowed = errors.New("sender not allowed")
+
+	// ErrRecipientNotAllowed is returned if the recipient of a transaction is not allowed by the admission policy of the tx pool.
+	ErrRecipientNotAllowed = errors.New("recipient not allowed")
+
+	// ErrTxTypeDisabled is returned if the type of a transaction is disabled by the admission policy of the tx pool.
+	ErrTxTypeDisabled = errors.New("tx type disabled")
+
+	// ErrSenderTxsExceeded is returned if the sender of a transaction has too many transactions in the tx pool.
+	ErrSenderTxsExceeded = errors.New("too many transactions of the sender in the tx pool")
+
 	// ErrNotYetImplementedAPI is returned if API is not yet implemented
 	ErrNotYetImplementedAPI = errors.New("not yet implemented API")
 
@@ -29,6 +29,27 @@ import (
 // NewTxsEvent is posted when a batch of transactions enter the transaction pool.
 type NewTxsEvent struct{ Txs []*types.Transaction }
 
+// TxPoolEventType is the type of a change of a transaction in the transaction pool.
+type TxPoolEventType string
+
+const (
+	TxPoolEventAdmitted TxPoolEventType = "admitted" // a transaction entered the pool
+	TxPoolEventPromoted TxPoolEventType = "promoted" // a transaction became executable
+	TxPoolEventReplaced TxPoolEventType = "replaced" // a transaction was replaced by another one with the same nonce
+	TxPoolEventEvicted  TxPoolEventType = "evicted"  // a transaction was removed from the pool without being executed
+)
+
+// TxPoolEvent is posted when a transaction is admitted, promoted, replaced or evicted
+// in the transaction pool. Reason describes why a transaction was replaced or evicted.
+// Note that the transactions included in a new block are also evicted as their nonces are too low.
+// Dropped is true if some events before this one were dropped since the subscribers were too slow.
+type TxPoolEvent struct {
+	Type    TxPoolEventType
+	Tx      *types.Transaction
+	Reason  string
+	Dropped bool
+}
+
 // PendingLogsEvent is posted pre mining and notifies of pending logs.
 type PendingLogsEvent struct {
 	Logs []*types.Log
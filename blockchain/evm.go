@@ -48,7 +48,7 @@ func NewEVMContext(msg Message, header *types.Header, chain ChainContext, author
 	} else {
 		beneficiary = *author
 	}
-	return vm.Context{
+	ctx := vm.Context{
 		CanTransfer: CanTransfer,
 		Transfer:    Transfer,
 		GetHash:     GetHashFn(header, chain),
@@ -59,6 +59,12 @@ func NewEVMContext(msg Message, header *types.Header, chain ChainContext, author
 		BlockScore:  new(big.Int).Set(header.BlockScore),
 		GasPrice:    new(big.Int).Set(msg.GasPrice()),
 	}
+	// After the magma fork, the effective gas price of every transaction is the base fee.
+	if header.BaseFee != nil {
+		ctx.BaseFee = new(big.Int).Set(header.BaseFee)
+		ctx.GasPrice = new(big.Int).Set(header.BaseFee)
+	}
+	return ctx
 }
 
 // GetHashFn returns a GetHashFunc which retrieves header hashes by number
@@ -0,0 +1,567 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package snapshot implements a flat account and storage layer which mirrors
+// the leaves of the state trie, keyed by the hashes of accounts and storage slots.
+// The flat layer is used to serve and download contiguous state ranges during
+// snap sync, and the state trie can be regenerated from it.
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+)
+
+var (
+	logger = log.NewModuleLogger(log.BlockchainSnapshot)
+
+	// emptyRoot is the known root hash of an empty trie.
+	emptyRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+	// errMissingTrieRoot is returned if the trie to be converted to the
+	// snapshot does not exist in the database.
+	errMissingTrieRoot = errors.New("missing state trie root")
+)
+
+const (
+	accountSnapshotKeyLength = 1 + common.HashLength
+	storageSnapshotKeyLength = 1 + 2*common.HashLength
+
+	// trieCommitInterval is the number of accounts after which the account trie
+	// being regenerated is flushed into the disk database.
+	trieCommitInterval = 100000
+)
+
+// Wipe deletes all the account and storage entries of the flat snapshot along
+// with the snapshot root marker.
+func Wipe(db database.DBManager) error {
+	db.DeleteSnapshotRoot()
+	return wipeContent(db)
+}
+
+// wipeContent deletes all the account and storage entries of the flat snapshot.
+func wipeContent(db database.DBManager) error {
+	for _, item := range []struct {
+		prefix []byte
+		keyLen int
+	}{
+		{database.SnapshotAccountPrefix, accountSnapshotKeyLength},
+		{database.SnapshotStoragePrefix, storageSnapshotKeyLength},
+	} {
+		if err := wipeKeyRange(db, item.prefix, nil, item.keyLen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wipeKeyRange deletes all the entries having the given prefix and key length,
+// starting from the given position.
+func wipeKeyRange(db database.DBManager, prefix []byte, start []byte, keyLen int) error {
+	var (
+		batch = db.NewBatch(database.SnapshotDB)
+		it    = db.NewSnapshotDBIterator(prefix, start)
+	)
+	defer it.Release()
+
+	for it.Next() {
+		// Skip any keys with the correct prefix but wrong length (trie nodes)
+		key := it.Key()
+		if len(key) != keyLen {
+			continue
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		if batch.ValueSize() > database.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Generate converts the state trie of the given root into the flat snapshot.
+// The snapshot root marker is removed during the conversion and written only
+// after all the entries are persisted, so an interrupted conversion leaves the
+// snapshot marked as invalid.
+func Generate(db database.DBManager, triedb *statedb.Database, root common.Hash) error {
+	accTrie, err := statedb.NewSecureTrie(root, triedb)
+	if err != nil {
+		return errMissingTrieRoot
+	}
+	db.DeleteSnapshotRoot()
+
+	var (
+		start    = time.Now()
+		accounts int
+		slots    int
+		batch    = db.NewBatch(database.SnapshotDB)
+		accIt    = statedb.NewIterator(accTrie.NodeIterator(nil))
+	)
+	flush := func(force bool) error {
+		if !force && batch.ValueSize() < database.IdealBatchSize {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	}
+	for accIt.Next() {
+		accHash := common.BytesToHash(accIt.Key)
+		db.PutAccountSnapshotToBatch(batch, accHash, accIt.Value)
+		accounts++
+
+		storageRoot, _, err := decodeProgramAccount(accIt.Value)
+		if err != nil {
+			return err
+		}
+		if !isEmptyStorage(storageRoot) {
+			storageTrie, err := statedb.NewSecureTrie(storageRoot, triedb)
+			if err != nil {
+				return err
+			}
+			storageIt := statedb.NewIterator(storageTrie.NodeIterator(nil))
+			for storageIt.Next() {
+				db.PutStorageSnapshotToBatch(batch, accHash, common.BytesToHash(storageIt.Key), storageIt.Value)
+				slots++
+				if err := flush(false); err != nil {
+					return err
+				}
+			}
+			if storageIt.Err != nil {
+				return storageIt.Err
+			}
+		}
+		if err := flush(false); err != nil {
+			return err
+		}
+	}
+	if accIt.Err != nil {
+		return accIt.Err
+	}
+	if err := flush(true); err != nil {
+		return err
+	}
+	db.WriteSnapshotRoot(root)
+
+	logger.Info("Generated state snapshot", "root", root, "accounts", accounts, "slots", slots,
+		"elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// ContractCallback is called for every program account while the state trie
+// is regenerated. complete reports whether the storage trie matching the
+// storage root of the account could be regenerated from the flat snapshot.
+type ContractCallback func(storageRoot, codeHash common.Hash, complete bool)
+
+// GenerateTrie regenerates the state trie from the flat snapshot, persisting
+// all the account and storage trie nodes into the disk database of triedb.
+// Program accounts are reported through onContract, so the caller can schedule
+// the retrieval of missing contract codes and storage tries. The root of the
+// generated trie is returned.
+func GenerateTrie(db database.DBManager, triedb *statedb.Database, onContract ContractCallback) (common.Hash, error) {
+	accTrie, err := statedb.NewTrie(common.Hash{}, triedb)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var (
+		start    = time.Now()
+		accounts int
+		slots    int
+		accIt    = db.NewSnapshotDBIterator(database.SnapshotAccountPrefix, nil)
+	)
+	defer accIt.Release()
+
+	for accIt.Next() {
+		key := accIt.Key()
+		if len(key) != accountSnapshotKeyLength {
+			continue
+		}
+		accHash := common.BytesToHash(key[1:])
+		storageRoot, codeHash, err := decodeProgramAccount(accIt.Value())
+		if err != nil {
+			return common.Hash{}, err
+		}
+		complete := true
+		if !isEmptyStorage(storageRoot) {
+			root, n, err := generateStorageTrie(db, triedb, accHash)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			if root != storageRoot {
+				// The flat storage may be stale or incomplete. The trie healing
+				// after the generation will fix up the inconsistency.
+				logger.Debug("Regenerated storage trie mismatches", "account", accHash, "expected", storageRoot, "got", root)
+				complete = false
+			}
+			slots += n
+		}
+		if onContract != nil && codeHash != (common.Hash{}) {
+			onContract(storageRoot, codeHash, complete)
+		}
+		if err := accTrie.TryUpdate(accHash[:], common.CopyBytes(accIt.Value())); err != nil {
+			return common.Hash{}, err
+		}
+		accounts++
+
+		// Flush the account trie periodically to keep the memory usage bounded.
+		if accounts%trieCommitInterval == 0 {
+			root, err := commitTrie(accTrie, triedb)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			if accTrie, err = statedb.NewTrie(root, triedb); err != nil {
+				return common.Hash{}, err
+			}
+			logger.Info("Regenerating state trie", "accounts", accounts, "slots", slots,
+				"elapsed", common.PrettyDuration(time.Since(start)))
+		}
+	}
+	if err := accIt.Error(); err != nil {
+		return common.Hash{}, err
+	}
+	root, err := commitTrie(accTrie, triedb)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	logger.Info("Regenerated state trie", "root", root, "accounts", accounts, "slots", slots,
+		"elapsed", common.PrettyDuration(time.Since(start)))
+	return root, nil
+}
+
+// generateStorageTrie regenerates and persists the storage trie of the given
+// account from the flat snapshot.
+func generateStorageTrie(db database.DBManager, triedb *statedb.Database, accHash common.Hash) (common.Hash, int, error) {
+	storageTrie, err := statedb.NewTrie(common.Hash{}, triedb)
+	if err != nil {
+		return common.Hash{}, 0, err
+	}
+	it := db.NewSnapshotDBIterator(database.StorageSnapshotsKey(accHash), nil)
+	defer it.Release()
+
+	slots := 0
+	for it.Next() {
+		key := it.Key()
+		if len(key) != storageSnapshotKeyLength {
+			continue
+		}
+		if err := storageTrie.TryUpdate(key[1+common.HashLength:], common.CopyBytes(it.Value())); err != nil {
+			return common.Hash{}, 0, err
+		}
+		slots++
+	}
+	if err := it.Error(); err != nil {
+		return common.Hash{}, 0, err
+	}
+	root, err := commitTrie(storageTrie, triedb)
+	return root, slots, err
+}
+
+// commitTrie commits the given trie into the memory database and flushes the
+// nodes into the disk database.
+func commitTrie(trie *statedb.Trie, triedb *statedb.Database) (common.Hash, error) {
+	root, err := trie.Commit(nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := triedb.Commit(root, false, 0); err != nil {
+		return common.Hash{}, err
+	}
+	return root, nil
+}
+
+// decodeProgramAccount decodes the given account snapshot entry and returns
+// the storage root and the code hash if the account is a program account.
+func decodeProgramAccount(enc []byte) (common.Hash, common.Hash, error) {
+	serializer := account.NewAccountSerializer()
+	if err := rlp.DecodeBytes(enc, serializer); err != nil {
+		return common.Hash{}, common.Hash{}, err
+	}
+	pa := account.GetProgramAccount(serializer.GetAccount())
+	if pa == nil {
+		return common.Hash{}, common.Hash{}, nil
+	}
+	return pa.GetStorageRoot(), common.BytesToHash(pa.GetCodeHash()), nil
+}
+
+// isEmptyStorage returns true if the given storage root denotes no storage.
+func isEmptyStorage(root common.Hash) bool {
+	return root == (common.Hash{}) || root == emptyRoot
+}
+
+// generatorStats is a collection of statistics gathered by the snapshot generator
+// for logging purposes.
+type generatorStats struct {
+	wiping   chan struct{} // Notification channel if wiping is in progress
+	start    time.Time     // Timestamp when generation started
+	accounts uint64        // Number of accounts indexed
+	slots    uint64        // Number of storage slots indexed
+}
+
+// log creates an contextual log with the given message and the context pulled
+// from the internally maintained statistics.
+func (gs *generatorStats) log(msg string, root common.Hash, marker []byte) {
+	ctx := []interface{}{"root", root}
+	if len(marker) > 0 {
+		ctx = append(ctx, "at", common.BytesToHash(marker))
+	}
+	ctx = append(ctx, "accounts", gs.accounts, "slots", gs.slots,
+		"elapsed", common.PrettyDuration(time.Since(gs.start)))
+	logger.Info(msg, ctx...)
+}
+
+// wipeSnapshot starts a goroutine to iterate over the entire key-value database
+// and delete all the data associated with the snapshot (accounts, storage).
+// The returned channel is closed when the wipe is done.
+func wipeSnapshot(db database.DBManager) chan struct{} {
+	wiper := make(chan struct{})
+	go func() {
+		if err := wipeContent(db); err != nil {
+			logger.Error("Failed to wipe state snapshot", "err", err) // Database close will trigger this
+			return
+		}
+		close(wiper)
+	}()
+	return wiper
+}
+
+// generateSnapshot regenerates a brand new snapshot based on an existing state
+// database and head block asynchronously. The snapshot is returned immediately
+// and generation is continued in the background until done.
+func generateSnapshot(diskdb database.DBManager, triedb *statedb.Database, cache int, root common.Hash) *diskLayer {
+	// Wipe any previously existing snapshot from the database
+	stats := &generatorStats{wiping: wipeSnapshot(diskdb), start: time.Now()}
+
+	// Create a new disk layer with an initialized state marker at zero
+	diskdb.DeleteSnapshotJournal()
+	diskdb.WriteSnapshotRoot(root)
+	journalProgress(diskdb, []byte{}, stats)
+
+	base := &diskLayer{
+		diskdb:     diskdb,
+		triedb:     triedb,
+		root:       root,
+		cache:      fastcache.New(cache * 1024 * 1024),
+		genMarker:  []byte{}, // Initialized but empty!
+		genPending: make(chan struct{}),
+		genAbort:   make(chan chan *generatorStats),
+	}
+	go base.generate(stats)
+	logger.Debug("Start snapshot generation", "root", root)
+	return base
+}
+
+// journalProgress persists the generator stats into the database to resume later.
+func journalProgress(db database.DBManager, marker []byte, stats *generatorStats) {
+	// Write out the generator marker. Note it's a standalone disk layer generator
+	// which is not mixed with journal. It's ok if the generator is persisted while
+	// journal is not.
+	entry := journalGenerator{
+		Done:   marker == nil,
+		Marker: marker,
+	}
+	if stats != nil {
+		entry.Wiping = stats.wiping != nil
+		entry.Accounts = stats.accounts
+		entry.Slots = stats.slots
+	}
+	blob, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		panic(err) // Cannot happen, here to catch dev errors
+	}
+	db.WriteSnapshotGenerator(blob)
+}
+
+// generate is a background thread that iterates over the state and storage tries,
+// constructing the state snapshot. All the arguments are purely for statistics
+// gathering and logging, since the method surfs the blocks as they arrive, often
+// being restarted.
+//
+// The generation progresses account by account. The marker points to the last
+// account whose storage slots are also generated completely.
+func (dl *diskLayer) generate(stats *generatorStats) {
+	if stats == nil {
+		stats = &generatorStats{start: time.Now()}
+	}
+	// If a database wipe is in operation, wait until it's done
+	if stats.wiping != nil {
+		stats.log("Wiper running, state snapshotting paused", dl.root, dl.genMarker)
+		select {
+		// If wiper is done, resume normal mode of operation
+		case <-stats.wiping:
+			stats.wiping = nil
+			stats.start = time.Now()
+
+		// If generator was aborted during wipe, return
+		case abort := <-dl.genAbort:
+			abort <- stats
+			return
+		}
+	}
+	// Create an account trie iterator and continue from the last marker
+	accTrie, err := statedb.NewSecureTrie(dl.root, dl.triedb)
+	if err != nil {
+		// The account trie is missing (GC), surf the chain until one becomes available
+		stats.log("Trie missing, state snapshotting paused", dl.root, dl.genMarker)
+
+		abort := <-dl.genAbort
+		abort <- stats
+		return
+	}
+	stats.log("Resuming state snapshot generation", dl.root, dl.genMarker)
+
+	// The storage slots beyond the marker may have been written partially before
+	// an interruption, delete them before resuming.
+	accMarker := dl.genMarker
+	if start, ok := nextHash(accMarker); ok {
+		if err := wipeKeyRange(dl.diskdb, database.SnapshotStoragePrefix, start, storageSnapshotKeyLength); err != nil {
+			logger.Error("Failed to wipe uncovered storage snapshot", "err", err)
+		}
+	}
+	var (
+		batch  = dl.diskdb.NewBatch(database.SnapshotDB)
+		logged = time.Now()
+		accIt  = statedb.NewIterator(accTrie.NodeIterator(accMarker))
+	)
+	flush := func(force bool) {
+		if !force && batch.ValueSize() < database.IdealBatchSize {
+			return
+		}
+		if err := batch.Write(); err != nil {
+			logger.Crit("Failed to write snapshot", "err", err)
+		}
+		batch.Reset()
+	}
+	for accIt.Next() {
+		accHash := common.BytesToHash(accIt.Key)
+
+		// The account at the marker was generated already, skip it
+		if bytes.Equal(accHash[:], accMarker) {
+			continue
+		}
+		dl.diskdb.PutAccountSnapshotToBatch(batch, accHash, accIt.Value)
+		stats.accounts++
+
+		// If the account is a program account, generate its storage slots too
+		storageRoot, _, err := decodeProgramAccount(accIt.Value)
+		if err != nil {
+			logger.Crit("Invalid account encountered during snapshot creation", "err", err)
+		}
+		if !isEmptyStorage(storageRoot) {
+			storageTrie, err := statedb.NewSecureTrie(storageRoot, dl.triedb)
+			if err != nil {
+				logger.Error("Generator failed to access storage trie", "root", dl.root, "account", accHash, "storage", storageRoot, "err", err)
+				abort := <-dl.genAbort
+				abort <- stats
+				return
+			}
+			storageIt := statedb.NewIterator(storageTrie.NodeIterator(nil))
+			for storageIt.Next() {
+				dl.diskdb.PutStorageSnapshotToBatch(batch, accHash, common.BytesToHash(storageIt.Key), storageIt.Value)
+				stats.slots++
+				flush(false)
+			}
+			if storageIt.Err != nil {
+				logger.Error("Generator failed to iterate storage trie", "root", dl.root, "account", accHash, "err", storageIt.Err)
+				abort := <-dl.genAbort
+				abort <- stats
+				return
+			}
+		}
+		// The account is generated completely, check for an abort request and
+		// persist the progress if the batch is large enough.
+		var abort chan *generatorStats
+		select {
+		case abort = <-dl.genAbort:
+		default:
+		}
+		if batch.ValueSize() > database.IdealBatchSize || abort != nil {
+			flush(true)
+
+			// Only now can we update the marker, since the account and all its
+			// storage slots are persisted.
+			dl.lock.Lock()
+			dl.genMarker = accHash[:]
+			dl.lock.Unlock()
+
+			journalProgress(dl.diskdb, dl.genMarker, stats)
+			if abort != nil {
+				stats.log("Aborting state snapshot generation", dl.root, dl.genMarker)
+				abort <- stats
+				return
+			}
+		}
+		if time.Since(logged) > 8*time.Second {
+			stats.log("Generating state snapshot", dl.root, accHash[:])
+			logged = time.Now()
+		}
+	}
+	if accIt.Err != nil {
+		logger.Error("Generator failed to iterate account trie", "root", dl.root, "err", accIt.Err)
+		abort := <-dl.genAbort
+		abort <- stats
+		return
+	}
+	// Snapshot fully generated, set the marker to nil
+	flush(true)
+	journalProgress(dl.diskdb, nil, stats)
+
+	logger.Info("Generated state snapshot", "accounts", stats.accounts, "slots", stats.slots,
+		"elapsed", common.PrettyDuration(time.Since(stats.start)))
+
+	dl.lock.Lock()
+	dl.genMarker = nil
+	close(dl.genPending)
+	dl.lock.Unlock()
+
+	// Someone will be looking for us, wait it out
+	abort := <-dl.genAbort
+	abort <- nil
+}
+
+// nextHash returns the hash right after the given marker, which is the start
+// position of the range not yet covered by the generator. False is returned if
+// there is no such position.
+func nextHash(marker []byte) ([]byte, bool) {
+	if len(marker) == 0 {
+		return nil, true
+	}
+	next := common.CopyBytes(marker)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next, true
+		}
+	}
+	return nil, false
+}
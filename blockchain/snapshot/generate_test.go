@@ -0,0 +1,154 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+)
+
+// makeTestState creates a state trie containing externally owned accounts and
+// smart contract accounts having storage, and returns the root of the trie
+// along with the code hash of the contracts.
+func makeTestState(t *testing.T, triedb *statedb.Database, numAccounts, numSlots int) (common.Hash, common.Hash) {
+	codeHash := crypto.Keccak256Hash([]byte("code"))
+
+	accTrie, _ := statedb.NewSecureTrie(common.Hash{}, triedb)
+	for i := 0; i < numAccounts; i++ {
+		values := map[account.AccountValueKeyType]interface{}{
+			account.AccountValueKeyNonce:   uint64(i),
+			account.AccountValueKeyBalance: big.NewInt(int64(i)),
+		}
+		accType := account.ExternallyOwnedAccountType
+		if i%2 == 0 {
+			storageTrie, _ := statedb.NewSecureTrie(common.Hash{}, triedb)
+			for j := 0; j < numSlots; j++ {
+				enc, _ := rlp.EncodeToBytes(big.NewInt(int64(i*numSlots + j + 1)).Bytes())
+				storageTrie.Update(common.BigToHash(big.NewInt(int64(j))).Bytes(), enc)
+			}
+			storageRoot, err := storageTrie.Commit(nil)
+			assert.NoError(t, err)
+
+			values[account.AccountValueKeyStorageRoot] = storageRoot
+			values[account.AccountValueKeyCodeHash] = codeHash.Bytes()
+			accType = account.SmartContractAccountType
+		}
+		acc, err := account.NewAccountWithMap(accType, values)
+		assert.NoError(t, err)
+
+		enc, err := rlp.EncodeToBytes(account.NewAccountSerializerWithAccount(acc))
+		assert.NoError(t, err)
+		accTrie.Update(common.BigToAddress(big.NewInt(int64(i))).Bytes(), enc)
+	}
+	root, err := accTrie.Commit(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, triedb.Commit(root, false, 0))
+	return root, codeHash
+}
+
+// countSnapshotEntries returns the number of account and storage snapshot entries.
+func countSnapshotEntries(db database.DBManager) (int, int) {
+	count := func(prefix []byte, keyLen int) int {
+		n := 0
+		it := db.NewSnapshotDBIterator(prefix, nil)
+		defer it.Release()
+		for it.Next() {
+			if len(it.Key()) == keyLen {
+				n++
+			}
+		}
+		return n
+	}
+	return count(database.SnapshotAccountPrefix, accountSnapshotKeyLength),
+		count(database.SnapshotStoragePrefix, storageSnapshotKeyLength)
+}
+
+func TestGenerateAndWipe(t *testing.T) {
+	db := database.NewMemoryDBManager()
+	triedb := statedb.NewDatabase(db)
+	root, _ := makeTestState(t, triedb, 100, 10)
+
+	assert.Equal(t, errMissingTrieRoot, Generate(db, triedb, common.HexToHash("0x1234")))
+
+	assert.NoError(t, Generate(db, triedb, root))
+	assert.Equal(t, root, db.ReadSnapshotRoot())
+
+	accounts, slots := countSnapshotEntries(db)
+	assert.Equal(t, 100, accounts)
+	assert.Equal(t, 50*10, slots)
+
+	assert.NoError(t, Wipe(db))
+	assert.Equal(t, common.Hash{}, db.ReadSnapshotRoot())
+
+	accounts, slots = countSnapshotEntries(db)
+	assert.Equal(t, 0, accounts)
+	assert.Equal(t, 0, slots)
+
+	// Make sure that the trie nodes are not removed with the snapshot.
+	_, err := statedb.NewSecureTrie(root, triedb)
+	assert.NoError(t, err)
+}
+
+func TestGenerateTrie(t *testing.T) {
+	srcDB := database.NewMemoryDBManager()
+	srcTrieDB := statedb.NewDatabase(srcDB)
+	root, codeHash := makeTestState(t, srcTrieDB, 100, 10)
+	assert.NoError(t, Generate(srcDB, srcTrieDB, root))
+
+	// Regenerate the trie into a fresh database from the snapshot.
+	dstDB := database.NewMemoryDBManager()
+	dstTrieDB := statedb.NewDatabase(dstDB)
+
+	codes := make(map[common.Hash]int)
+	genRoot, err := GenerateTrie(srcDB, dstTrieDB, func(storageRoot, hash common.Hash, complete bool) {
+		assert.True(t, complete)
+		codes[hash]++
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, root, genRoot)
+	assert.Equal(t, map[common.Hash]int{codeHash: 50}, codes)
+
+	// All the nodes of the source trie should exist in the destination.
+	dstTrieDB = statedb.NewDatabase(dstDB)
+	dstTrie, err := statedb.NewSecureTrie(root, dstTrieDB)
+	assert.NoError(t, err)
+
+	accIt := statedb.NewIterator(dstTrie.NodeIterator(nil))
+	for accIt.Next() {
+		storageRoot, _, err := decodeProgramAccount(accIt.Value)
+		assert.NoError(t, err)
+		if isEmptyStorage(storageRoot) {
+			continue
+		}
+		storageTrie, err := statedb.NewSecureTrie(storageRoot, dstTrieDB)
+		assert.NoError(t, err)
+
+		nodeIt := storageTrie.NodeIterator(nil)
+		for nodeIt.Next(true) {
+		}
+		assert.NoError(t, nodeIt.Error())
+	}
+	assert.NoError(t, accIt.Err)
+}
@@ -0,0 +1,325 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+)
+
+// journalVersion is the version of the snapshot journal format. A journal with
+// a different version is discarded on load.
+const journalVersion uint64 = 0
+
+// journalGenerator is a disk layer entry containing the generator progress marker.
+type journalGenerator struct {
+	Wiping   bool // Whether the database was in progress of being wiped
+	Done     bool // Whether the generator finished creating the snapshot
+	Marker   []byte
+	Accounts uint64
+	Slots    uint64
+}
+
+// journalDestruct is an account deletion entry in a diffLayer's disk journal.
+type journalDestruct struct {
+	Hash common.Hash
+}
+
+// journalAccount is an account entry in a diffLayer's disk journal.
+type journalAccount struct {
+	Hash common.Hash
+	Blob []byte
+}
+
+// journalStorage is an account's storage map in a diffLayer's disk journal.
+type journalStorage struct {
+	Hash common.Hash
+	Keys []common.Hash
+	Vals [][]byte
+}
+
+// loadSnapshot loads a pre-existing state snapshot backed by a key-value store.
+func loadSnapshot(diskdb database.DBManager, triedb *statedb.Database, cache int, root common.Hash) (snapshot, error) {
+	// Retrieve the block number and hash of the snapshot, failing if no snapshot
+	// is present in the database (or crashed mid-update).
+	baseRoot := diskdb.ReadSnapshotRoot()
+	if baseRoot == (common.Hash{}) {
+		return nil, errors.New("missing or corrupted snapshot")
+	}
+	base := &diskLayer{
+		diskdb: diskdb,
+		triedb: triedb,
+		cache:  fastcache.New(cache * 1024 * 1024),
+		root:   baseRoot,
+	}
+	generator, err := loadGenerator(diskdb)
+	if err != nil {
+		return nil, err
+	}
+	// Load all the diff layers from the journal. If the journal is broken or
+	// belongs to another disk layer, only the disk layer is used.
+	snap, err := loadDiffLayers(diskdb, base)
+	if err != nil {
+		logger.Warn("Failed to load snapshot journal, discarding diffs", "err", err)
+		snap = base
+	}
+	// Entire snapshot journal loaded, sanity check the head
+	if head := snap.Root(); head != root {
+		return nil, fmt.Errorf("head doesn't match snapshot: have %#x, want %#x", head, root)
+	}
+	// Everything loaded correctly, resume any suspended operations
+	if !generator.Done {
+		// Resume the generation from the persisted marker. If the database was
+		// still being wiped, the wiper is restarted first.
+		base.genMarker = generator.Marker
+		if base.genMarker == nil {
+			base.genMarker = []byte{}
+		}
+		base.genPending = make(chan struct{})
+		base.genAbort = make(chan chan *generatorStats)
+
+		var wiper chan struct{}
+		if generator.Wiping {
+			wiper = wipeSnapshot(diskdb)
+		}
+		go base.generate(&generatorStats{
+			wiping:   wiper,
+			start:    time.Now(),
+			accounts: generator.Accounts,
+			slots:    generator.Slots,
+		})
+	}
+	return snap, nil
+}
+
+// loadGenerator loads the generator progress marker of the disk layer.
+func loadGenerator(diskdb database.DBManager) (*journalGenerator, error) {
+	blob := diskdb.ReadSnapshotGenerator()
+	if len(blob) == 0 {
+		return nil, errors.New("missing snapshot generator")
+	}
+	var generator journalGenerator
+	if err := rlp.DecodeBytes(blob, &generator); err != nil {
+		return nil, fmt.Errorf("failed to load snapshot generator: %v", err)
+	}
+	return &generator, nil
+}
+
+// loadDiffLayers loads the diff layers persisted in the journal on top of the
+// given disk layer.
+func loadDiffLayers(diskdb database.DBManager, base *diskLayer) (snapshot, error) {
+	journal := diskdb.ReadSnapshotJournal()
+	if len(journal) == 0 {
+		return nil, errors.New("missing snapshot journal")
+	}
+	r := rlp.NewStream(bytes.NewReader(journal), 0)
+
+	// Firstly, resolve the version and the disk layer root of the journal.
+	var version uint64
+	if err := r.Decode(&version); err != nil {
+		return nil, err
+	}
+	if version != journalVersion {
+		return nil, fmt.Errorf("journal version mismatch: have %d, want %d", version, journalVersion)
+	}
+	var root common.Hash
+	if err := r.Decode(&root); err != nil {
+		return nil, err
+	}
+	// The journal is not matched with disk layer, which happens if the node
+	// crashed after flattening diffs into the disk layer.
+	if root != base.root {
+		return nil, fmt.Errorf("journal is not matched with disk layer: have %#x, want %#x", root, base.root)
+	}
+	var snap snapshot = base
+	for {
+		next, err := loadDiffLayer(snap, r)
+		if err == io.EOF {
+			return snap, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		snap = next
+	}
+}
+
+// loadDiffLayer reads the next sections of a snapshot journal, reconstructing a
+// new diff layer on top of the given parent. io.EOF is returned if there are no
+// more layers in the journal.
+func loadDiffLayer(parent snapshot, r *rlp.Stream) (snapshot, error) {
+	// Read the next diff journal entry
+	var root common.Hash
+	if err := r.Decode(&root); err != nil {
+		// The first read may fail with EOF, marking the end of the journal
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("load diff root: %v", err)
+	}
+	var destructs []journalDestruct
+	if err := r.Decode(&destructs); err != nil {
+		return nil, fmt.Errorf("load diff destructs: %v", err)
+	}
+	destructSet := make(map[common.Hash]struct{})
+	for _, entry := range destructs {
+		destructSet[entry.Hash] = struct{}{}
+	}
+	var accounts []journalAccount
+	if err := r.Decode(&accounts); err != nil {
+		return nil, fmt.Errorf("load diff accounts: %v", err)
+	}
+	accountData := make(map[common.Hash][]byte)
+	for _, entry := range accounts {
+		if len(entry.Blob) > 0 { // RLP loses nil-ness, but `[]byte{}` is not a valid item, so reinterpret that
+			accountData[entry.Hash] = entry.Blob
+		} else {
+			accountData[entry.Hash] = nil
+		}
+	}
+	var storage []journalStorage
+	if err := r.Decode(&storage); err != nil {
+		return nil, fmt.Errorf("load diff storage: %v", err)
+	}
+	storageData := make(map[common.Hash]map[common.Hash][]byte)
+	for _, entry := range storage {
+		slots := make(map[common.Hash][]byte)
+		for i, key := range entry.Keys {
+			if len(entry.Vals[i]) > 0 { // RLP loses nil-ness, but `[]byte{}` is not a valid item, so reinterpret that
+				slots[key] = entry.Vals[i]
+			} else {
+				slots[key] = nil
+			}
+		}
+		storageData[entry.Hash] = slots
+	}
+	return newDiffLayer(parent, root, destructSet, accountData, storageData), nil
+}
+
+// journal writes the diff hierarchy ending at the given layer into the disk
+// journal, returning the root of the disk layer.
+func journal(diskdb database.DBManager, snap snapshot) (common.Hash, error) {
+	// Firstly write out the metadata of journal
+	buffer := new(bytes.Buffer)
+	if err := rlp.Encode(buffer, journalVersion); err != nil {
+		return common.Hash{}, err
+	}
+	// Find the disk layer at the bottom, its root is the first item of the journal
+	var disk snapshot = snap
+	for disk.Parent() != nil {
+		disk = disk.Parent()
+	}
+	if err := rlp.Encode(buffer, disk.Root()); err != nil {
+		return common.Hash{}, err
+	}
+	// Secondly write out the disk layer and the diff layers on top of it
+	base, err := snap.Journal(buffer)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	// Store the journal into the database and return
+	diskdb.WriteSnapshotJournal(buffer.Bytes())
+	return base, nil
+}
+
+// Journal terminates any in-progress snapshot generation and persists the
+// generator progress. The disk layer itself is already in the database, so
+// nothing is written into the buffer.
+func (dl *diskLayer) Journal(buffer *bytes.Buffer) (common.Hash, error) {
+	// If the snapshot is currently being generated, abort it
+	var stats *generatorStats
+	if dl.genAbort != nil {
+		abort := make(chan *generatorStats)
+		dl.genAbort <- abort
+
+		if stats = <-abort; stats != nil {
+			stats.log("Journalling in-progress snapshot", dl.root, dl.genMarker)
+		}
+		dl.genAbort = nil
+	}
+	// Ensure the layer didn't get stale
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return common.Hash{}, ErrSnapshotStale
+	}
+	// Ensure the generator stats is written even if none was ran this cycle
+	journalProgress(dl.diskdb, dl.genMarker, stats)
+
+	logger.Debug("Journalled disk layer", "root", dl.root)
+	return dl.root, nil
+}
+
+// Journal writes the memory layer contents into a buffer to be stored in the
+// database as the snapshot journal.
+func (dl *diffLayer) Journal(buffer *bytes.Buffer) (common.Hash, error) {
+	// Journal the parent first
+	base, err := dl.parent.Journal(buffer)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	// Ensure the layer didn't get stale
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.Stale() {
+		return common.Hash{}, ErrSnapshotStale
+	}
+	// Everything below was journalled, persist this layer too
+	if err := rlp.Encode(buffer, dl.root); err != nil {
+		return common.Hash{}, err
+	}
+	destructs := make([]journalDestruct, 0, len(dl.destructSet))
+	for hash := range dl.destructSet {
+		destructs = append(destructs, journalDestruct{Hash: hash})
+	}
+	if err := rlp.Encode(buffer, destructs); err != nil {
+		return common.Hash{}, err
+	}
+	accounts := make([]journalAccount, 0, len(dl.accountData))
+	for hash, blob := range dl.accountData {
+		accounts = append(accounts, journalAccount{Hash: hash, Blob: blob})
+	}
+	if err := rlp.Encode(buffer, accounts); err != nil {
+		return common.Hash{}, err
+	}
+	storage := make([]journalStorage, 0, len(dl.storageData))
+	for hash, slots := range dl.storageData {
+		keys := make([]common.Hash, 0, len(slots))
+		vals := make([][]byte, 0, len(slots))
+		for key, val := range slots {
+			keys = append(keys, key)
+			vals = append(vals, val)
+		}
+		storage = append(storage, journalStorage{Hash: hash, Keys: keys, Vals: vals})
+	}
+	if err := rlp.Encode(buffer, storage); err != nil {
+		return common.Hash{}, err
+	}
+	logger.Debug("Journalled diff layer", "root", dl.root, "parent", dl.parent.Root())
+	return base, nil
+}
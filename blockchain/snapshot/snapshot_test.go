@@ -0,0 +1,171 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTree creates a state trie and a snapshot tree fully generated from it.
+func newTestTree(t *testing.T) (database.DBManager, *statedb.Database, *Tree, common.Hash) {
+	db := database.NewMemoryDBManager()
+	triedb := statedb.NewDatabase(db)
+	root, _ := makeTestState(t, triedb, 100, 10)
+
+	tree := New(db, triedb, 16, root, false)
+	assert.NotNil(t, tree.Snapshot(root))
+	return db, triedb, tree, root
+}
+
+func testAccountHash(i int) common.Hash {
+	return crypto.Keccak256Hash(common.BigToAddress(big.NewInt(int64(i))).Bytes())
+}
+
+func testSlotHash(j int) common.Hash {
+	return crypto.Keccak256Hash(common.BigToHash(big.NewInt(int64(j))).Bytes())
+}
+
+func TestTreeGenerate(t *testing.T) {
+	_, triedb, tree, root := newTestTree(t)
+	snap := tree.Snapshot(root)
+
+	accTrie, err := statedb.NewSecureTrie(root, triedb)
+	assert.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i)))
+		expected, err := accTrie.TryGet(addr.Bytes())
+		assert.NoError(t, err)
+
+		blob, err := snap.Account(testAccountHash(i))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, blob)
+	}
+	// The contract at index 0 has 10 slots, the value of slot j is j+1
+	for j := 0; j < 10; j++ {
+		blob, err := snap.Storage(testAccountHash(0), testSlotHash(j))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, blob)
+	}
+	// Missing entries are returned as empty ones
+	blob, err := snap.Account(common.HexToHash("0x1234"))
+	assert.NoError(t, err)
+	assert.Empty(t, blob)
+}
+
+func TestTreeUpdateAndCap(t *testing.T) {
+	db, _, tree, root := newTestTree(t)
+
+	var (
+		root1 = common.HexToHash("0x01")
+		root2 = common.HexToHash("0x02")
+		root3 = common.HexToHash("0x03")
+	)
+	// Layer 1 modifies an account and a slot, layer 2 destructs the contract,
+	// layer 3 resurrects it with a single slot.
+	assert.NoError(t, tree.Update(root1, root, nil,
+		map[common.Hash][]byte{testAccountHash(1): []byte("account1")},
+		map[common.Hash]map[common.Hash][]byte{testAccountHash(0): {testSlotHash(0): []byte("slot0")}}))
+	assert.NoError(t, tree.Update(root2, root1, map[common.Hash]struct{}{testAccountHash(0): {}}, nil, nil))
+	assert.NoError(t, tree.Update(root3, root2, nil,
+		map[common.Hash][]byte{testAccountHash(0): []byte("account0")},
+		map[common.Hash]map[common.Hash][]byte{testAccountHash(0): {testSlotHash(1): []byte("slot1")}}))
+	assert.Equal(t, errSnapshotCycle, tree.Update(root3, root3, nil, nil, nil))
+
+	// Each layer returns the state of its own
+	blob, _ := tree.Snapshot(root1).Storage(testAccountHash(0), testSlotHash(0))
+	assert.Equal(t, []byte("slot0"), blob)
+	blob, _ = tree.Snapshot(root2).Account(testAccountHash(0))
+	assert.Empty(t, blob)
+	blob, _ = tree.Snapshot(root3).Storage(testAccountHash(0), testSlotHash(0))
+	assert.Empty(t, blob)
+	blob, _ = tree.Snapshot(root3).Storage(testAccountHash(0), testSlotHash(1))
+	assert.Equal(t, []byte("slot1"), blob)
+	blob, _ = tree.Snapshot(root3).Account(testAccountHash(1))
+	assert.Equal(t, []byte("account1"), blob)
+
+	// Keeping two layers flattens the bottom ones. The flattened layer is
+	// persisted right away, since the generator of the disk layer is still alive.
+	assert.NoError(t, tree.Cap(root3, 2))
+	assert.Nil(t, tree.Snapshot(root))
+	assert.Equal(t, root1, tree.DiskRoot())
+	blob, _ = tree.Snapshot(root3).Account(testAccountHash(1))
+	assert.Equal(t, []byte("account1"), blob)
+
+	// Flattening everything persists the diffs into the disk layer
+	assert.NoError(t, tree.Cap(root3, 0))
+	assert.Equal(t, root3, db.ReadSnapshotRoot())
+	assert.Equal(t, root3, tree.DiskRoot())
+	assert.Nil(t, tree.Snapshot(root2))
+
+	assert.Equal(t, []byte("account0"), db.ReadAccountSnapshot(testAccountHash(0)))
+	assert.Equal(t, []byte("account1"), db.ReadAccountSnapshot(testAccountHash(1)))
+	assert.Empty(t, db.ReadStorageSnapshot(testAccountHash(0), testSlotHash(0)))
+	assert.Equal(t, []byte("slot1"), db.ReadStorageSnapshot(testAccountHash(0), testSlotHash(1)))
+
+	accounts, slots := countSnapshotEntries(db)
+	assert.Equal(t, 100, accounts)
+	assert.Equal(t, 49*10+1, slots)
+}
+
+func TestTreeJournal(t *testing.T) {
+	db, triedb, tree, root := newTestTree(t)
+
+	var (
+		root1 = common.HexToHash("0x01")
+		root2 = common.HexToHash("0x02")
+	)
+	assert.NoError(t, tree.Update(root1, root, map[common.Hash]struct{}{testAccountHash(2): {}},
+		map[common.Hash][]byte{testAccountHash(1): []byte("account1")}, nil))
+	assert.NoError(t, tree.Update(root2, root1, nil, nil,
+		map[common.Hash]map[common.Hash][]byte{testAccountHash(0): {testSlotHash(0): nil}}))
+
+	base, err := tree.Journal(root2)
+	assert.NoError(t, err)
+	assert.Equal(t, root, base)
+
+	// Reload the tree, the diff layers should be restored from the journal
+	loaded := New(db, triedb, 16, root2, false)
+	for _, r := range []common.Hash{root, root1, root2} {
+		assert.NotNil(t, loaded.Snapshot(r))
+	}
+	blob, err := loaded.Snapshot(root2).Account(testAccountHash(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("account1"), blob)
+
+	blob, err = loaded.Snapshot(root2).Account(testAccountHash(2))
+	assert.NoError(t, err)
+	assert.Empty(t, blob)
+
+	blob, err = loaded.Snapshot(root2).Storage(testAccountHash(0), testSlotHash(0))
+	assert.NoError(t, err)
+	assert.Empty(t, blob)
+
+	// Loading with an unknown head regenerates the snapshot from the trie
+	regenerated := New(db, triedb, 16, root, false)
+	assert.Nil(t, regenerated.Snapshot(root2))
+	blob, err = regenerated.Snapshot(root).Account(testAccountHash(1))
+	assert.NoError(t, err)
+	assert.NotEqual(t, []byte("account1"), blob)
+}
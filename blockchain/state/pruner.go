@@ -0,0 +1,177 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/steakknife/bloomfilter"
+)
+
+const (
+	pruningLogInterval       = 8 * time.Second // Interval to log the progress of the state pruning
+	pruningBloomHashFuncsNum = 4               // Number of hash functions of the state pruning bloom
+)
+
+var (
+	errPruningInMigration = errors.New("state pruning is not supported while the state migration is in progress")
+	errPruningLivePruning = errors.New("state pruning is not supported for the database with the live pruning")
+	errPruningNoRoot      = errors.New("no state root to retain")
+	errPruningNotIterable = errors.New("state trie database does not support iteration")
+)
+
+// stateBloomHasher is a wrapper around a byte blob to satisfy the interface API
+// requirements of the bloom library used. It's used to convert a trie hash into
+// a 64 bit mini hash.
+type stateBloomHasher []byte
+
+func (f stateBloomHasher) Write(p []byte) (n int, err error) { panic("not implemented") }
+func (f stateBloomHasher) Sum(b []byte) []byte               { panic("not implemented") }
+func (f stateBloomHasher) Reset()                            { panic("not implemented") }
+func (f stateBloomHasher) BlockSize() int                    { panic("not implemented") }
+func (f stateBloomHasher) Size() int                         { return 8 }
+func (f stateBloomHasher) Sum64() uint64                     { return binary.BigEndian.Uint64(f) }
+
+// Pruner deletes the state trie nodes which are not reachable from the given
+// state roots. The reachable nodes are marked in a bloom filter, so the pruning
+// runs in a limited memory at the expense of keeping a few unreachable nodes
+// by false positives. The database must not be used by others while pruning.
+type Pruner struct {
+	db    database.DBManager
+	bloom *bloomfilter.Filter
+}
+
+// NewPruner creates a state pruner with a bloom filter of the given size (in megabytes).
+func NewPruner(db database.DBManager, bloomSize uint64) (*Pruner, error) {
+	if db.InMigration() {
+		return nil, errPruningInMigration
+	}
+	if db.ReadPruningEnabled() {
+		return nil, errPruningLivePruning
+	}
+	bloom, err := bloomfilter.New(bloomSize*1024*1024*8, pruningBloomHashFuncsNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bloom: %v", err)
+	}
+	logger.Info("Allocated state pruning bloom", "size", common.StorageSize(bloomSize*1024*1024))
+	return &Pruner{db: db, bloom: bloom}, nil
+}
+
+// Prune marks all trie nodes and contract codes reachable from the given roots,
+// and deletes the others from the state trie database.
+func (p *Pruner) Prune(roots []common.Hash) error {
+	if len(roots) == 0 {
+		return errPruningNoRoot
+	}
+	start := time.Now()
+	for _, root := range roots {
+		if err := p.mark(root); err != nil {
+			return err
+		}
+	}
+	logger.Info("Marked reachable state trie nodes", "roots", len(roots),
+		"falsePositiveRate", math.Pow(p.bloom.PreciseFilledRatio(), float64(p.bloom.K())),
+		"elapsed", common.PrettyDuration(time.Since(start)))
+
+	return p.sweep()
+}
+
+// mark adds the hashes of all trie nodes and contract codes reachable from the
+// given root to the bloom filter.
+func (p *Pruner) mark(root common.Hash) error {
+	stateDB, err := New(root, NewDatabase(p.db), nil)
+	if err != nil {
+		return err
+	}
+	var (
+		it     = NewNodeIterator(stateDB)
+		nodes  = 0
+		logged = time.Now()
+	)
+	for it.Next() {
+		if it.Hash == (common.Hash{}) {
+			continue
+		}
+		p.bloom.Add(stateBloomHasher(it.Hash[:]))
+		nodes++
+		if time.Since(logged) > pruningLogInterval {
+			logger.Info("Marking state trie nodes", "root", root, "nodes", nodes)
+			logged = time.Now()
+		}
+	}
+	if it.Error != nil {
+		return it.Error
+	}
+	logger.Info("Marked state trie", "root", root, "nodes", nodes)
+	return nil
+}
+
+// sweep deletes the trie nodes and contract codes which are not marked in the
+// bloom filter. Only the entries whose keys are the hashes of their values are
+// deleted, so that other data sharing the same database are never touched.
+func (p *Pruner) sweep() error {
+	it := p.db.GetStateTrieDB().NewIterator(nil, nil)
+	if it == nil {
+		return errPruningNotIterable
+	}
+	defer it.Release()
+
+	var (
+		batch   = p.db.NewBatch(database.StateTrieDB)
+		start   = time.Now()
+		logged  = time.Now()
+		checked = 0
+		deleted = 0
+		size    common.StorageSize
+	)
+	for it.Next() {
+		checked++
+		key := it.Key()
+		if len(key) == common.HashLength && !p.bloom.Contains(stateBloomHasher(key)) &&
+			crypto.Keccak256Hash(it.Value()) == common.BytesToHash(key) {
+			if err := batch.Delete(common.CopyBytes(key)); err != nil {
+				return err
+			}
+			if _, err := database.WriteBatchesOverThreshold(batch); err != nil {
+				return err
+			}
+			deleted++
+			size += common.StorageSize(len(key) + len(it.Value()))
+		}
+		if time.Since(logged) > pruningLogInterval {
+			logger.Info("Sweeping stale state trie nodes", "checked", checked, "deleted", deleted, "size", size,
+				"elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if _, err := database.WriteBatches(batch); err != nil {
+		return err
+	}
+	logger.Info("Pruned state trie nodes", "checked", checked, "deleted", deleted, "size", size,
+		"elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
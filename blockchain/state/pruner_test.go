@@ -0,0 +1,78 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	db, root, accounts := makeTestState(t)
+	diskDB := db.TrieDB().DiskDB()
+
+	// Update a part of the state to make some nodes of the old state stale.
+	state, err := New(root, db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, acc := range accounts {
+		if i%10 != 0 {
+			continue
+		}
+		acc.balance = new(big.Int).Add(acc.balance, big.NewInt(1))
+		state.AddBalance(acc.address, big.NewInt(1))
+		if len(acc.storageMap) > 0 {
+			key, value := common.Hash{byte(i) + 100}, common.Hash{1}
+			acc.storageMap[key] = value
+			state.SetState(acc.address, key, value)
+		}
+	}
+	newRoot, err := state.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.TrieDB().Commit(newRoot, false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	pruner, err := NewPruner(diskDB, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pruner.Prune([]common.Hash{newRoot}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stale nodes are deleted while the retained state is intact.
+	if has, _ := diskDB.HasStateTrieNode(root[:]); has {
+		t.Errorf("stale state root %x is not pruned", root)
+	}
+	checkStateAccounts(t, diskDB, newRoot, accounts)
+}
+
+func TestPruner_LivePruning(t *testing.T) {
+	diskDB := database.NewMemoryDBManager()
+	diskDB.WritePruningEnabled()
+
+	if _, err := NewPruner(diskDB, 1); err != errPruningLivePruning {
+		t.Errorf("unexpected error: have %v, want %v", err, errPruningLivePruning)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/klaytn/klaytn/blockchain/snapshot"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/blockchain/types/accountkey"
@@ -62,6 +63,12 @@ type StateDB struct {
 	db   Database
 	trie Trie
 
+	snaps         *snapshot.Tree
+	snap          snapshot.Snapshot
+	snapDestructs map[common.Hash]struct{}
+	snapAccounts  map[common.Hash][]byte
+	snapStorage   map[common.Hash]map[common.Hash][]byte
+
 	// This map holds 'live' objects, which will get modified while processing a state transition.
 	stateObjects             map[common.Address]*stateObject
 	stateObjectsDirty        map[common.Address]struct{}
@@ -104,32 +111,37 @@ type StateDB struct {
 }
 
 // Create a new state from a given trie.
-func New(root common.Hash, db Database) (*StateDB, error) {
+// If snaps is given, the state is read from the flat snapshot of the root first.
+func New(root common.Hash, db Database, snaps *snapshot.Tree) (*StateDB, error) {
 	tr, err := db.OpenTrie(root)
 	if err != nil {
 		return nil, err
 	}
-	return &StateDB{
+	sdb := &StateDB{
 		db:                       db,
 		trie:                     tr,
+		snaps:                    snaps,
 		stateObjects:             make(map[common.Address]*stateObject),
 		stateObjectsDirtyStorage: make(map[common.Address]struct{}),
 		stateObjectsDirty:        make(map[common.Address]struct{}),
 		logs:                     make(map[common.Hash][]*types.Log),
 		preimages:                make(map[common.Hash][]byte),
 		journal:                  newJournal(),
-	}, nil
+	}
+	sdb.openSnapshot(root)
+	return sdb, nil
 }
 
 // Create a new state from a given trie with prefetching
-func NewForPrefetching(root common.Hash, db Database) (*StateDB, error) {
+func NewForPrefetching(root common.Hash, db Database, snaps *snapshot.Tree) (*StateDB, error) {
 	tr, err := db.OpenTrieForPrefetching(root)
 	if err != nil {
 		return nil, err
 	}
-	return &StateDB{
+	sdb := &StateDB{
 		db:                       db,
 		trie:                     tr,
+		snaps:                    snaps,
 		stateObjects:             make(map[common.Address]*stateObject),
 		stateObjectsDirtyStorage: make(map[common.Address]struct{}),
 		stateObjectsDirty:        make(map[common.Address]struct{}),
@@ -137,7 +149,23 @@ func NewForPrefetching(root common.Hash, db Database) (*StateDB, error) {
 		preimages:                make(map[common.Hash][]byte),
 		journal:                  newJournal(),
 		prefetching:              true,
-	}, nil
+	}
+	sdb.openSnapshot(root)
+	return sdb, nil
+}
+
+// openSnapshot retrieves the snapshot layer of the given root, if any, and
+// resets the snapshot modifications tracked for it.
+func (self *StateDB) openSnapshot(root common.Hash) {
+	self.snap, self.snapDestructs, self.snapAccounts, self.snapStorage = nil, nil, nil, nil
+	if self.snaps == nil {
+		return
+	}
+	if self.snap = self.snaps.Snapshot(root); self.snap != nil {
+		self.snapDestructs = make(map[common.Hash]struct{})
+		self.snapAccounts = make(map[common.Hash][]byte)
+		self.snapStorage = make(map[common.Hash]map[common.Hash][]byte)
+	}
 }
 
 // RLockGCCachedNode locks the GC lock of CachedNode.
@@ -178,6 +206,7 @@ func (self *StateDB) Reset(root common.Hash) error {
 	self.logSize = 0
 	self.preimages = make(map[common.Hash][]byte)
 	self.clearJournalAndRefund()
+	self.openSnapshot(root)
 	return nil
 }
 
@@ -437,6 +466,15 @@ func (self *StateDB) SetState(addr common.Address, key, value common.Hash) {
 	}
 }
 
+// SetStorage replaces the entire storage for the specified account with given
+// storage. This function should only be used for debugging.
+func (self *StateDB) SetStorage(addr common.Address, storage map[common.Hash]common.Hash) {
+	stateObject := self.GetOrNewSmartContract(addr)
+	if stateObject != nil {
+		stateObject.SetStorage(storage)
+	}
+}
+
 // UpdateKey updates the account's key with the given key.
 func (self *StateDB) UpdateKey(addr common.Address, newKey accountkey.AccountKey, currentBlockNumber uint64) error {
 	stateObject := self.getStateObject(addr)
@@ -487,12 +525,26 @@ func (self *StateDB) updateStateObject(stateObject *stateObject) {
 		self.setError(self.trie.TryUpdateWithKeys(addr[:],
 			encodedData.trieHashKey, encodedData.trieHexKey, encodedData.data))
 		stateObject.encoded = atomic.Value{}
+		self.updateSnapshotAccount(stateObject, encodedData.data)
 	} else {
 		data, err := rlp.EncodeToBytes(stateObject)
 		if err != nil {
 			panic(fmt.Errorf("can't encode object at %x: %v", addr[:], err))
 		}
 		self.setError(self.trie.TryUpdate(addr[:], data))
+		self.updateSnapshotAccount(stateObject, data)
+	}
+}
+
+// updateSnapshotAccount tracks the given account update to be applied to the
+// snapshot on commit.
+func (self *StateDB) updateSnapshotAccount(stateObject *stateObject, data []byte) {
+	// If state snapshotting is active, cache the data til commit. Note, this
+	// update mechanism is not symmetric to the deletion, because whereas it is
+	// enough to track account updates at commit time, deletions need tracking
+	// at transaction boundary level to ensure we capture state clearing.
+	if self.snap != nil {
+		self.snapAccounts[stateObject.addrHash] = data
 	}
 }
 
@@ -505,6 +557,16 @@ func (self *StateDB) deleteStateObject(stateObject *stateObject) {
 	stateObject.deleted = true
 	addr := stateObject.Address()
 	self.setError(self.trie.TryDelete(addr[:]))
+
+	// If state snapshotting is active, also mark the destruction there.
+	// Note, we can't do this only at the end of a block because multiple
+	// transactions within the same block might self destruct and then
+	// resurrect an account; but the snapshotter needs both events.
+	if self.snap != nil {
+		self.snapDestructs[stateObject.addrHash] = struct{}{} // We need to maintain account deletions explicitly (will remain set indefinitely)
+		delete(self.snapAccounts, stateObject.addrHash)       // Clear out any previously updated account data (may be recreated via a resurrect)
+		delete(self.snapStorage, stateObject.addrHash)        // Clear out any previously updated storage data (may be recreated via a resurrect)
+	}
 }
 
 // Retrieve a state object given by the address. Returns nil if not found.
@@ -521,8 +583,18 @@ func (self *StateDB) getStateObject(addr common.Address) *stateObject {
 		defer func(start time.Time) { self.AccountReads += time.Since(start) }(time.Now())
 	}
 	// Second, the object for given address is not cached.
-	// Load the object from the database.
-	enc, err := self.trie.TryGet(addr[:])
+	// Load the object from the snapshot if available, or from the database.
+	var (
+		enc []byte
+		err error
+	)
+	if self.snap != nil {
+		enc, err = self.snap.Account(crypto.Keccak256Hash(addr[:]))
+	}
+	// If snapshot unavailable or reading from it failed, load from the database
+	if self.snap == nil || err != nil {
+		enc, err = self.trie.TryGet(addr[:])
+	}
 	if len(enc) == 0 {
 		self.setError(err)
 		return nil
@@ -574,12 +646,19 @@ func (self *StateDB) createObject(addr common.Address) (newobj, prev *stateObjec
 	if err != nil {
 		logger.Error("An error occurred on call NewAccountWithType", "err", err)
 	}
+	var prevdestruct bool
+	if self.snap != nil && prev != nil {
+		_, prevdestruct = self.snapDestructs[prev.addrHash]
+		if !prevdestruct {
+			self.snapDestructs[prev.addrHash] = struct{}{}
+		}
+	}
 	newobj = newObject(self, addr, acc)
 	newobj.setNonce(0) // sets the object to dirty
 	if prev == nil {
 		self.journal.append(createObjectChange{account: &addr})
 	} else {
-		self.journal.append(resetObjectChange{prev: prev})
+		self.journal.append(resetObjectChange{prev: prev, prevdestruct: prevdestruct})
 	}
 	self.setStateObject(newobj)
 	return newobj, prev
@@ -595,12 +674,19 @@ func (self *StateDB) createObjectWithMap(addr common.Address, accountType accoun
 	if err != nil {
 		logger.Error("An error occurred on call NewAccountWithMap", "err", err)
 	}
+	var prevdestruct bool
+	if self.snap != nil && prev != nil {
+		_, prevdestruct = self.snapDestructs[prev.addrHash]
+		if !prevdestruct {
+			self.snapDestructs[prev.addrHash] = struct{}{}
+		}
+	}
 	newobj = newObject(self, addr, acc)
 	newobj.setNonce(0) // sets the object to dirty
 	if prev == nil {
 		self.journal.append(createObjectChange{account: &addr})
 	} else {
-		self.journal.append(resetObjectChange{prev: prev})
+		self.journal.append(resetObjectChange{prev: prev, prevdestruct: prevdestruct})
 	}
 	self.setStateObject(newobj)
 	return newobj, prev
@@ -714,6 +800,31 @@ func (self *StateDB) Copy() *StateDB {
 		state.preimages[hash] = preimage
 	}
 
+	if self.snaps != nil {
+		// In order for the block proposer to be able to use and make additions
+		// to the snapshot tree, we need to copy that as well.
+		// Otherwise, any block proposed by ourselves will cause gaps in the tree,
+		// and force the proposer to operate trie-backed only
+		state.snaps = self.snaps
+		state.snap = self.snap
+		// deep copy needed
+		state.snapDestructs = make(map[common.Hash]struct{})
+		for k, v := range self.snapDestructs {
+			state.snapDestructs[k] = v
+		}
+		state.snapAccounts = make(map[common.Hash][]byte)
+		for k, v := range self.snapAccounts {
+			state.snapAccounts[k] = v
+		}
+		state.snapStorage = make(map[common.Hash]map[common.Hash][]byte)
+		for k, v := range self.snapStorage {
+			temp := make(map[common.Hash][]byte)
+			for kk, vv := range v {
+				temp[kk] = vv
+			}
+			state.snapStorage[k] = temp
+		}
+	}
 	return state
 }
 
@@ -886,7 +997,20 @@ func (s *StateDB) Commit(deleteEmptyObjects bool) (root common.Hash, err error)
 		}
 		return nil
 	})
-	return root, err
+	if err != nil {
+		return common.Hash{}, err
+	}
+	// If snapshotting is enabled, update the snapshot tree with this new version
+	if s.snap != nil {
+		// Only update if there's a state transition (skip empty blocks)
+		if parent := s.snap.Root(); parent != root {
+			if err := s.snaps.Update(root, parent, s.snapDestructs, s.snapAccounts, s.snapStorage); err != nil {
+				logger.Warn("Failed to update snapshot tree", "from", parent, "to", root, "err", err)
+			}
+		}
+		s.snap, s.snapDestructs, s.snapAccounts, s.snapStorage = nil, nil, nil, nil
+	}
+	return root, nil
 }
 
 // GetTxHash returns the hash of current running transaction.
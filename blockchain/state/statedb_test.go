@@ -32,8 +32,10 @@ import (
 	"testing"
 	"testing/quick"
 
+	"github.com/klaytn/klaytn/blockchain/snapshot"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/storage/statedb"
@@ -46,7 +48,7 @@ func TestUpdateLeaks(t *testing.T) {
 	// Create an empty state database
 	memDBManager := database.NewMemoryDBManager()
 	db := memDBManager.GetMemDB()
-	state, _ := New(common.Hash{}, NewDatabase(memDBManager))
+	state, _ := New(common.Hash{}, NewDatabase(memDBManager), nil)
 
 	// Update it with some accounts
 	for i := byte(0); i < 255; i++ {
@@ -79,8 +81,8 @@ func TestIntermediateLeaks(t *testing.T) {
 	transDb := transDBManager.GetMemDB()
 	finalDb := finalDBManager.GetMemDB()
 
-	transState, _ := New(common.Hash{}, NewDatabase(transDBManager))
-	finalState, _ := New(common.Hash{}, NewDatabase(finalDBManager))
+	transState, _ := New(common.Hash{}, NewDatabase(transDBManager), nil)
+	finalState, _ := New(common.Hash{}, NewDatabase(finalDBManager), nil)
 
 	modify := func(state *StateDB, addr common.Address, i, tweak byte) {
 		if i%2 == 0 {
@@ -133,7 +135,7 @@ func TestIntermediateLeaks(t *testing.T) {
 // https://github.com/ethereum/go-ethereum/pull/15549.
 func TestCopy(t *testing.T) {
 	// Create a random state test to copy and modify "independently"
-	orig, _ := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()))
+	orig, _ := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()), nil)
 
 	for i := byte(0); i < 255; i++ {
 		obj := orig.GetOrNewStateObject(common.BytesToAddress([]byte{i}))
@@ -195,7 +197,7 @@ func TestSnapshotRandom(t *testing.T) {
 // TestStateObjects tests basic functional operations of StateObjects.
 // It will be updated by StateDB.Commit() with state objects in StateDB.stateObjects.
 func TestStateObjects(t *testing.T) {
-	stateDB, _ := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()))
+	stateDB, _ := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()), nil)
 
 	// Update each account, it will update StateDB.stateObjects.
 	for i := byte(0); i < 128; i++ {
@@ -364,7 +366,7 @@ func (test *snapshotTest) String() string {
 func (test *snapshotTest) run() bool {
 	// Run all actions and create snapshots.
 	var (
-		state, _     = New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()))
+		state, _     = New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()), nil)
 		snapshotRevs = make([]int, len(test.snapshots))
 		sindex       = 0
 	)
@@ -378,7 +380,7 @@ func (test *snapshotTest) run() bool {
 	// Revert all snapshots in reverse order. Each revert must yield a state
 	// that is equivalent to fresh state with all actions up the snapshot applied.
 	for sindex--; sindex >= 0; sindex-- {
-		checkstate, _ := New(common.Hash{}, state.Database())
+		checkstate, _ := New(common.Hash{}, state.Database(), nil)
 		for _, action := range test.actions[:test.snapshots[sindex]] {
 			action.fn(action, checkstate)
 		}
@@ -457,7 +459,7 @@ func (s *StateSuite) TestSnapshotWithJournalDirties(c *check.C) {
 // TestCopyOfCopy tests that modified objects are carried over to the copy, and the copy of the copy.
 // See https://github.com/ethereum/go-ethereum/pull/15225#issuecomment-380191512
 func TestCopyOfCopy(t *testing.T) {
-	sdb, _ := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()))
+	sdb, _ := New(common.Hash{}, NewDatabase(database.NewMemoryDBManager()), nil)
 	addr := common.HexToAddress("aaaa")
 	sdb.SetBalance(addr, big.NewInt(42))
 
@@ -484,3 +486,73 @@ func TestZeroHashNode(t *testing.T) {
 		t.Fatalf("node should return nil value for zero hash")
 	}
 }
+
+// Tests that the state read from the snapshot tree is the same as the one read
+// from the state trie, including the storage of a destructed account.
+func TestStateDBWithSnapshot(t *testing.T) {
+	memDBManager := database.NewMemoryDBManager()
+	sdb := NewDatabase(memDBManager)
+
+	var (
+		contract = common.BytesToAddress([]byte{0xca})
+		eoa      = common.BytesToAddress([]byte{0xea})
+		newEOA   = common.BytesToAddress([]byte{0xeb})
+		slot     = func(i byte) common.Hash { return common.BytesToHash([]byte{i}) }
+	)
+	state, _ := New(common.Hash{}, sdb, nil)
+	state.CreateSmartContractAccount(contract, params.CodeFormatEVM)
+	state.SetCode(contract, []byte{1, 2, 3})
+	for i := byte(0); i < 10; i++ {
+		state.SetState(contract, slot(i), slot(i+1))
+	}
+	state.AddBalance(eoa, big.NewInt(100))
+	root, err := state.Commit(false)
+	assert.NoError(t, err)
+	assert.NoError(t, sdb.TrieDB().Commit(root, false, 0))
+
+	snaps := snapshot.New(memDBManager, sdb.TrieDB(), 16, root, false)
+
+	// Modify the state on top of the generated snapshot
+	state, _ = New(root, sdb, snaps)
+	assert.Equal(t, big.NewInt(100), state.GetBalance(eoa))
+	assert.Equal(t, slot(3), state.GetState(contract, slot(2)))
+
+	state.AddBalance(eoa, big.NewInt(1))
+	state.AddBalance(newEOA, big.NewInt(7))
+	state.SetState(contract, slot(0), common.Hash{})
+	state.SetState(contract, slot(1), slot(0xff))
+	root2, err := state.Commit(false)
+	assert.NoError(t, err)
+
+	// The modifications are kept in a diff layer of the snapshot tree
+	snap := snaps.Snapshot(root2)
+	assert.NotNil(t, snap)
+	enc, err := snap.Account(crypto.Keccak256Hash(newEOA[:]))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, enc)
+
+	snapState, _ := New(root2, sdb, snaps)
+	trieState, _ := New(root2, sdb, nil)
+	for _, s := range []*StateDB{snapState, trieState} {
+		assert.Equal(t, big.NewInt(101), s.GetBalance(eoa))
+		assert.Equal(t, big.NewInt(7), s.GetBalance(newEOA))
+		assert.Equal(t, common.Hash{}, s.GetState(contract, slot(0)))
+		assert.Equal(t, slot(0xff), s.GetState(contract, slot(1)))
+		assert.Equal(t, slot(3), s.GetState(contract, slot(2)))
+		assert.Equal(t, []byte{1, 2, 3}, s.GetCode(contract))
+	}
+
+	// Destruct the contract, its storage should not be read from the snapshot
+	state, _ = New(root2, sdb, snaps)
+	state.Suicide(contract)
+	root3, err := state.Commit(true)
+	assert.NoError(t, err)
+
+	snapState, _ = New(root3, sdb, snaps)
+	assert.False(t, snapState.Exist(contract))
+	assert.Equal(t, common.Hash{}, snapState.GetState(contract, slot(2)))
+
+	enc, err = snaps.Snapshot(root3).Storage(crypto.Keccak256Hash(contract[:]), crypto.Keccak256Hash(slot(2).Bytes()))
+	assert.NoError(t, err)
+	assert.Empty(t, enc)
+}
@@ -221,6 +221,15 @@ func (st *StateTransition) preCheck() error {
 			return ErrNonceTooLow
 		}
 	}
+	// After the magma fork, the gas price should cover the base fee which is charged instead.
+	if st.evm.BaseFee != nil {
+		if st.gasPrice.Cmp(st.evm.BaseFee) < 0 {
+			logger.Debug(ErrGasPriceBelowBaseFee.Error(), "gasPrice", st.gasPrice, "baseFee", st.evm.BaseFee,
+				"txHash", st.msg.Hash().String())
+			return ErrGasPriceBelowBaseFee
+		}
+		st.gasPrice = st.evm.BaseFee
+	}
 	return st.buyGas()
 }
 
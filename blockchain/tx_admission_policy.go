@@ -0,0 +1,202 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/rcrowley/go-metrics"
+)
+
+var notAdmittedTxCounter = metrics.NewRegisteredCounter("txpool/notadmitted", nil)
+
+// TxAdmissionPolicy decides whether a transaction can be admitted into the TxPool.
+// The policies are checked after a transaction passes the validation of the TxPool,
+// so they don't need to validate the transaction itself.
+type TxAdmissionPolicy interface {
+	// Name returns the name of the policy to be shown in logs.
+	Name() string
+
+	// Admit returns an error if the transaction must not be admitted into the TxPool.
+	Admit(tx *types.Transaction, ctx *TxAdmissionContext) error
+}
+
+// TxAdmissionContext is the state of the TxPool given to TxAdmissionPolicy.
+type TxAdmissionContext struct {
+	From        common.Address // Validated sender of the transaction
+	PoolTxs     int            // Number of the transactions of the sender in the pool
+	Replacement bool           // Whether the transaction replaces one of the sender with the same nonce
+	BlockNumber uint64         // Current block number of the pool
+}
+
+// newTxAdmissionPolicies returns the built-in policies enabled by the configuration.
+func newTxAdmissionPolicies(config *TxPoolConfig) []TxAdmissionPolicy {
+	var policies []TxAdmissionPolicy
+	if len(config.AllowedSenders) > 0 || len(config.DeniedSenders) > 0 {
+		policies = append(policies, NewSenderListPolicy(config.AllowedSenders, config.DeniedSenders))
+	}
+	if len(config.AllowedRecipients) > 0 || len(config.DeniedRecipients) > 0 {
+		policies = append(policies, NewRecipientListPolicy(config.AllowedRecipients, config.DeniedRecipients))
+	}
+	if len(config.DisabledTxTypes) > 0 {
+		policies = append(policies, NewTxTypePolicy(config.DisabledTxTypes))
+	}
+	if config.MaxTxsPerSender > 0 {
+		policies = append(policies, NewSenderTxsCapPolicy(config.MaxTxsPerSender))
+	}
+	return policies
+}
+
+// AddAdmissionPolicy registers a policy checked for the transactions newly added to the pool.
+// It is supposed to be called at the startup of a node, before the pool receives transactions.
+func (pool *TxPool) AddAdmissionPolicy(policy TxAdmissionPolicy) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.admissionPolicies = append(pool.admissionPolicies, policy)
+	logger.Info("Registered a transaction admission policy", "policy", policy.Name())
+}
+
+// checkAdmissionPolicies returns the error of the first policy not admitting the transaction.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) checkAdmissionPolicies(tx *types.Transaction, from common.Address) error {
+	if len(pool.admissionPolicies) == 0 {
+		return nil
+	}
+	ctx := &TxAdmissionContext{From: from, BlockNumber: pool.currentBlockNumber}
+	if list := pool.pending[from]; list != nil {
+		ctx.PoolTxs += list.Len()
+		ctx.Replacement = ctx.Replacement || list.txs.Get(tx.Nonce()) != nil
+	}
+	if list := pool.queue[from]; list != nil {
+		ctx.PoolTxs += list.Len()
+		ctx.Replacement = ctx.Replacement || list.txs.Get(tx.Nonce()) != nil
+	}
+	for _, policy := range pool.admissionPolicies {
+		if err := policy.Admit(tx, ctx); err != nil {
+			logger.Trace("Transaction not admitted by a policy", "policy", policy.Name(), "hash", tx.Hash(), "from", from, "err", err)
+			notAdmittedTxCounter.Inc(1)
+			return err
+		}
+	}
+	return nil
+}
+
+// accountListPolicy admits the transactions whose accounts are in the allow list, if given,
+// and are not in the deny list.
+type accountListPolicy struct {
+	name    string
+	allowed map[common.Address]struct{}
+	denied  map[common.Address]struct{}
+	account func(tx *types.Transaction, ctx *TxAdmissionContext) *common.Address
+	err     error
+}
+
+// NewSenderListPolicy returns a policy admitting the transactions whose senders are in
+// the allow list, if it is not empty, and are not in the deny list.
+func NewSenderListPolicy(allowed, denied []common.Address) TxAdmissionPolicy {
+	return newAccountListPolicy("sender list", allowed, denied, ErrSenderNotAllowed,
+		func(tx *types.Transaction, ctx *TxAdmissionContext) *common.Address { return &ctx.From })
+}
+
+// NewRecipientListPolicy returns a policy admitting the transactions whose recipients are in
+// the allow list, if it is not empty, and are not in the deny list. The transactions without
+// a recipient, such as smart contract deployments, are not restricted by the policy.
+func NewRecipientListPolicy(allowed, denied []common.Address) TxAdmissionPolicy {
+	return newAccountListPolicy("recipient list", allowed, denied, ErrRecipientNotAllowed,
+		func(tx *types.Transaction, ctx *TxAdmissionContext) *common.Address { return tx.To() })
+}
+
+func newAccountListPolicy(name string, allowed, denied []common.Address, err error,
+	account func(tx *types.Transaction, ctx *TxAdmissionContext) *common.Address) *accountListPolicy {
+	policy := &accountListPolicy{
+		name:    name,
+		allowed: make(map[common.Address]struct{}, len(allowed)),
+		denied:  make(map[common.Address]struct{}, len(denied)),
+		account: account,
+		err:     err,
+	}
+	for _, addr := range allowed {
+		policy.allowed[addr] = struct{}{}
+	}
+	for _, addr := range denied {
+		policy.denied[addr] = struct{}{}
+	}
+	return policy
+}
+
+func (p *accountListPolicy) Name() string { return p.name }
+
+func (p *accountListPolicy) Admit(tx *types.Transaction, ctx *TxAdmissionContext) error {
+	addr := p.account(tx, ctx)
+	if addr == nil {
+		return nil
+	}
+	if _, ok := p.denied[*addr]; ok {
+		return p.err
+	}
+	if _, ok := p.allowed[*addr]; len(p.allowed) > 0 && !ok {
+		return p.err
+	}
+	return nil
+}
+
+// txTypePolicy rejects the transactions of the disabled types.
+type txTypePolicy struct {
+	disabled map[types.TxType]struct{}
+}
+
+// NewTxTypePolicy returns a policy rejecting the transactions of the given types.
+func NewTxTypePolicy(disabled []types.TxType) TxAdmissionPolicy {
+	policy := &txTypePolicy{disabled: make(map[types.TxType]struct{}, len(disabled))}
+	for _, txType := range disabled {
+		policy.disabled[txType] = struct{}{}
+	}
+	return policy
+}
+
+func (p *txTypePolicy) Name() string { return "tx type" }
+
+func (p *txTypePolicy) Admit(tx *types.Transaction, ctx *TxAdmissionContext) error {
+	if _, ok := p.disabled[tx.Type()]; ok {
+		return fmt.Errorf("%w: %s", ErrTxTypeDisabled, tx.Type())
+	}
+	return nil
+}
+
+// senderTxsCapPolicy limits the number of the transactions of a sender in the pool.
+type senderTxsCapPolicy struct {
+	max uint64
+}
+
+// NewSenderTxsCapPolicy returns a policy rejecting the transactions of a sender who already
+// has max transactions in the pool. The transactions replacing ones in the pool are admitted.
+func NewSenderTxsCapPolicy(max uint64) TxAdmissionPolicy {
+	return &senderTxsCapPolicy{max: max}
+}
+
+func (p *senderTxsCapPolicy) Name() string { return "sender txs cap" }
+
+func (p *senderTxsCapPolicy) Admit(tx *types.Transaction, ctx *TxAdmissionContext) error {
+	if !ctx.Replacement && uint64(ctx.PoolTxs) >= p.max {
+		return ErrSenderTxsExceeded
+	}
+	return nil
+}
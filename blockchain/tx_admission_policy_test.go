@@ -0,0 +1,122 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupTxPoolWithPolicies creates a pool with the given configuration and funded accounts.
+func setupTxPoolWithPolicies(config TxPoolConfig, keys ...*ecdsa.PrivateKey) *TxPool {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	for _, key := range keys {
+		statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	}
+	return NewTxPool(config, params.TestChainConfig, &testBlockChain{statedb, 1000000, new(event.Feed)})
+}
+
+func TestTxAdmissionPolicy_AccountLists(t *testing.T) {
+	allowedKey, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+	deniedKey, _ := crypto.GenerateKey()
+
+	config := testTxPoolConfig
+	config.AllowedSenders = []common.Address{crypto.PubkeyToAddress(allowedKey.PublicKey), crypto.PubkeyToAddress(deniedKey.PublicKey)}
+	config.DeniedSenders = []common.Address{crypto.PubkeyToAddress(deniedKey.PublicKey)}
+	config.DeniedRecipients = []common.Address{common.HexToAddress("0xAAAA")}
+	pool := setupTxPoolWithPolicies(config, allowedKey, otherKey, deniedKey)
+	defer pool.Stop()
+
+	// The deny list takes precedence over the allow list.
+	assert.Equal(t, ErrSenderNotAllowed, pool.AddRemote(transaction(0, 100000, otherKey)))
+	assert.Equal(t, ErrSenderNotAllowed, pool.AddRemote(transaction(0, 100000, deniedKey)))
+	assert.Equal(t, ErrRecipientNotAllowed, pool.AddRemote(transaction(0, 100000, allowedKey)))
+
+	signer := types.NewEIP155Signer(params.TestChainConfig.ChainID)
+	tx, _ := types.SignTx(types.NewTransaction(0, common.HexToAddress("0xBBBB"), big.NewInt(100), 100000, big.NewInt(1), nil), signer, allowedKey)
+	assert.NoError(t, pool.AddRemote(tx))
+
+	// Contract creations have no recipient to be checked.
+	tx, _ = types.SignTx(types.NewContractCreation(1, big.NewInt(0), 100000, big.NewInt(1), nil), signer, allowedKey)
+	assert.NoError(t, pool.AddRemote(tx))
+}
+
+func TestTxAdmissionPolicy_TxTypes(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+
+	config := testTxPoolConfig
+	config.DisabledTxTypes = []types.TxType{types.TxTypeLegacyTransaction}
+	pool := setupTxPoolWithPolicies(config, key)
+	defer pool.Stop()
+
+	err := pool.AddRemote(transaction(0, 100000, key))
+	assert.True(t, errors.Is(err, ErrTxTypeDisabled), err)
+}
+
+func TestTxAdmissionPolicy_SenderTxsCap(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+
+	config := testTxPoolConfig
+	config.MaxTxsPerSender = 2
+	pool := setupTxPoolWithPolicies(config, key)
+	defer pool.Stop()
+
+	assert.NoError(t, pool.AddRemote(transaction(0, 100000, key)))
+	assert.NoError(t, pool.AddRemote(transaction(5, 100000, key)))
+	assert.Equal(t, ErrSenderTxsExceeded, pool.AddRemote(transaction(1, 100000, key)))
+
+	// A replacement is not limited by the cap, but by the replacement rule of the pool.
+	assert.Equal(t, ErrAlreadyNonceExistInPool, pool.AddRemote(pricedTransaction(5, 110000, big.NewInt(1), key)))
+}
+
+// testAdmissionPolicy rejects the transactions with the given nonce.
+type testAdmissionPolicy struct{ nonce uint64 }
+
+var errTestAdmissionPolicy = errors.New("rejected by test policy")
+
+func (p *testAdmissionPolicy) Name() string { return "test" }
+
+func (p *testAdmissionPolicy) Admit(tx *types.Transaction, ctx *TxAdmissionContext) error {
+	if tx.Nonce() == p.nonce {
+		return errTestAdmissionPolicy
+	}
+	return nil
+}
+
+func TestTxAdmissionPolicy_Custom(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	pool := setupTxPoolWithPolicies(testTxPoolConfig, key)
+	defer pool.Stop()
+
+	pool.AddAdmissionPolicy(&testAdmissionPolicy{nonce: 1})
+
+	assert.NoError(t, pool.AddRemote(transaction(0, 100000, key)))
+	assert.Equal(t, errTestAdmissionPolicy, pool.AddRemote(transaction(1, 100000, key)))
+	assert.NoError(t, pool.AddRemote(transaction(2, 100000, key)))
+}
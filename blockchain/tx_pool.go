@@ -48,6 +48,22 @@ const (
 	txMsgChSize = 100
 	// MaxTxDataSize is a heuristic limit of tx data size, and txPool rejects transactions over 32KB to prevent DOS attacks.
 	MaxTxDataSize = 32 * 1024
+	// txPoolEventChanSize is the number of TxPoolEvents can be queued before being sent to the subscribers.
+	txPoolEventChanSize = 4096
+)
+
+// Reasons of the transaction evictions and replacements posted by TxPoolEvent.
+const (
+	evictReasonNonceTooLow       = "nonce too low"
+	evictReasonUnexecutable      = "insufficient funds or invalid"
+	evictReasonAccountSlots      = "account slots exceeded"
+	evictReasonPoolSlots         = "pool slots exceeded"
+	evictReasonLifetime          = "lifetime exceeded"
+	evictReasonPoolFull          = "txpool is full"
+	evictReasonUnderpriced       = "underpriced"
+	evictReasonNonceAlreadyInUse = "nonce already in pool"
+	evictReasonDropped           = "dropped by admin"
+	replaceReasonPrefix          = "replaced by "
 )
 
 var (
@@ -76,6 +92,9 @@ var (
 	invalidTxCounter     = metrics.NewRegisteredCounter("txpool/invalid", nil)
 	underpricedTxCounter = metrics.NewRegisteredCounter("txpool/underpriced", nil)
 	refusedTxCounter     = metrics.NewRegisteredCounter("txpool/refuse", nil)
+
+	// TxPoolEvents not sent because the subscribers are too slow
+	txPoolEventDropCounter = metrics.NewRegisteredCounter("txpool/event/drop", nil)
 )
 
 // TxStatus is the current status of a transaction as seen by the pool.
@@ -106,6 +125,10 @@ type TxPoolConfig struct {
 	Journal            string        // Journal of local transactions to survive node restarts
 	JournalInterval    time.Duration // Time interval to regenerate the local transaction journal
 
+	Persist         bool          // Whether all pending and queued transactions are stored in the database to survive node restarts
+	PersistInterval time.Duration // Time interval to store the transactions of the pool in the database
+	MirrorSource    string        // RPC endpoint of a node whose transaction pool is mirrored into this pool (empty = disabled)
+
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
 
@@ -118,6 +141,14 @@ type TxPoolConfig struct {
 	Lifetime   time.Duration // Maximum amount of time non-executable transaction are queued
 
 	NoAccountCreation bool // Whether account creation transactions should be disabled
+
+	// Built-in transaction admission policies
+	AllowedSenders    []common.Address // Senders allowed to add transactions (empty = all senders)
+	DeniedSenders     []common.Address // Senders not allowed to add transactions
+	AllowedRecipients []common.Address // Recipients allowed to receive transactions (empty = all recipients)
+	DeniedRecipients  []common.Address // Recipients not allowed to receive transactions
+	DisabledTxTypes   []types.TxType   // Transaction types not allowed to be added
+	MaxTxsPerSender   uint64           // Maximum number of transactions of a sender in the pool (0 = no limit)
 }
 
 // DefaultTxPoolConfig contains the default configurations for the transaction
@@ -126,6 +157,8 @@ var DefaultTxPoolConfig = TxPoolConfig{
 	Journal:         "transactions.rlp",
 	JournalInterval: time.Hour,
 
+	PersistInterval: time.Minute,
+
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -146,6 +179,10 @@ func (config *TxPoolConfig) sanitize() TxPoolConfig {
 		logger.Error("Sanitizing invalid txpool journal time", "provided", conf.JournalInterval, "updated", time.Second)
 		conf.JournalInterval = time.Second
 	}
+	if conf.PersistInterval < time.Second {
+		logger.Error("Sanitizing invalid txpool persist time", "provided", conf.PersistInterval, "updated", time.Second)
+		conf.PersistInterval = time.Second
+	}
 	if conf.PriceLimit < 1 {
 		logger.Error("Sanitizing invalid txpool price limit", "provided", conf.PriceLimit, "updated", DefaultTxPoolConfig.PriceLimit)
 		conf.PriceLimit = DefaultTxPoolConfig.PriceLimit
@@ -169,6 +206,7 @@ type TxPool struct {
 	chainconfig  *params.ChainConfig
 	chain        blockChain
 	gasPrice     *big.Int
+	baseFee      *big.Int // base fee of the current head, nil before the magma hard fork
 	txFeed       event.Feed
 	scope        event.SubscriptionScope
 	chainHeadCh  chan ChainHeadEvent
@@ -183,6 +221,8 @@ type TxPool struct {
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
 
+	persistence *txPoolPersistence // Storage of all transactions of the pool, nil if disabled
+
 	//TODO-Klaytn
 	txMu sync.RWMutex
 
@@ -195,6 +235,12 @@ type TxPool struct {
 	wg sync.WaitGroup // for shutdown sync
 
 	txMsgCh chan types.Transactions
+
+	txPoolEventFeed    event.Feed
+	txPoolEventCh      chan TxPoolEvent
+	txPoolEventDropped bool // whether an event was dropped after the last queued one
+
+	admissionPolicies []TxAdmissionPolicy // Policies checked for newly added transactions
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -217,11 +263,13 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		chainHeadCh:  make(chan ChainHeadEvent, chainHeadChanSize),
 		// TODO-Klaytn We use ChainConfig.UnitPrice to initialize TxPool.gasPrice,
 		//         later we have to change this rule when governance of UnitPrice is determined.
-		gasPrice: new(big.Int).SetUint64(chainconfig.UnitPrice),
-		txMsgCh:  make(chan types.Transactions, txMsgChSize),
+		gasPrice:      new(big.Int).SetUint64(chainconfig.UnitPrice),
+		txMsgCh:       make(chan types.Transactions, txMsgChSize),
+		txPoolEventCh: make(chan TxPoolEvent, txPoolEventChanSize),
 	}
 	pool.locals = newAccountSet(pool.signer)
 	pool.priced = newTxPricedList(&pool.all)
+	pool.admissionPolicies = newTxAdmissionPolicies(&config)
 	pool.reset(nil, chain.CurrentBlock().Header())
 
 	// If local transactions and journaling is enabled, load from disk
@@ -239,9 +287,10 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 	pool.chainHeadSub = pool.chain.SubscribeChainHeadEvent(pool.chainHeadCh)
 
 	// Start the event loop and return
-	pool.wg.Add(2)
+	pool.wg.Add(3)
 	go pool.loop()
 	go pool.handleTxMsg()
+	go pool.handleTxPoolEvent()
 
 	return pool
 }
@@ -318,7 +367,7 @@ func (pool *TxPool) loop() {
 				if time.Since(beat) > pool.config.Lifetime {
 					if pool.queue[addr] != nil {
 						for _, tx := range pool.queue[addr].Flatten() {
-							pool.removeTx(tx.Hash(), true)
+							pool.evictTx(tx.Hash(), true, evictReasonLifetime)
 						}
 					}
 					delete(pool.beats, addr)
@@ -410,6 +459,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.currentState = stateDB
 	pool.pendingNonce = make(map[common.Address]uint64)
 	pool.currentBlockNumber = newHead.Number.Uint64()
+	pool.baseFee = newHead.BaseFee
 
 	// Inject any transactions discarded due to reorgs
 	logger.Debug("Reinjecting stale transactions", "count", len(reinject))
@@ -453,6 +503,9 @@ func (pool *TxPool) Stop() {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if pool.persistence != nil {
+		pool.persistence.stop(pool)
+	}
 	logger.Info("Transaction pool stopped")
 }
 
@@ -462,11 +515,49 @@ func (pool *TxPool) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscripti
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeTxPoolEvent registers a subscription of TxPoolEvent and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeTxPoolEvent(ch chan<- TxPoolEvent) event.Subscription {
+	return pool.scope.Track(pool.txPoolEventFeed.Subscribe(ch))
+}
+
+// postTxPoolEvent queues a TxPoolEvent to be sent to the subscribers.
+// The event is dropped if the queue is full not to block the pool, and the next queued
+// event is marked to let the subscribers know that they missed some events.
+// It should be called with the pool lock held.
+func (pool *TxPool) postTxPoolEvent(typ TxPoolEventType, tx *types.Transaction, reason string) {
+	select {
+	case pool.txPoolEventCh <- TxPoolEvent{Type: typ, Tx: tx, Reason: reason, Dropped: pool.txPoolEventDropped}:
+		pool.txPoolEventDropped = false
+	default:
+		pool.txPoolEventDropped = true
+		txPoolEventDropCounter.Inc(1)
+	}
+}
+
+// handleTxPoolEvent sends the queued TxPoolEvents to the subscribers.
+func (pool *TxPool) handleTxPoolEvent() {
+	defer pool.wg.Done()
+
+	for {
+		select {
+		case ev := <-pool.txPoolEventCh:
+			pool.txPoolEventFeed.Send(ev)
+		case <-pool.chainHeadSub.Err():
+			return
+		}
+	}
+}
+
 // GasPrice returns the current gas price enforced by the transaction pool.
+// After the magma hard fork, it is the base fee of the current head.
 func (pool *TxPool) GasPrice() *big.Int {
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
 
+	if pool.baseFee != nil {
+		return new(big.Int).Set(pool.baseFee)
+	}
 	return new(big.Int).Set(pool.gasPrice)
 }
 
@@ -532,6 +623,24 @@ func (pool *TxPool) Content() (map[common.Address]types.Transactions, map[common
 	return pending, queued
 }
 
+// ContentFrom retrieves the data content of the transaction pool for the given
+// account, returning its pending as well as queued transactions sorted by nonce.
+func (pool *TxPool) ContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.txMu.Lock()
+	defer pool.txMu.Unlock()
+
+	var pending, queued types.Transactions
+	if list := pool.pending[addr]; list != nil {
+		pending = list.Flatten()
+	}
+	if list := pool.queue[addr]; list != nil {
+		queued = list.Flatten()
+	}
+	return pending, queued
+}
+
 // Pending retrieves all currently processable transactions, groupped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -616,8 +725,14 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidChainId
 	}
 
-	// NOTE-Klaytn Drop transactions with unexpected gasPrice
-	if pool.gasPrice.Cmp(tx.GasPrice()) != 0 {
+	// NOTE-Klaytn After the magma hard fork, drop transactions paying less than the base fee.
+	// Before it, drop transactions with unexpected gasPrice.
+	if pool.baseFee != nil {
+		if tx.GasPrice().Cmp(pool.baseFee) < 0 {
+			logger.Trace("fail to validate gas price", "baseFee", pool.baseFee, "tx gasPrice", tx.GasPrice())
+			return ErrGasPriceBelowBaseFee
+		}
+	} else if pool.gasPrice.Cmp(tx.GasPrice()) != 0 {
 		logger.Trace("fail to validate unitprice", "Klaytn unitprice", pool.gasPrice, "tx unitprice", tx.GasPrice())
 		return ErrInvalidUnitPrice
 	}
@@ -711,7 +826,8 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 		return err
 	}
 
-	return nil
+	// Check the admission policies registered by the node
+	return pool.checkAdmissionPolicies(tx, from)
 }
 
 // getMaxTxFromQueueWhenNonceIsMissing finds and returns a trasaction with max nonce in queue when a given Tx has missing nonce.
@@ -771,7 +887,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		maxTx := pool.getMaxTxFromQueueWhenNonceIsMissing(tx, &from)
 		if maxTx != tx {
 			// (2) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
-			pool.removeTx(maxTx.Hash(), true)
+			pool.evictTx(maxTx.Hash(), true, evictReasonPoolFull)
 			logger.Trace("Removing an old Tx with the max nonce to insert a new Tx with missing nonce, because TxPool is full", "account", from, "new nonce(previously missing)", tx.Nonce(), "removed max nonce", maxTx.Nonce())
 		} else {
 			// (3) discard a new Tx if the new Tx does not have a missing nonce
@@ -792,7 +908,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		for _, tx := range drop {
 			logger.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", tx.GasPrice())
 			underpricedTxCounter.Inc(1)
-			pool.removeTx(tx.Hash(), false)
+			pool.evictTx(tx.Hash(), false, evictReasonUnderpriced)
 		}
 	}
 	// If the transaction is replacing an already pending one, do directly
@@ -809,10 +925,13 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 			delete(pool.all, old.Hash())
 			pool.priced.Removed()
 			pendingReplaceCounter.Inc(1)
+			pool.postTxPoolEvent(TxPoolEventReplaced, old, replaceReasonPrefix+hash.String())
 		}
 		pool.all[tx.Hash()] = tx
 		pool.priced.Put(tx)
 		pool.journalTx(from, tx)
+		pool.postTxPoolEvent(TxPoolEventAdmitted, tx, "")
+		pool.postTxPoolEvent(TxPoolEventPromoted, tx, "")
 
 		logger.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
@@ -831,6 +950,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		pool.locals.add(from)
 	}
 	pool.journalTx(from, tx)
+	pool.postTxPoolEvent(TxPoolEventAdmitted, tx, "")
 
 	logger.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replace, nil
@@ -856,6 +976,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 		delete(pool.all, old.Hash())
 		pool.priced.Removed()
 		queuedReplaceCounter.Inc(1)
+		pool.postTxPoolEvent(TxPoolEventReplaced, old, replaceReasonPrefix+hash.String())
 	}
 	if pool.all[hash] == nil {
 		pool.all[hash] = tx
@@ -896,6 +1017,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.priced.Removed()
 
 		pendingDiscardCounter.Inc(1)
+		pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonNonceAlreadyInUse)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -904,6 +1026,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.priced.Removed()
 
 		pendingReplaceCounter.Inc(1)
+		pool.postTxPoolEvent(TxPoolEventReplaced, old, replaceReasonPrefix+hash.String())
 	}
 	// Failsafe to work around direct pending inserts (tests)
 	if pool.all[hash] == nil {
@@ -913,6 +1036,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	// Set the potentially new pending nonce and notify any subsystems of the new tx
 	pool.beats[addr] = time.Now()
 	pool.setPendingNonce(addr, tx.Nonce()+1)
+	pool.postTxPoolEvent(TxPoolEventPromoted, tx, "")
 
 	return true
 }
@@ -1093,6 +1217,53 @@ func (pool *TxPool) checkAndSetBeat(addr common.Address) {
 	}
 }
 
+// DropTx removes the transaction of the given hash from the pool, moving all
+// subsequent transactions of the sender back to the future queue.
+// It returns false if the transaction is not in the pool.
+func (pool *TxPool) DropTx(hash common.Hash) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.txMu.Lock()
+	defer pool.txMu.Unlock()
+
+	if pool.all[hash] == nil {
+		return false
+	}
+	pool.evictTx(hash, true, evictReasonDropped)
+	return true
+}
+
+// DropTxsFrom removes all the transactions sent from the given account from
+// the pool, and returns the number of the removed transactions.
+func (pool *TxPool) DropTxsFrom(addr common.Address) int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.txMu.Lock()
+	defer pool.txMu.Unlock()
+
+	var txs types.Transactions
+	if list := pool.pending[addr]; list != nil {
+		txs = append(txs, list.Flatten()...)
+	}
+	if list := pool.queue[addr]; list != nil {
+		txs = append(txs, list.Flatten()...)
+	}
+	// Remove the transactions from the highest nonce not to move them to the future queue
+	for i := len(txs) - 1; i >= 0; i-- {
+		pool.evictTx(txs[i].Hash(), true, evictReasonDropped)
+	}
+	return len(txs)
+}
+
+// evictTx removes a single transaction with removeTx, and notifies the
+// subscribers of TxPoolEvent of the eviction with the given reason.
+func (pool *TxPool) evictTx(hash common.Hash, outofbound bool, reason string) {
+	if tx := pool.all[hash]; tx != nil {
+		pool.postTxPoolEvent(TxPoolEventEvicted, tx, reason)
+	}
+	pool.removeTx(hash, outofbound)
+}
+
 // removeTx removes a single transaction from the queue, moving all subsequent
 // transactions back to the future queue.
 func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
@@ -1161,6 +1332,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			logger.Trace("Removed old queued transaction", "hash", hash)
 			delete(pool.all, hash)
 			pool.priced.Removed()
+			pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonNonceTooLow)
 		}
 		// Drop all transactions that are too costly (low balance)
 		drops, _ := list.Filter(pool.getBalance(addr), pool)
@@ -1170,6 +1342,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			delete(pool.all, hash)
 			pool.priced.Removed()
 			queuedNofundsCounter.Inc(1)
+			pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonUnexecutable)
 		}
 
 		// Gather all executable transactions and promote them
@@ -1187,6 +1360,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 				delete(pool.all, hash)
 				pool.priced.Removed()
 				queuedRateLimitCounter.Inc(1)
+				pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonAccountSlots)
 				logger.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
 		}
@@ -1239,6 +1413,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 
 							// Update the account nonce to the dropped transaction
 							pool.updatePendingNonce(offenders[i], tx.Nonce())
+							pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonPoolSlots)
 							logger.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 						}
 						pending--
@@ -1259,6 +1434,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 
 						// Update the account nonce to the dropped transaction
 						pool.updatePendingNonce(addr, tx.Nonce())
+						pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonPoolSlots)
 						logger.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 					}
 					pending--
@@ -1293,7 +1469,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			// Drop all transactions if they are less than the overflow
 			if size := uint64(list.Len()); size <= drop {
 				for _, tx := range list.Flatten() {
-					pool.removeTx(tx.Hash(), true)
+					pool.evictTx(tx.Hash(), true, evictReasonPoolSlots)
 				}
 				drop -= size
 				queuedRateLimitCounter.Inc(int64(size))
@@ -1302,7 +1478,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			// Otherwise drop only last few transactions
 			txs := list.Flatten()
 			for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
-				pool.removeTx(txs[i].Hash(), true)
+				pool.evictTx(txs[i].Hash(), true, evictReasonPoolSlots)
 				drop--
 				queuedRateLimitCounter.Inc(1)
 			}
@@ -1330,6 +1506,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			logger.Trace("Removed old pending transaction", "hash", hash)
 			delete(pool.all, hash)
 			pool.priced.Removed()
+			pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonNonceTooLow)
 		}
 
 		// demoteUnexecutables does full-validation for a limited number of txs. Otherwise, it only validate nonce.
@@ -1348,6 +1525,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			delete(pool.all, hash)
 			pool.priced.Removed()
 			pendingNofundsCounter.Inc(1)
+			pool.postTxPoolEvent(TxPoolEventEvicted, tx, evictReasonUnexecutable)
 		}
 
 		for _, tx := range invalids {
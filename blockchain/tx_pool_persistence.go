@@ -0,0 +1,126 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// txPoolPersistence periodically stores the pending and queued transactions of
+// the pool, remote ones included, in the misc database to allow them to survive
+// node restarts. Unlike txJournal, which only keeps local transactions, it
+// restores the whole pool on boot.
+type txPoolPersistence struct {
+	db       database.DBManager
+	interval time.Duration
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// EnablePersistence restores the transactions stored in the given database into
+// the pool and starts storing the contents of the pool every PersistInterval.
+// The last contents are stored again when the pool is stopped.
+// It should be called only once, right after the pool is created.
+func (pool *TxPool) EnablePersistence(db database.DBManager) {
+	if pool.persistence != nil {
+		return
+	}
+	persistence := &txPoolPersistence{
+		db:       db,
+		interval: pool.config.PersistInterval,
+		quit:     make(chan struct{}),
+	}
+	persistence.load(pool.AddRemotes)
+	pool.persistence = persistence
+
+	persistence.wg.Add(1)
+	go persistence.loop(pool)
+}
+
+// load adds the stored transactions to the pool in small-ish batches.
+func (persistence *txPoolPersistence) load(add func([]*types.Transaction) []error) {
+	txs := persistence.db.ReadTxPoolSnapshot()
+	if len(txs) == 0 {
+		return
+	}
+
+	dropped := 0
+	for start := 0; start < len(txs); start += 1024 {
+		end := start + 1024
+		if end > len(txs) {
+			end = len(txs)
+		}
+		for _, err := range add(txs[start:end]) {
+			if err != nil {
+				logger.Debug("Failed to add persisted transaction", "err", err)
+				dropped++
+			}
+		}
+	}
+	logger.Info("Loaded persisted transaction pool", "transactions", len(txs), "dropped", dropped)
+}
+
+// store writes the current pending and queued transactions of the pool to the database.
+func (persistence *txPoolPersistence) store(pool *TxPool) {
+	pending, queued := pool.Content()
+
+	txs := make(types.Transactions, 0, len(pending)+len(queued))
+	for _, content := range []map[common.Address]types.Transactions{pending, queued} {
+		for _, list := range content {
+			txs = append(txs, list...)
+		}
+	}
+	// Nonce ordering lets the transactions be promoted as soon as they are restored.
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].Nonce() < txs[j].Nonce() })
+
+	if err := persistence.db.WriteTxPoolSnapshot(txs); err != nil {
+		logger.Error("Failed to persist transaction pool", "err", err)
+		return
+	}
+	logger.Debug("Persisted transaction pool", "transactions", len(txs))
+}
+
+// loop stores the contents of the pool periodically until stop is called.
+func (persistence *txPoolPersistence) loop(pool *TxPool) {
+	defer persistence.wg.Done()
+
+	ticker := time.NewTicker(persistence.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			persistence.store(pool)
+		case <-persistence.quit:
+			return
+		}
+	}
+}
+
+// stop terminates the periodic storing and stores the last contents of the pool.
+func (persistence *txPoolPersistence) stop(pool *TxPool) {
+	close(persistence.quit)
+	persistence.wg.Wait()
+	persistence.store(pool)
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// Tests that the pending and queued remote transactions survive a restart of the
+// pool if persistence is enabled, and that the contents are stored again on stop.
+func TestTransactionPoolPersistence(t *testing.T) {
+	t.Parallel()
+
+	db := database.NewMemoryDBManager()
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
+	pool.EnablePersistence(db)
+
+	key, _ := crypto.GenerateKey()
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	// Add two executable and a gapped remote transactions
+	for _, nonce := range []uint64{0, 1, 3} {
+		if err := pool.AddRemote(transaction(nonce, 100000, key)); err != nil {
+			t.Fatalf("failed to add remote transaction %d: %v", nonce, err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 2, 1)
+	}
+	pool.Stop()
+
+	if txs := db.ReadTxPoolSnapshot(); len(txs) != 3 {
+		t.Fatalf("persisted transactions mismatched: have %d, want %d", len(txs), 3)
+	}
+
+	// Restart the pool and ensure all transactions are restored
+	blockchain = &testBlockChain{statedb, 1000000, new(event.Feed)}
+	pool = NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
+	pool.EnablePersistence(db)
+
+	if pending, queued := pool.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("restored pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 2, 1)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+
+	// Drop the transactions and ensure the new contents are stored on stop
+	pool.DropTxsFrom(crypto.PubkeyToAddress(key.PublicKey))
+	pool.Stop()
+
+	if txs := db.ReadTxPoolSnapshot(); len(txs) != 0 {
+		t.Fatalf("persisted transactions mismatched: have %d, want %d", len(txs), 0)
+	}
+
+	// Ensure a pool without persistence does not restore anything
+	blockchain = &testBlockChain{statedb, 1000000, new(event.Feed)}
+	pool = NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 0, 0)
+	}
+}
@@ -82,7 +82,7 @@ func pricedTransaction(nonce uint64, gaslimit uint64, gasprice *big.Int, key *ec
 }
 
 func setupTxPool() (*TxPool, *ecdsa.PrivateKey) {
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	key, _ := crypto.GenerateKey()
@@ -168,7 +168,7 @@ func (c *testChain) State() (*state.StateDB, error) {
 	// a state change between those fetches.
 	stdb := c.statedb
 	if *c.trigger {
-		c.statedb, _ = state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+		c.statedb, _ = state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 		// simulate that the new head block included tx0 and tx1
 		c.statedb.SetNonce(c.address, 2)
 		c.statedb.SetBalance(c.address, new(big.Int).SetUint64(params.KLAY))
@@ -186,7 +186,7 @@ func TestStateChangeDuringTransactionPoolReset(t *testing.T) {
 	var (
 		key, _     = crypto.GenerateKey()
 		address    = crypto.PubkeyToAddress(key.PublicKey)
-		statedb, _ = state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+		statedb, _ = state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 		trigger    = false
 	)
 
@@ -267,6 +267,35 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that after the magma hard fork the pool accepts transactions paying at
+// least the base fee of the current head and rejects the rest.
+func TestTransactionBaseFee(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	pool.currentState.AddBalance(from, big.NewInt(0xffffffffffffff))
+
+	pool.mu.Lock()
+	pool.baseFee = big.NewInt(10)
+	pool.mu.Unlock()
+
+	if price := pool.GasPrice(); price.Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("gas price mismatch: have %v, want %v", price, 10)
+	}
+	if err := pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(9), key)); err != ErrGasPriceBelowBaseFee {
+		t.Error("expected", ErrGasPriceBelowBaseFee, "got", err)
+	}
+	if err := pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(10), key)); err != nil {
+		t.Error("expected", nil, "got", err)
+	}
+	if err := pool.AddRemote(pricedTransaction(1, 100000, big.NewInt(20), key)); err != nil {
+		t.Error("expected", nil, "got", err)
+	}
+}
+
 func genAnchorTx(nonce uint64) *types.Transaction {
 	key, _ := crypto.HexToECDSA("45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")
 	from := crypto.PubkeyToAddress(key.PublicKey)
@@ -404,7 +433,7 @@ func TestTransactionChainFork(t *testing.T) {
 
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	resetState := func() {
-		statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 		statedb.AddBalance(addr, big.NewInt(100000000000000))
 
 		pool.chain = &testBlockChain{statedb, 1000000, new(event.Feed)}
@@ -433,7 +462,7 @@ func TestTransactionDoubleNonce(t *testing.T) {
 
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	resetState := func() {
-		statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 		statedb.AddBalance(addr, big.NewInt(100000000000000))
 
 		pool.chain = &testBlockChain{statedb, 1000000, new(event.Feed)}
@@ -604,7 +633,7 @@ func TestTransactionPostponing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the postponing with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -819,7 +848,7 @@ func testTransactionQueueGlobalLimiting(t *testing.T, nolocals bool) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -917,7 +946,7 @@ func testTransactionQueueTimeLimiting(t *testing.T, nolocals, keepLocals bool) {
 	evictionInterval = time.Second
 
 	// Create the pool to test the non-expiration enforcement
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1081,7 +1110,7 @@ func TestTransactionPendingGlobalLimiting(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1127,7 +1156,7 @@ func TestTransactionCapClearsFromAll(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1161,7 +1190,7 @@ func TestTransactionPendingMinimumAllowance(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1211,7 +1240,7 @@ func TestTransactionPoolRepricing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1339,7 +1368,7 @@ func TestTransactionPoolRepricingKeepsLocals(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1405,7 +1434,7 @@ func TestTransactionPoolUnderpricing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1515,7 +1544,7 @@ func TestTransactionPoolStableUnderpricing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1584,7 +1613,7 @@ func TestTransactionReplacement(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemDB()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1679,7 +1708,7 @@ func testTransactionJournaling(t *testing.T, nolocals bool) {
 	os.Remove(journal)
 
 	// Create the original pool to inject transaction into the journal
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1777,7 +1806,7 @@ func TestTransactionStatusCheck(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the status retrievals with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1827,6 +1856,99 @@ func TestTransactionStatusCheck(t *testing.T) {
 	}
 }
 
+// Tests that the transactions can be dropped from the pool by hash or by sender,
+// and the subscribers are notified of the changes of the pool.
+func TestTransactionDropping_ByAdmin(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	events := make(chan TxPoolEvent, 32)
+	sub := pool.SubscribeTxPoolEvent(events)
+	defer sub.Unsubscribe()
+
+	account, _ := deriveSender(transaction(0, 0, key))
+	pool.currentState.AddBalance(account, big.NewInt(1000000))
+
+	// Add three executable transactions and a future one
+	txs := types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key), transaction(2, 100000, key), transaction(4, 100000, key)}
+	for _, err := range pool.AddRemotes(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	pending, queued := pool.ContentFrom(account)
+	if len(pending) != 3 || len(queued) != 1 {
+		t.Fatalf("content mismatch: have %d/%d, want %d/%d", len(pending), len(queued), 3, 1)
+	}
+	if pending, queued = pool.ContentFrom(common.Address{}); len(pending) != 0 || len(queued) != 0 {
+		t.Fatalf("unexpected content of an unknown account: %d/%d", len(pending), len(queued))
+	}
+
+	// Dropping a pending transaction moves the subsequent ones back to the queue
+	if !pool.DropTx(txs[1].Hash()) {
+		t.Fatalf("failed to drop a pending transaction")
+	}
+	if pool.DropTx(txs[1].Hash()) {
+		t.Fatalf("dropped an unknown transaction")
+	}
+	if pending, queued := pool.Stats(); pending != 1 || queued != 2 {
+		t.Fatalf("pool stats mismatch: have %d/%d, want %d/%d", pending, queued, 1, 2)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+
+	// Dropping all the transactions of the sender empties the pool
+	if dropped := pool.DropTxsFrom(account); dropped != 3 {
+		t.Fatalf("dropped transaction count mismatch: have %d, want %d", dropped, 3)
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("pool stats mismatch: have %d/%d, want %d/%d", pending, queued, 0, 0)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+
+	// Check the events: 4 admissions, 3 promotions and 4 evictions by admin
+	counts := make(map[TxPoolEventType]int)
+	for i := 0; i < 11; i++ {
+		select {
+		case ev := <-events:
+			counts[ev.Type]++
+			if ev.Type == TxPoolEventEvicted && ev.Reason != evictReasonDropped {
+				t.Errorf("eviction reason mismatch: have %q, want %q", ev.Reason, evictReasonDropped)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event #%d not fired", i)
+		}
+	}
+	if counts[TxPoolEventAdmitted] != 4 || counts[TxPoolEventPromoted] != 3 || counts[TxPoolEventEvicted] != 4 {
+		t.Fatalf("event count mismatch: %v", counts)
+	}
+}
+
+// Tests that the event queued after dropped events is marked to let the subscribers know it.
+func TestTxPoolEventDropped(t *testing.T) {
+	pool := &TxPool{txPoolEventCh: make(chan TxPoolEvent, 1)}
+
+	pool.postTxPoolEvent(TxPoolEventAdmitted, nil, "")
+	pool.postTxPoolEvent(TxPoolEventPromoted, nil, "") // dropped since the queue is full
+	if ev := <-pool.txPoolEventCh; ev.Type != TxPoolEventAdmitted || ev.Dropped {
+		t.Fatalf("first event mismatch: %v", ev)
+	}
+
+	pool.postTxPoolEvent(TxPoolEventEvicted, nil, "")
+	if ev := <-pool.txPoolEventCh; ev.Type != TxPoolEventEvicted || !ev.Dropped {
+		t.Fatalf("event after the dropped one is not marked: %v", ev)
+	}
+	pool.postTxPoolEvent(TxPoolEventEvicted, nil, "")
+	if ev := <-pool.txPoolEventCh; ev.Dropped {
+		t.Fatalf("event is marked without dropped events: %v", ev)
+	}
+}
+
 // Benchmarks the speed of validating the contents of the pending queue of the
 // transaction pool.
 func BenchmarkPendingDemotion100(b *testing.B)   { benchmarkPendingDemotion(b, 100) }
@@ -68,6 +68,9 @@ type Header struct {
 	Extra      []byte `json:"extraData"        gencodec:"required"`
 	Governance []byte `json:"governanceData"        gencodec:"required"`
 	Vote       []byte `json:"voteData,omitempty"`
+
+	// BaseFee was added by the magma fork and is ignored in legacy headers.
+	BaseFee *big.Int `json:"baseFeePerGas,omitempty" rlp:"optional"`
 }
 
 // field type overrides for gencodec
@@ -81,6 +84,7 @@ type headerMarshaling struct {
 	Hash       common.Hash `json:"hash"` // adds call to Hash() in MarshalJSON
 	Governance hexutil.Bytes
 	Vote       hexutil.Bytes
+	BaseFee    *hexutil.Big
 }
 
 // Hash returns the block hash of the header, which is simply the keccak256 hash of its
@@ -238,6 +242,9 @@ func CopyHeader(h *Header) *Header {
 		cpy.Vote = make([]byte, len(h.Vote))
 		copy(cpy.Vote, h.Vote)
 	}
+	if h.BaseFee != nil {
+		cpy.BaseFee = new(big.Int).Set(h.BaseFee)
+	}
 	return &cpy
 }
 
@@ -287,6 +294,14 @@ func (b *Block) TxHash() common.Hash        { return b.header.TxHash }
 func (b *Block) ReceiptHash() common.Hash   { return b.header.ReceiptHash }
 func (b *Block) Extra() []byte              { return common.CopyBytes(b.header.Extra) }
 
+// BaseFee returns the base fee of the block, or nil if the block is before the magma fork.
+func (b *Block) BaseFee() *big.Int {
+	if b.header.BaseFee == nil {
+		return nil
+	}
+	return new(big.Int).Set(b.header.BaseFee)
+}
+
 func (b *Block) Header() *Header { return CopyHeader(b.header) }
 
 // Body returns the non-header content of the block.
@@ -375,7 +390,8 @@ func (h *Header) String() string {
 	Extra:            %s
 	Governance:       %x
 	Vote:             %x
-]`, h.Hash(), h.ParentHash, h.Rewardbase, h.Root, h.TxHash, h.ReceiptHash, h.Bloom, h.BlockScore, h.Number, h.GasUsed, h.Time, h.TimeFoS, h.Extra, h.Governance, h.Vote)
+	BaseFee:          %v
+]`, h.Hash(), h.ParentHash, h.Rewardbase, h.Root, h.TxHash, h.ReceiptHash, h.Bloom, h.BlockScore, h.Number, h.GasUsed, h.Time, h.TimeFoS, h.Extra, h.Governance, h.Vote, h.BaseFee)
 }
 
 type Blocks []*Block
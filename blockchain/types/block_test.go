@@ -153,6 +153,7 @@ func TestBlockEncoding(t *testing.T) {
 		header.Extra,
 		header.Governance,
 		header.Vote,
+		header.BaseFee,
 	}
 
 	resHash := rlpHash(fHeader)
@@ -289,3 +290,35 @@ func TestBlockEncoding(t *testing.T) {
 	}
 }
 */
+
+// TestHeaderBaseFeeEncoding tests that the optional BaseFee field does not change the
+// encoding of legacy headers and survives encoding and decoding when it is set.
+func TestHeaderBaseFeeEncoding(t *testing.T) {
+	legacy := genHeader()
+
+	enc, err := rlp.EncodeToBytes(legacy)
+	assert.NoError(t, err)
+
+	legacyFields := []interface{}{legacy.ParentHash, legacy.Rewardbase, legacy.Root, legacy.TxHash, legacy.ReceiptHash,
+		legacy.Bloom, legacy.BlockScore, legacy.Number, legacy.GasUsed, legacy.Time, legacy.TimeFoS, legacy.Extra,
+		legacy.Governance, legacy.Vote}
+	legacyEnc, err := rlp.EncodeToBytes(legacyFields)
+	assert.NoError(t, err)
+	assert.Equal(t, legacyEnc, enc)
+
+	var decoded Header
+	assert.NoError(t, rlp.DecodeBytes(enc, &decoded))
+	assert.Nil(t, decoded.BaseFee)
+	assert.Equal(t, legacy.Hash(), decoded.Hash())
+
+	magma := genHeader()
+	magma.BaseFee = big.NewInt(25000000000)
+
+	enc, err = rlp.EncodeToBytes(magma)
+	assert.NoError(t, err)
+	assert.NoError(t, rlp.DecodeBytes(enc, &decoded))
+	assert.Equal(t, magma.BaseFee, decoded.BaseFee)
+	assert.Equal(t, magma.Hash(), decoded.Hash())
+	assert.NotEqual(t, legacy.Hash(), magma.Hash())
+	assert.Equal(t, magma.BaseFee, CopyHeader(magma).BaseFee)
+}
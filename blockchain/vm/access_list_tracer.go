@@ -0,0 +1,132 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// AccessTuple is an account and the storage slots of it touched by an execution.
+type AccessTuple struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []common.Hash  `json:"storageKeys"`
+}
+
+// AccessList is a list of the accounts and the storage slots touched by an execution.
+type AccessList []AccessTuple
+
+// AccessListTracer is a tracer that collects all the accounts and the storage
+// slots which are read or written during the execution. Precompiled contracts
+// are not collected since they do not have any state.
+type AccessListTracer struct {
+	list        map[common.Address]map[common.Hash]struct{}
+	precompiles map[common.Address]struct{}
+}
+
+// NewAccessListTracer returns a new AccessListTracer which excludes the given
+// precompiled contracts. They should be the ones active at the traced block.
+func NewAccessListTracer(precompiles []common.Address) *AccessListTracer {
+	excl := make(map[common.Address]struct{}, len(precompiles))
+	for _, addr := range precompiles {
+		excl[addr] = struct{}{}
+	}
+	return &AccessListTracer{
+		list:        make(map[common.Address]map[common.Hash]struct{}),
+		precompiles: excl,
+	}
+}
+
+// addAddress adds the given account to the access list.
+func (a *AccessListTracer) addAddress(addr common.Address) {
+	if _, ok := a.precompiles[addr]; ok {
+		return
+	}
+	if _, ok := a.list[addr]; !ok {
+		a.list[addr] = make(map[common.Hash]struct{})
+	}
+}
+
+// addSlot adds the given storage slot of the given account to the access list.
+func (a *AccessListTracer) addSlot(addr common.Address, slot common.Hash) {
+	a.addAddress(addr)
+	if slots, ok := a.list[addr]; ok {
+		slots[slot] = struct{}{}
+	}
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (a *AccessListTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	a.addAddress(from)
+	a.addAddress(to)
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (a *AccessListTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if err != nil {
+		return nil
+	}
+	a.addAddress(contract.Address())
+
+	stackData := stack.Data()
+	stackLen := len(stackData)
+	switch {
+	case (op == SLOAD || op == SSTORE) && stackLen >= 1:
+		a.addSlot(contract.Address(), common.BigToHash(stackData[stackLen-1]))
+	case (op == EXTCODECOPY || op == EXTCODEHASH || op == EXTCODESIZE || op == BALANCE || op == SELFDESTRUCT) && stackLen >= 1:
+		a.addAddress(common.BigToAddress(stackData[stackLen-1]))
+	case (op == DELEGATECALL || op == CALL || op == STATICCALL || op == CALLCODE) && stackLen >= 5:
+		a.addAddress(common.BigToAddress(stackData[stackLen-2]))
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (a *AccessListTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (a *AccessListTracer) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	return nil
+}
+
+// AccessList returns the collected accounts and storage slots sorted by the
+// addresses and the keys.
+func (a *AccessListTracer) AccessList() AccessList {
+	list := make(AccessList, 0, len(a.list))
+	for addr, slots := range a.list {
+		tuple := AccessTuple{Address: addr, StorageKeys: make([]common.Hash, 0, len(slots))}
+		for slot := range slots {
+			tuple.StorageKeys = append(tuple.StorageKeys, slot)
+		}
+		sort.Slice(tuple.StorageKeys, func(i, j int) bool {
+			return bytes.Compare(tuple.StorageKeys[i][:], tuple.StorageKeys[j][:]) < 0
+		})
+		list = append(list, tuple)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].Address[:], list[j].Address[:]) < 0
+	})
+	return list
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/kerrors"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessListTracer(t *testing.T) {
+	var (
+		env      = NewEVM(Context{}, &dummyStatedb{}, params.TestChainConfig, &Config{})
+		tracer   = NewAccessListTracer(ActivePrecompiles(params.TestChainConfig.Rules(new(big.Int))))
+		mem      = NewMemory()
+		contract = NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 0)
+		from     = common.HexToAddress("0xa0")
+		to       = common.HexToAddress("0xb0")
+		other    = common.HexToAddress("0xc0")
+		callee   = common.HexToAddress("0xd0")
+	)
+	tracer.CaptureStart(from, to, false, nil, 0, new(big.Int))
+
+	// Storage slots of the running contract
+	for _, op := range []OpCode{SLOAD, SSTORE} {
+		stack := newstack()
+		stack.push(big.NewInt(1))
+		stack.push(big.NewInt(int64(op)))
+		tracer.CaptureState(env, 0, op, 0, 0, mem, stack, contract, 0, nil)
+	}
+	// Accounts inspected by the running contract
+	stack := newstack()
+	stack.push(new(big.Int).SetBytes(other.Bytes()))
+	tracer.CaptureState(env, 0, BALANCE, 0, 0, mem, stack, contract, 0, nil)
+
+	// Accounts called by the running contract
+	stack = newstack()
+	stack.pushN(big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), new(big.Int).SetBytes(callee.Bytes()), big.NewInt(0))
+	tracer.CaptureState(env, 0, STATICCALL, 0, 0, mem, stack, contract, 0, nil)
+
+	// Precompiled contracts are not collected
+	stack = newstack()
+	stack.pushN(big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(1), big.NewInt(0))
+	tracer.CaptureState(env, 0, STATICCALL, 0, 0, mem, stack, contract, 0, nil)
+
+	// Faulted steps are ignored
+	stack = newstack()
+	stack.push(big.NewInt(2))
+	tracer.CaptureState(env, 0, SLOAD, 0, 0, mem, stack, contract, 0, kerrors.ErrOutOfGas)
+
+	expected := AccessList{
+		{Address: contract.Address(), StorageKeys: []common.Hash{common.BigToHash(big.NewInt(int64(SLOAD))), common.BigToHash(big.NewInt(int64(SSTORE)))}},
+		{Address: from, StorageKeys: []common.Hash{}},
+		{Address: to, StorageKeys: []common.Hash{}},
+		{Address: other, StorageKeys: []common.Hash{}},
+		{Address: callee, StorageKeys: []common.Hash{}},
+	}
+	assert.Equal(t, expected, tracer.AccessList())
+}
+
+func TestAccessListTracer_IstanbulPrecompiles(t *testing.T) {
+	config := *params.TestChainConfig
+	config.IstanbulCompatibleBlock = big.NewInt(10)
+
+	var (
+		env      = NewEVM(Context{BlockNumber: big.NewInt(10)}, &dummyStatedb{}, &config, &Config{})
+		mem      = NewMemory()
+		contract = NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 0)
+		vmLog    = common.BytesToAddress([]byte{3, 253})
+		feePayer = common.BytesToAddress([]byte{3, 254})
+		sender   = common.BytesToAddress([]byte{3, 255})
+		old      = common.BytesToAddress([]byte{9})
+	)
+	call := func(tracer *AccessListTracer, addr common.Address) {
+		stack := newstack()
+		stack.pushN(big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), new(big.Int).SetBytes(addr.Bytes()), big.NewInt(0))
+		tracer.CaptureState(env, 0, STATICCALL, 0, 0, mem, stack, contract, 0, nil)
+	}
+
+	// After the istanbul compatible block, the precompiled contracts moved to 0x3fd-0x3ff are not collected
+	tracer := NewAccessListTracer(ActivePrecompiles(config.Rules(big.NewInt(10))))
+	for _, addr := range []common.Address{vmLog, feePayer, sender, old} {
+		call(tracer, addr)
+	}
+	expected := AccessList{
+		{Address: contract.Address(), StorageKeys: []common.Hash{}},
+		{Address: old, StorageKeys: []common.Hash{}},
+	}
+	assert.Equal(t, expected, tracer.AccessList())
+
+	// Before the istanbul compatible block, the addresses are not precompiled contracts
+	tracer = NewAccessListTracer(ActivePrecompiles(config.Rules(big.NewInt(9))))
+	for _, addr := range []common.Address{vmLog, feePayer, sender, old} {
+		call(tracer, addr)
+	}
+	expected = AccessList{
+		{Address: contract.Address(), StorageKeys: []common.Hash{}},
+		{Address: vmLog, StorageKeys: []common.Hash{}},
+		{Address: feePayer, StorageKeys: []common.Hash{}},
+		{Address: sender, StorageKeys: []common.Hash{}},
+	}
+	assert.Equal(t, expected, tracer.AccessList())
+}
@@ -54,7 +54,7 @@ func prepare(reqGas uint64) (*Contract, *EVM, error) {
 		nil, new(big.Int), reqGas)
 
 	// Generate EVM
-	stateDb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()))
+	stateDb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
 	txhash := common.HexToHash("0xc6a37e155d3fa480faea012a68ad35fd53c8cc3cd8263a434c697755985a6577")
 	stateDb.Prepare(txhash, common.Hash{}, 0)
 	evm := NewEVM(Context{}, stateDb, params.TestChainConfig, &Config{})
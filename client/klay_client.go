@@ -370,6 +370,15 @@ func toFilterArg(q klaytn.FilterQuery) interface{} {
 	if q.FromBlock == nil {
 		arg["fromBlock"] = "0x0"
 	}
+	if q.Cursor != nil {
+		arg["cursor"] = map[string]interface{}{
+			"blockNumber": hexutil.Uint64(q.Cursor.BlockNumber),
+			"logIndex":    hexutil.Uint(q.Cursor.Index),
+		}
+	}
+	if q.Limit != 0 {
+		arg["limit"] = hexutil.Uint(q.Limit)
+	}
 	return arg
 }
 
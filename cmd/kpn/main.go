@@ -73,6 +73,9 @@ func init() {
 
 		// See utils/nodecmd/db_migration.go:
 		nodecmd.MigrationCommand,
+
+		// See utils/nodecmd/prunecmd.go:
+		nodecmd.PruneStateCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -81,6 +84,7 @@ func init() {
 	app.Flags = append(app.Flags, nodecmd.ConsoleFlags...)
 	app.Flags = append(app.Flags, debug.Flags...)
 	app.Flags = append(app.Flags, nodecmd.DBMigrationFlags...)
+	app.Flags = append(app.Flags, nodecmd.PruneStateFlags...)
 
 	cli.AppHelpTemplate = utils.GlobalAppHelpTemplate
 	cli.HelpPrinter = utils.NewHelpPrinter(utils.CategorizeFlags(app.Flags))
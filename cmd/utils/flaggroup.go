@@ -65,6 +65,9 @@ var FlagGroups = []FlagGroup{
 			TxPoolAllowLocalAnchorTxFlag,
 			TxPoolJournalFlag,
 			TxPoolJournalIntervalFlag,
+			TxPoolPersistFlag,
+			TxPoolPersistIntervalFlag,
+			TxPoolMirrorSourceFlag,
 			TxPoolPriceLimitFlag,
 			TxPoolPriceBumpFlag,
 			TxPoolExecSlotsAccountFlag,
@@ -73,6 +76,12 @@ var FlagGroups = []FlagGroup{
 			TxPoolNonExecSlotsAllFlag,
 			TxPoolLifetimeFlag,
 			TxPoolKeepLocalsFlag,
+			TxPoolAllowedSendersFlag,
+			TxPoolDeniedSendersFlag,
+			TxPoolAllowedRecipientsFlag,
+			TxPoolDeniedRecipientsFlag,
+			TxPoolDisabledTxTypesFlag,
+			TxPoolMaxTxsPerSenderFlag,
 			TxResendIntervalFlag,
 			TxResendCountFlag,
 			TxResendUseLegacyFlag,
@@ -94,6 +103,7 @@ var FlagGroups = []FlagGroup{
 			NoParallelDBWriteFlag,
 			SenderTxHashIndexingFlag,
 			DBNoPerformanceMetricsFlag,
+			AncientThresholdFlag,
 		},
 	},
 	{
@@ -167,6 +177,11 @@ var FlagGroups = []FlagGroup{
 			TrieMemoryCacheSizeFlag,
 			TrieBlockIntervalFlag,
 			TriesInMemoryFlag,
+			LivePruningFlag,
+			LivePruningRetentionFlag,
+			SnapshotFlag,
+			SnapshotCacheSizeFlag,
+			SnapshotAsyncGenFlag,
 		},
 	},
 	{
@@ -242,6 +257,15 @@ var FlagGroups = []FlagGroup{
 			RPCApiFlag,
 			RPCGlobalGasCap,
 			RPCConcurrencyLimit,
+			RPCRateLimitFlag,
+			RPCRateLimitBurstFlag,
+			RPCHeavyRateLimitFlag,
+			RPCHeavyRateLimitBurstFlag,
+			RPCHeavyMethodsFlag,
+			RPCRateLimitAPIKeysFlag,
+			RPCBatchLimitFlag,
+			RPCBatchMaxResponseSizeFlag,
+			RPCBatchParallelismFlag,
 			IPCDisabledFlag,
 			IPCPathFlag,
 			WSEnabledFlag,
@@ -252,12 +276,31 @@ var FlagGroups = []FlagGroup{
 			GRPCEnabledFlag,
 			GRPCListenAddrFlag,
 			GRPCPortFlag,
+			GRPCApiFlag,
+			AuthRPCEnabledFlag,
+			AuthRPCListenAddrFlag,
+			AuthRPCPortFlag,
+			AuthRPCApiFlag,
+			AuthRPCVirtualHostsFlag,
+			AuthRPCJWTSecretFlag,
+			GraphQLEnabledFlag,
+			GraphQLListenAddrFlag,
+			GraphQLPortFlag,
+			GraphQLCORSDomainFlag,
+			GraphQLVirtualHostsFlag,
+			GraphQLMaxDepthFlag,
+			GraphQLMaxParallelismFlag,
+			GraphQLTimeoutFlag,
+			GraphQLMaxBlockSpanFlag,
+			GraphQLMaxLogsFlag,
 			JSpathFlag,
 			ExecFlag,
 			PreloadJSFlag,
 			MaxRequestContentLengthFlag,
 			APIFilterGetLogsDeadlineFlag,
 			APIFilterGetLogsMaxItemsFlag,
+			APIFilterGetLogsMaxBlockSpanFlag,
+			APIBlockRangeMaxSpanFlag,
 		},
 	},
 	{
@@ -33,8 +33,10 @@ import (
 
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/api/debug"
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/fdlimit"
 	"github.com/klaytn/klaytn/crypto"
@@ -44,6 +46,7 @@ import (
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/log"
 	metricutils "github.com/klaytn/klaytn/metrics/utils"
+	"github.com/klaytn/klaytn/networks/graphql"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/p2p/discover"
 	"github.com/klaytn/klaytn/networks/p2p/nat"
@@ -123,7 +126,7 @@ var (
 	defaultSyncMode = cn.GetDefaultConfig().SyncMode
 	SyncModeFlag    = TextMarshalerFlag{
 		Name:  "syncmode",
-		Usage: `Blockchain sync mode (only "full" is supported)`,
+		Usage: `Blockchain sync mode ("full" or "snap")`,
 		Value: &defaultSyncMode,
 	}
 	GCModeFlag = cli.StringFlag{
@@ -162,6 +165,19 @@ var (
 		Usage: "Time interval to regenerate the local transaction journal",
 		Value: blockchain.DefaultTxPoolConfig.JournalInterval,
 	}
+	TxPoolPersistFlag = cli.BoolFlag{
+		Name:  "txpool.persist",
+		Usage: "Store all pending and queued transactions, remote ones included, in the database to survive node restarts",
+	}
+	TxPoolPersistIntervalFlag = cli.DurationFlag{
+		Name:  "txpool.persist-interval",
+		Usage: "Time interval to store the transactions of the pool in the database",
+		Value: blockchain.DefaultTxPoolConfig.PersistInterval,
+	}
+	TxPoolMirrorSourceFlag = cli.StringFlag{
+		Name:  "txpool.mirror-source",
+		Usage: "Websocket or IPC endpoint of a node whose transaction pool is mirrored into this node for failover (txpool API should be enabled on the source)",
+	}
 	TxPoolPriceLimitFlag = cli.Uint64Flag{
 		Name:  "txpool.pricelimit",
 		Usage: "Minimum gas price limit to enforce for acceptance into the pool",
@@ -201,6 +217,30 @@ var (
 		Usage: "Maximum amount of time non-executable transaction are queued",
 		Value: cn.GetDefaultConfig().TxPool.Lifetime,
 	}
+	TxPoolAllowedSendersFlag = cli.StringFlag{
+		Name:  "txpool.allowed-senders",
+		Usage: "Comma separated list of the senders allowed to add transactions to the pool (empty = all senders)",
+	}
+	TxPoolDeniedSendersFlag = cli.StringFlag{
+		Name:  "txpool.denied-senders",
+		Usage: "Comma separated list of the senders not allowed to add transactions to the pool",
+	}
+	TxPoolAllowedRecipientsFlag = cli.StringFlag{
+		Name:  "txpool.allowed-recipients",
+		Usage: "Comma separated list of the recipients allowed to receive transactions added to the pool (empty = all recipients)",
+	}
+	TxPoolDeniedRecipientsFlag = cli.StringFlag{
+		Name:  "txpool.denied-recipients",
+		Usage: "Comma separated list of the recipients not allowed to receive transactions added to the pool",
+	}
+	TxPoolDisabledTxTypesFlag = cli.StringFlag{
+		Name:  "txpool.disabled-txtypes",
+		Usage: "Comma separated list of the transaction types not allowed to be added to the pool (e.g. TxTypeSmartContractDeploy)",
+	}
+	TxPoolMaxTxsPerSenderFlag = cli.Uint64Flag{
+		Name:  "txpool.max-txs-per-sender",
+		Usage: "Maximum number of transactions of a sender in the pool (0 = no limit)",
+	}
 	// KES
 	KESNodeTypeServiceFlag = cli.BoolFlag{
 		Name:  "kes.nodetype.service",
@@ -265,6 +305,11 @@ var (
 		Name:  "db.no-perf-metrics",
 		Usage: "Disables performance metrics of database's read and write operations",
 	}
+	AncientThresholdFlag = cli.Uint64Flag{
+		Name:  "db.ancient.threshold",
+		Usage: "Number of recent blocks kept in the key-value databases. Older blocks are moved to the ancient store (0 = disabled)",
+		Value: 0,
+	}
 	TrieMemoryCacheSizeFlag = cli.IntFlag{
 		Name:  "state.cache-size",
 		Usage: "Size of in-memory cache of the global state (in MiB) to flush matured singleton trie nodes to disk",
@@ -280,6 +325,28 @@ var (
 		Usage: "The number of recent state tries residing in the memory",
 		Value: blockchain.DefaultTriesInMemory,
 	}
+	LivePruningFlag = cli.BoolFlag{
+		Name:  "state.live-pruning",
+		Usage: "Enables deleting the state trie nodes which are no longer referenced. Can only be enabled on an empty database",
+	}
+	LivePruningRetentionFlag = cli.Uint64Flag{
+		Name:  "state.live-pruning-retention",
+		Usage: "The number of recent blocks whose state tries are retained by the live pruning",
+		Value: blockchain.DefaultLivePruningRetention,
+	}
+	SnapshotFlag = cli.BoolFlag{
+		Name:  "snapshot",
+		Usage: "Enables the flat state snapshot for fast account and storage reads",
+	}
+	SnapshotCacheSizeFlag = cli.IntFlag{
+		Name:  "snapshot.cache",
+		Usage: "Size of in-memory cache of the state snapshot (in MiB)",
+		Value: 512,
+	}
+	SnapshotAsyncGenFlag = cli.BoolTFlag{
+		Name:  "snapshot.async-gen",
+		Usage: "Generates the state snapshot in background (default: true)",
+	}
 	CacheTypeFlag = cli.IntFlag{
 		Name:  "cache.type",
 		Usage: "Cache Type: 0=LRUCache, 1=LRUShardCache, 2=FIFOCache",
@@ -456,6 +523,46 @@ var (
 		Usage: "Sets a limit of concurrent connection number of HTTP-RPC server",
 		Value: rpc.ConcurrencyLimit,
 	}
+	RPCRateLimitFlag = cli.Float64Flag{
+		Name:  "rpc.ratelimit",
+		Usage: "Sets a limit of requests per second of an HTTP-RPC client for each API namespace (0 = no limit)",
+	}
+	RPCRateLimitBurstFlag = cli.IntFlag{
+		Name:  "rpc.ratelimit.burst",
+		Usage: "Sets a limit of requests at once of an HTTP-RPC client for each API namespace (0 = same as rpc.ratelimit)",
+	}
+	RPCHeavyRateLimitFlag = cli.Float64Flag{
+		Name:  "rpc.ratelimit.heavy",
+		Usage: "Sets a limit of requests per second of an HTTP-RPC client for the heavy methods (0 = no limit)",
+	}
+	RPCHeavyRateLimitBurstFlag = cli.IntFlag{
+		Name:  "rpc.ratelimit.heavyburst",
+		Usage: "Sets a limit of requests at once of an HTTP-RPC client for the heavy methods (0 = same as rpc.ratelimit.heavy)",
+	}
+	RPCHeavyMethodsFlag = cli.StringFlag{
+		Name:  "rpc.ratelimit.heavymethods",
+		Usage: "Comma separated list of the heavy methods limited by rpc.ratelimit.heavy. A name ending with '*' matches the methods with the prefix",
+		Value: strings.Join(rpc.RateLimit.HeavyMethods, ","),
+	}
+	RPCRateLimitAPIKeysFlag = cli.StringFlag{
+		Name:  "rpc.ratelimit.apikeys",
+		Usage: "Comma separated list of the API keys identifying HTTP-RPC clients by the X-Api-Key header. Clients without an allowed key are identified by their IP addresses",
+	}
+	RPCBatchLimitFlag = cli.IntFlag{
+		Name:  "rpc.batch.limit",
+		Usage: "Sets a limit of requests in a batch of JSON-RPC servers (0 = no limit)",
+		Value: rpc.BatchLimit.MaxItems,
+	}
+	RPCBatchMaxResponseSizeFlag = cli.IntFlag{
+		Name:  "rpc.batch.maxresponsesize",
+		Usage: "Sets a limit of bytes of the responses of a batch of JSON-RPC servers (0 = no limit)",
+		Value: rpc.BatchLimit.MaxResponseSize,
+	}
+	RPCBatchParallelismFlag = cli.IntFlag{
+		Name:  "rpc.batch.parallelism",
+		Usage: "Sets the number of requests of a batch executed in parallel by JSON-RPC servers (1 = sequential)",
+		Value: rpc.BatchLimit.Parallelism,
+	}
 	WSEnabledFlag = cli.BoolFlag{
 		Name:  "ws",
 		Usage: "Enable the WS-RPC server",
@@ -514,6 +621,88 @@ var (
 		Usage: "gRPC server listening port",
 		Value: node.DefaultGRPCPort,
 	}
+	GRPCApiFlag = cli.StringFlag{
+		Name:  "grpcapi",
+		Usage: "API's offered over the gRPC interface",
+		Value: "",
+	}
+	AuthRPCEnabledFlag = cli.BoolFlag{
+		Name:  "authrpc",
+		Usage: "Enable the authenticated RPC server requiring JWT bearer tokens",
+	}
+	AuthRPCListenAddrFlag = cli.StringFlag{
+		Name:  "authrpc.addr",
+		Usage: "Authenticated RPC server listening interface",
+		Value: node.DefaultAuthHost,
+	}
+	AuthRPCPortFlag = cli.IntFlag{
+		Name:  "authrpc.port",
+		Usage: "Authenticated RPC server listening port",
+		Value: node.DefaultAuthPort,
+	}
+	AuthRPCApiFlag = cli.StringFlag{
+		Name:  "authrpc.api",
+		Usage: "API's offered over the authenticated RPC interface. Each token can call only the namespaces in its claim among them",
+		Value: "",
+	}
+	AuthRPCVirtualHostsFlag = cli.StringFlag{
+		Name:  "authrpc.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests of the authenticated RPC server. Accepts '*' wildcard.",
+		Value: strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
+	}
+	AuthRPCJWTSecretFlag = cli.StringFlag{
+		Name:  "authrpc.jwtsecret",
+		Usage: "Path to a hex-encoded JWT secret for the authenticated RPC server (default: generated in the data directory)",
+	}
+	GraphQLEnabledFlag = cli.BoolFlag{
+		Name:  "graphql",
+		Usage: "Enable the GraphQL server",
+	}
+	GraphQLListenAddrFlag = cli.StringFlag{
+		Name:  "graphql.addr",
+		Usage: "GraphQL server listening interface",
+		Value: node.DefaultGraphQLHost,
+	}
+	GraphQLPortFlag = cli.IntFlag{
+		Name:  "graphql.port",
+		Usage: "GraphQL server listening port",
+		Value: node.DefaultGraphQLPort,
+	}
+	GraphQLCORSDomainFlag = cli.StringFlag{
+		Name:  "graphql.corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin requests of the GraphQL server (browser enforced)",
+		Value: "",
+	}
+	GraphQLVirtualHostsFlag = cli.StringFlag{
+		Name:  "graphql.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests of the GraphQL server. Accepts '*' wildcard.",
+		Value: strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
+	}
+	GraphQLMaxDepthFlag = cli.IntFlag{
+		Name:  "graphql.maxdepth",
+		Usage: "Sets a limit of the field nesting depth of a GraphQL query (0 = no limit)",
+		Value: node.DefaultConfig.GraphQLMaxDepth,
+	}
+	GraphQLMaxParallelismFlag = cli.IntFlag{
+		Name:  "graphql.maxparallelism",
+		Usage: "Sets a limit of resolvers running in parallel for a GraphQL query",
+		Value: node.DefaultConfig.GraphQLMaxParallelism,
+	}
+	GraphQLTimeoutFlag = cli.DurationFlag{
+		Name:  "graphql.timeout",
+		Usage: "Sets a timeout of a GraphQL query (0 = no timeout)",
+		Value: node.DefaultConfig.GraphQLTimeout,
+	}
+	GraphQLMaxBlockSpanFlag = cli.Uint64Flag{
+		Name:  "graphql.maxblockspan",
+		Usage: "Sets a limit of the blocks queried by blocks and logs of a GraphQL query (0 = no limit)",
+		Value: node.DefaultConfig.GraphQLMaxBlockSpan,
+	}
+	GraphQLMaxLogsFlag = cli.IntFlag{
+		Name:  "graphql.maxlogs",
+		Usage: "Sets a limit of the logs returned by logs of a GraphQL query (0 = no limit)",
+		Value: node.DefaultConfig.GraphQLMaxLogs,
+	}
 	IPCDisabledFlag = cli.BoolFlag{
 		Name:  "ipcdisable",
 		Usage: "Disable the IPC-RPC server",
@@ -540,6 +729,16 @@ var (
 		Usage: "Maximum allowed number of return items for log collecting filter API",
 		Value: filters.GetLogsMaxItems,
 	}
+	APIFilterGetLogsMaxBlockSpanFlag = cli.Uint64Flag{
+		Name:  "api.filter.getLogs.maxblockspan",
+		Usage: "Maximum allowed number of blocks queried by log collecting filter API (0 = no limit)",
+		Value: filters.GetLogsMaxBlockSpan,
+	}
+	APIBlockRangeMaxSpanFlag = cli.Uint64Flag{
+		Name:  "api.blockrange.maxspan",
+		Usage: "Maximum allowed number of blocks returned by block range APIs (0 = no limit)",
+		Value: api.BlockRangeMaxSpan,
+	}
 
 	// Network Settings
 	NodeTypeFlag = cli.StringFlag{
@@ -988,6 +1187,18 @@ var (
 		Value: database.GetDefaultDynamoDBConfig().WriteCapacityUnits,
 	}
 
+	// state pruning vars
+	PruneStateBlocksFlag = cli.Uint64Flag{
+		Name:  "prune.blocks",
+		Usage: "The number of recent blocks whose state tries are retained by the state pruning",
+		Value: blockchain.DefaultTriesInMemory,
+	}
+	PruneStateBloomSizeFlag = cli.Uint64Flag{
+		Name:  "prune.bloom-size",
+		Usage: "Size of the bloom filter (in MiB) marking the retained state trie nodes. A larger bloom keeps fewer stale nodes",
+		Value: 2048,
+	}
+
 	// Config
 	ConfigFileFlag = cli.StringFlag{
 		Name:  "config",
@@ -1146,6 +1357,57 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 		rpc.ConcurrencyLimit = ctx.GlobalInt(RPCConcurrencyLimit.Name)
 		logger.Info("Set the concurrency limit of RPC-HTTP server", "limit", rpc.ConcurrencyLimit)
 	}
+	setRPCRateLimit(ctx)
+	setRPCBatchLimit(ctx)
+}
+
+// setRPCRateLimit sets the rate limit of RPC servers from the set command line flags.
+func setRPCRateLimit(ctx *cli.Context) {
+	if ctx.GlobalIsSet(RPCRateLimitFlag.Name) {
+		rpc.RateLimit.Rate = ctx.GlobalFloat64(RPCRateLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCRateLimitBurstFlag.Name) {
+		rpc.RateLimit.Burst = ctx.GlobalInt(RPCRateLimitBurstFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCHeavyRateLimitFlag.Name) {
+		rpc.RateLimit.HeavyRate = ctx.GlobalFloat64(RPCHeavyRateLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCHeavyRateLimitBurstFlag.Name) {
+		rpc.RateLimit.HeavyBurst = ctx.GlobalInt(RPCHeavyRateLimitBurstFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCHeavyMethodsFlag.Name) {
+		rpc.RateLimit.HeavyMethods = splitAndTrim(ctx.GlobalString(RPCHeavyMethodsFlag.Name))
+	}
+	if ctx.GlobalIsSet(RPCRateLimitAPIKeysFlag.Name) {
+		rpc.RateLimit.APIKeys = splitAndTrim(ctx.GlobalString(RPCRateLimitAPIKeysFlag.Name))
+	}
+	if rpc.RateLimit.Rate > 0 || rpc.RateLimit.HeavyRate > 0 {
+		logger.Info("Set the rate limit of RPC-HTTP server", "rate", rpc.RateLimit.Rate, "burst", rpc.RateLimit.Burst,
+			"heavyRate", rpc.RateLimit.HeavyRate, "heavyBurst", rpc.RateLimit.HeavyBurst, "heavyMethods", rpc.RateLimit.HeavyMethods,
+			"apiKeys", len(rpc.RateLimit.APIKeys))
+	}
+}
+
+// setRPCBatchLimit sets the batch limits of RPC servers from the set command line flags.
+func setRPCBatchLimit(ctx *cli.Context) {
+	isSet := false
+	if ctx.GlobalIsSet(RPCBatchLimitFlag.Name) {
+		rpc.BatchLimit.MaxItems = ctx.GlobalInt(RPCBatchLimitFlag.Name)
+		isSet = true
+	}
+	if ctx.GlobalIsSet(RPCBatchMaxResponseSizeFlag.Name) {
+		rpc.BatchLimit.MaxResponseSize = ctx.GlobalInt(RPCBatchMaxResponseSizeFlag.Name)
+		isSet = true
+	}
+	if ctx.GlobalIsSet(RPCBatchParallelismFlag.Name) {
+		rpc.BatchLimit.Parallelism = ctx.GlobalInt(RPCBatchParallelismFlag.Name)
+		isSet = true
+	}
+	if !isSet {
+		return
+	}
+	logger.Info("Set the batch limit of RPC servers", "maxItems", rpc.BatchLimit.MaxItems,
+		"maxResponseSize", rpc.BatchLimit.MaxResponseSize, "parallelism", rpc.BatchLimit.Parallelism)
 }
 
 // setWS creates the WebSocket RPC listener interface string from the set
@@ -1198,12 +1460,69 @@ func setgRPC(ctx *cli.Context, cfg *node.Config) {
 	if ctx.GlobalIsSet(GRPCPortFlag.Name) {
 		cfg.GRPCPort = ctx.GlobalInt(GRPCPortFlag.Name)
 	}
+	if ctx.GlobalIsSet(GRPCApiFlag.Name) {
+		cfg.GRPCModules = splitAndTrim(ctx.GlobalString(GRPCApiFlag.Name))
+	}
+}
+
+// setAuthRPC creates the authenticated RPC listener interface string from the set
+// command line flags, returning empty if the authenticated endpoint is disabled.
+func setAuthRPC(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalBool(AuthRPCEnabledFlag.Name) && cfg.AuthHost == "" {
+		cfg.AuthHost = ctx.GlobalString(AuthRPCListenAddrFlag.Name)
+	}
+	if ctx.GlobalIsSet(AuthRPCPortFlag.Name) {
+		cfg.AuthPort = ctx.GlobalInt(AuthRPCPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(AuthRPCApiFlag.Name) {
+		cfg.AuthModules = splitAndTrim(ctx.GlobalString(AuthRPCApiFlag.Name))
+	}
+	if ctx.GlobalIsSet(AuthRPCVirtualHostsFlag.Name) {
+		cfg.AuthVirtualHosts = splitAndTrim(ctx.GlobalString(AuthRPCVirtualHostsFlag.Name))
+	}
+	if ctx.GlobalIsSet(AuthRPCJWTSecretFlag.Name) {
+		cfg.JWTSecret = ctx.GlobalString(AuthRPCJWTSecretFlag.Name)
+	}
+}
+
+// setGraphQL creates the GraphQL listener interface string from the set
+// command line flags, returning empty if the GraphQL endpoint is disabled.
+func setGraphQL(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalBool(GraphQLEnabledFlag.Name) && cfg.GraphQLHost == "" {
+		cfg.GraphQLHost = ctx.GlobalString(GraphQLListenAddrFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLPortFlag.Name) {
+		cfg.GraphQLPort = ctx.GlobalInt(GraphQLPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLCORSDomainFlag.Name) {
+		cfg.GraphQLCors = splitAndTrim(ctx.GlobalString(GraphQLCORSDomainFlag.Name))
+	}
+	if ctx.GlobalIsSet(GraphQLVirtualHostsFlag.Name) {
+		cfg.GraphQLVirtualHosts = splitAndTrim(ctx.GlobalString(GraphQLVirtualHostsFlag.Name))
+	}
+	if ctx.GlobalIsSet(GraphQLMaxDepthFlag.Name) {
+		cfg.GraphQLMaxDepth = ctx.GlobalInt(GraphQLMaxDepthFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLMaxParallelismFlag.Name) {
+		cfg.GraphQLMaxParallelism = ctx.GlobalInt(GraphQLMaxParallelismFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLTimeoutFlag.Name) {
+		cfg.GraphQLTimeout = ctx.GlobalDuration(GraphQLTimeoutFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLMaxBlockSpanFlag.Name) {
+		cfg.GraphQLMaxBlockSpan = ctx.GlobalUint64(GraphQLMaxBlockSpanFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLMaxLogsFlag.Name) {
+		cfg.GraphQLMaxLogs = ctx.GlobalInt(GraphQLMaxLogsFlag.Name)
+	}
 }
 
 // setAPIConfig sets configurations for specific APIs.
 func setAPIConfig(ctx *cli.Context) {
 	filters.GetLogsDeadline = ctx.GlobalDuration(APIFilterGetLogsDeadlineFlag.Name)
 	filters.GetLogsMaxItems = ctx.GlobalInt(APIFilterGetLogsMaxItemsFlag.Name)
+	filters.GetLogsMaxBlockSpan = ctx.GlobalUint64(APIFilterGetLogsMaxBlockSpanFlag.Name)
+	api.BlockRangeMaxSpan = ctx.GlobalUint64(APIBlockRangeMaxSpanFlag.Name)
 }
 
 // MakeAddress converts an account specified directly as a hex encoded string or
@@ -1344,6 +1663,8 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setHTTP(ctx, cfg)
 	setWS(ctx, cfg)
 	setgRPC(ctx, cfg)
+	setAuthRPC(ctx, cfg)
+	setGraphQL(ctx, cfg)
 	setAPIConfig(ctx)
 	setNodeUserIdent(ctx, cfg)
 
@@ -1375,6 +1696,13 @@ func setTxPool(ctx *cli.Context, cfg *blockchain.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolJournalIntervalFlag.Name) {
 		cfg.JournalInterval = ctx.GlobalDuration(TxPoolJournalIntervalFlag.Name)
 	}
+	cfg.Persist = ctx.GlobalIsSet(TxPoolPersistFlag.Name)
+	if ctx.GlobalIsSet(TxPoolPersistIntervalFlag.Name) {
+		cfg.PersistInterval = ctx.GlobalDuration(TxPoolPersistIntervalFlag.Name)
+	}
+	if ctx.GlobalIsSet(TxPoolMirrorSourceFlag.Name) {
+		cfg.MirrorSource = ctx.GlobalString(TxPoolMirrorSourceFlag.Name)
+	}
 	if ctx.GlobalIsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.GlobalUint64(TxPoolPriceLimitFlag.Name)
 	}
@@ -1399,6 +1727,62 @@ func setTxPool(ctx *cli.Context, cfg *blockchain.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.GlobalDuration(TxPoolLifetimeFlag.Name)
 	}
+
+	if ctx.GlobalIsSet(TxPoolAllowedSendersFlag.Name) {
+		cfg.AllowedSenders = parseAddresses(TxPoolAllowedSendersFlag.Name, ctx.GlobalString(TxPoolAllowedSendersFlag.Name))
+	}
+	if ctx.GlobalIsSet(TxPoolDeniedSendersFlag.Name) {
+		cfg.DeniedSenders = parseAddresses(TxPoolDeniedSendersFlag.Name, ctx.GlobalString(TxPoolDeniedSendersFlag.Name))
+	}
+	if ctx.GlobalIsSet(TxPoolAllowedRecipientsFlag.Name) {
+		cfg.AllowedRecipients = parseAddresses(TxPoolAllowedRecipientsFlag.Name, ctx.GlobalString(TxPoolAllowedRecipientsFlag.Name))
+	}
+	if ctx.GlobalIsSet(TxPoolDeniedRecipientsFlag.Name) {
+		cfg.DeniedRecipients = parseAddresses(TxPoolDeniedRecipientsFlag.Name, ctx.GlobalString(TxPoolDeniedRecipientsFlag.Name))
+	}
+	if ctx.GlobalIsSet(TxPoolDisabledTxTypesFlag.Name) {
+		cfg.DisabledTxTypes = parseTxTypes(TxPoolDisabledTxTypesFlag.Name, ctx.GlobalString(TxPoolDisabledTxTypesFlag.Name))
+	}
+	if ctx.GlobalIsSet(TxPoolMaxTxsPerSenderFlag.Name) {
+		cfg.MaxTxsPerSender = ctx.GlobalUint64(TxPoolMaxTxsPerSenderFlag.Name)
+	}
+}
+
+// parseAddresses parses a comma separated list of addresses given by the flag.
+func parseAddresses(flagName, input string) []common.Address {
+	var addrs []common.Address
+	for _, addr := range splitAndTrim(input) {
+		if addr == "" {
+			continue
+		}
+		if !common.IsHexAddress(addr) {
+			log.Fatalf("Option %q: invalid address %q", flagName, addr)
+		}
+		addrs = append(addrs, common.HexToAddress(addr))
+	}
+	return addrs
+}
+
+// parseTxTypes parses a comma separated list of transaction type names given by the flag.
+func parseTxTypes(flagName, input string) []types.TxType {
+	var txTypes []types.TxType
+	for _, name := range splitAndTrim(input) {
+		if name == "" {
+			continue
+		}
+		txType := types.TxTypeLast
+		for t := types.TxTypeLegacyTransaction; t < types.TxTypeLast; t++ {
+			if t.String() == name {
+				txType = t
+				break
+			}
+		}
+		if txType == types.TxTypeLast {
+			log.Fatalf("Option %q: invalid transaction type %q", flagName, name)
+		}
+		txTypes = append(txTypes, txType)
+	}
+	return txTypes
 }
 
 // CheckExclusive verifies that only a single instance of the provided flags was
@@ -1467,8 +1851,8 @@ func SetKlayConfig(ctx *cli.Context, stack *node.Node, cfg *cn.Config) {
 
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
-		if cfg.SyncMode != downloader.FullSync {
-			log.Fatalf("only syncmode=full can be used for syncmode!")
+		if cfg.SyncMode != downloader.FullSync && cfg.SyncMode != downloader.SnapSync {
+			log.Fatalf("only syncmode=full or syncmode=snap can be used for syncmode!")
 		}
 	}
 
@@ -1498,6 +1882,7 @@ func SetKlayConfig(ctx *cli.Context, stack *node.Node, cfg *cn.Config) {
 	cfg.LevelDBBufferPool = !ctx.GlobalIsSet(LevelDBNoBufferPoolFlag.Name)
 	cfg.EnableDBPerfMetrics = !ctx.GlobalIsSet(DBNoPerformanceMetricsFlag.Name)
 	cfg.LevelDBCacheSize = ctx.GlobalInt(LevelDBCacheSizeFlag.Name)
+	cfg.AncientThreshold = ctx.GlobalUint64(AncientThresholdFlag.Name)
 
 	cfg.DynamoDBConfig.TableName = ctx.GlobalString(DynamoDBTableNameFlag.Name)
 	cfg.DynamoDBConfig.Region = ctx.GlobalString(DynamoDBRegionFlag.Name)
@@ -1519,6 +1904,19 @@ func SetKlayConfig(ctx *cli.Context, stack *node.Node, cfg *cn.Config) {
 	common.DefaultCacheType = common.CacheType(ctx.GlobalInt(CacheTypeFlag.Name))
 	cfg.TrieBlockInterval = ctx.GlobalUint(TrieBlockIntervalFlag.Name)
 	cfg.TriesInMemory = ctx.GlobalUint64(TriesInMemoryFlag.Name)
+	cfg.LivePruning = ctx.GlobalBool(LivePruningFlag.Name)
+	cfg.LivePruningRetention = ctx.GlobalUint64(LivePruningRetentionFlag.Name)
+	if cfg.LivePruning && cfg.NoPruning {
+		log.Fatalf("--%s cannot be used with the archive gcmode", LivePruningFlag.Name)
+	}
+
+	if ctx.GlobalBool(SnapshotFlag.Name) {
+		cfg.SnapshotCacheSize = ctx.GlobalInt(SnapshotCacheSizeFlag.Name)
+		if cfg.SnapshotCacheSize <= 0 {
+			log.Fatalf("--%s must be positive if the snapshot is enabled", SnapshotCacheSizeFlag.Name)
+		}
+		cfg.SnapshotAsyncGen = ctx.GlobalBoolT(SnapshotAsyncGenFlag.Name)
+	}
 
 	if ctx.GlobalIsSet(CacheScaleFlag.Name) {
 		common.CacheScale = ctx.GlobalInt(CacheScaleFlag.Name)
@@ -1629,6 +2027,23 @@ func RegisterService(stack *node.Node, cfg *sc.SCConfig) {
 	}
 }
 
+// RegisterGraphQLService adds a GraphQL server serving the chain data of the CN service to the stack.
+func RegisterGraphQLService(stack *node.Node, endpoint string, cors, vhosts []string, timeouts rpc.HTTPTimeouts, config graphql.Config) {
+	if endpoint == "" {
+		return
+	}
+	err := stack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
+		var fullNode *cn.CN
+		if err := ctx.Service(&fullNode); err != nil {
+			return nil, err
+		}
+		return graphql.New(fullNode.APIBackend, endpoint, cors, vhosts, timeouts, config)
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the GraphQL service: %v", err)
+	}
+}
+
 // RegisterChainDataFetcherService adds a ChainDataFetcher to the stack
 func RegisterChainDataFetcherService(stack *node.Node, cfg *chaindatafetcher.ChainDataFetcherConfig) {
 	if cfg.EnabledChainDataFetcher {
@@ -37,6 +37,7 @@ import (
 	"github.com/klaytn/klaytn/datasync/chaindatafetcher/kas"
 	"github.com/klaytn/klaytn/datasync/dbsyncer"
 	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/graphql"
 	"github.com/klaytn/klaytn/node"
 	"github.com/klaytn/klaytn/node/cn"
 	"github.com/klaytn/klaytn/node/sc"
@@ -391,6 +392,13 @@ func MakeFullNode(ctx *cli.Context) *node.Node {
 	} else {
 		utils.RegisterCNService(stack, &cfg.CN)
 	}
+	utils.RegisterGraphQLService(stack, cfg.Node.GraphQLEndpoint(), cfg.Node.GraphQLCors, cfg.Node.GraphQLVirtualHosts, cfg.Node.HTTPTimeouts, graphql.Config{
+		MaxDepth:       cfg.Node.GraphQLMaxDepth,
+		MaxParallelism: cfg.Node.GraphQLMaxParallelism,
+		Timeout:        cfg.Node.GraphQLTimeout,
+		MaxBlockSpan:   cfg.Node.GraphQLMaxBlockSpan,
+		MaxLogs:        cfg.Node.GraphQLMaxLogs,
+	})
 	utils.RegisterService(stack, &scfg)
 
 	dbfg := makeDBSyncerConfig(ctx)
@@ -42,6 +42,9 @@ var CommonNodeFlags = []cli.Flag{
 	utils.TxPoolAllowLocalAnchorTxFlag,
 	utils.TxPoolJournalFlag,
 	utils.TxPoolJournalIntervalFlag,
+	utils.TxPoolPersistFlag,
+	utils.TxPoolPersistIntervalFlag,
+	utils.TxPoolMirrorSourceFlag,
 	utils.TxPoolPriceLimitFlag,
 	utils.TxPoolPriceBumpFlag,
 	utils.TxPoolExecSlotsAccountFlag,
@@ -50,6 +53,12 @@ var CommonNodeFlags = []cli.Flag{
 	utils.TxPoolNonExecSlotsAllFlag,
 	utils.TxPoolLifetimeFlag,
 	utils.TxPoolKeepLocalsFlag,
+	utils.TxPoolAllowedSendersFlag,
+	utils.TxPoolDeniedSendersFlag,
+	utils.TxPoolAllowedRecipientsFlag,
+	utils.TxPoolDeniedRecipientsFlag,
+	utils.TxPoolDisabledTxTypesFlag,
+	utils.TxPoolMaxTxsPerSenderFlag,
 	utils.SyncModeFlag,
 	utils.GCModeFlag,
 	utils.LightKDFFlag,
@@ -58,6 +67,7 @@ var CommonNodeFlags = []cli.Flag{
 	utils.LevelDBCompressionTypeFlag,
 	utils.LevelDBNoBufferPoolFlag,
 	utils.DBNoPerformanceMetricsFlag,
+	utils.AncientThresholdFlag,
 	utils.DynamoDBTableNameFlag,
 	utils.DynamoDBRegionFlag,
 	utils.DynamoDBIsProvisionedFlag,
@@ -70,6 +80,11 @@ var CommonNodeFlags = []cli.Flag{
 	utils.TrieMemoryCacheSizeFlag,
 	utils.TrieBlockIntervalFlag,
 	utils.TriesInMemoryFlag,
+	utils.LivePruningFlag,
+	utils.LivePruningRetentionFlag,
+	utils.SnapshotFlag,
+	utils.SnapshotCacheSizeFlag,
+	utils.SnapshotAsyncGenFlag,
 	utils.CacheTypeFlag,
 	utils.CacheScaleFlag,
 	utils.CacheUsageLevelFlag,
@@ -112,6 +127,8 @@ var CommonNodeFlags = []cli.Flag{
 	utils.DaemonPathFlag,
 	utils.ConfigFileFlag,
 	utils.APIFilterGetLogsMaxItemsFlag,
+	utils.APIFilterGetLogsMaxBlockSpanFlag,
+	utils.APIBlockRangeMaxSpanFlag,
 	utils.APIFilterGetLogsDeadlineFlag,
 }
 
@@ -128,7 +145,33 @@ var CommonRPCFlags = []cli.Flag{
 	utils.GRPCEnabledFlag,
 	utils.GRPCListenAddrFlag,
 	utils.GRPCPortFlag,
+	utils.GRPCApiFlag,
+	utils.AuthRPCEnabledFlag,
+	utils.AuthRPCListenAddrFlag,
+	utils.AuthRPCPortFlag,
+	utils.AuthRPCApiFlag,
+	utils.AuthRPCVirtualHostsFlag,
+	utils.AuthRPCJWTSecretFlag,
+	utils.GraphQLEnabledFlag,
+	utils.GraphQLListenAddrFlag,
+	utils.GraphQLPortFlag,
+	utils.GraphQLCORSDomainFlag,
+	utils.GraphQLVirtualHostsFlag,
+	utils.GraphQLMaxDepthFlag,
+	utils.GraphQLMaxParallelismFlag,
+	utils.GraphQLTimeoutFlag,
+	utils.GraphQLMaxBlockSpanFlag,
+	utils.GraphQLMaxLogsFlag,
 	utils.RPCConcurrencyLimit,
+	utils.RPCRateLimitFlag,
+	utils.RPCRateLimitBurstFlag,
+	utils.RPCHeavyRateLimitFlag,
+	utils.RPCHeavyRateLimitBurstFlag,
+	utils.RPCHeavyMethodsFlag,
+	utils.RPCRateLimitAPIKeysFlag,
+	utils.RPCBatchLimitFlag,
+	utils.RPCBatchMaxResponseSizeFlag,
+	utils.RPCBatchParallelismFlag,
 	utils.WSApiFlag,
 	utils.WSAllowedOriginsFlag,
 	utils.WSMaxSubscriptionPerConn,
@@ -317,3 +360,8 @@ var DBMigrationFlags = []cli.Flag{
 	utils.DstDynamoDBReadCapacityFlag,
 	utils.DstDynamoDBWriteCapacityFlag,
 }
+
+var PruneStateFlags = []cli.Flag{
+	utils.PruneStateBlocksFlag,
+	utils.PruneStateBloomSizeFlag,
+}
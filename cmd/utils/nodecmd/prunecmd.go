@@ -0,0 +1,119 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"errors"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	pruneStateFlags = append(dbFlags, PruneStateFlags...)
+
+	PruneStateCommand = cli.Command{
+		Action:   utils.MigrateFlags(pruneState),
+		Name:     "prune-state",
+		Usage:    "Prune stale state trie nodes",
+		Flags:    pruneStateFlags,
+		Category: "DB MIGRATION COMMANDS",
+		Description: `
+The prune-state command deletes the state trie nodes which are not reachable
+from the state roots of the recent blocks (see --prune.blocks).
+
+The reachable trie nodes are marked in a bloom filter (see --prune.bloom-size),
+so a few stale nodes may remain by false positives of the bloom filter.
+Unlike the state migration, it does not need the extra disk space for a new database.
+
+Note: Do not use prune-state while a node is executing.
+Note: The state of the blocks older than the retained ones will not be available.
+Note: This feature is not provided for the database with the live pruning enabled.`,
+	}
+)
+
+func pruneState(ctx *cli.Context) error {
+	stack, cfg := makeConfigNode(ctx)
+	dbc := &database.DBConfig{
+		Dir: "chaindata", DBType: cfg.CN.DBType, SingleDB: cfg.CN.SingleDB, NumStateTrieShards: cfg.CN.NumStateTrieShards,
+		LevelDBCacheSize: cfg.CN.LevelDBCacheSize, OpenFilesLimit: database.GetOpenFilesLimit(), LevelDBCompression: cfg.CN.LevelDBCompression,
+		LevelDBBufferPool: cfg.CN.LevelDBBufferPool, DynamoDBConfig: &cfg.CN.DynamoDBConfig,
+	}
+	chainDB := stack.OpenDatabase(dbc)
+	defer chainDB.Close()
+
+	roots, err := retainedStateRoots(chainDB, ctx.GlobalUint64(utils.PruneStateBlocksFlag.Name))
+	if err != nil {
+		return err
+	}
+	pruner, err := state.NewPruner(chainDB, ctx.GlobalUint64(utils.PruneStateBloomSizeFlag.Name))
+	if err != nil {
+		return err
+	}
+	return pruner.Prune(roots)
+}
+
+// retainedStateRoots returns the state roots of the given number of recent
+// blocks which exist in the database. Since only the state tries of some blocks
+// are committed to the database, the number of returned roots can be smaller.
+func retainedStateRoots(db database.DBManager, blocks uint64) ([]common.Hash, error) {
+	headHash := db.ReadHeadBlockHash()
+	headNumber := db.ReadHeaderNumber(headHash)
+	if headNumber == nil {
+		return nil, errors.New("failed to find the head block")
+	}
+	head := db.ReadHeader(headHash, *headNumber)
+	if head == nil {
+		return nil, errors.New("failed to find the head block")
+	}
+	if ok, _ := db.HasStateTrieNode(head.Root.Bytes()); !ok {
+		return nil, errors.New("the state of the head block does not exist")
+	}
+
+	var (
+		roots []common.Hash
+		seen  = make(map[common.Hash]struct{})
+	)
+	for i := uint64(0); i < blocks && i <= *headNumber; i++ {
+		number := *headNumber - i
+		header := db.ReadHeader(db.ReadCanonicalHash(number), number)
+		if header == nil {
+			continue
+		}
+		if _, ok := seen[header.Root]; ok {
+			continue
+		}
+		if ok, _ := db.HasStateTrieNode(header.Root.Bytes()); ok {
+			roots = append(roots, header.Root)
+			seen[header.Root] = struct{}{}
+		}
+	}
+	// Retain the base state of the snapshot as well, so that the snapshot can be
+	// regenerated from it.
+	if root := db.ReadSnapshotRoot(); root != (common.Hash{}) {
+		if _, ok := seen[root]; !ok {
+			if ok, _ := db.HasStateTrieNode(root.Bytes()); ok {
+				roots = append(roots, root)
+			}
+		}
+	}
+	logger.Info("Retaining state tries", "head", *headNumber, "blocks", blocks, "roots", len(roots))
+	return roots, nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/crypto/sha3"
 	"github.com/klaytn/klaytn/governance"
@@ -122,6 +123,9 @@ var (
 	// the previous block's timestamp + the minimum block period.
 	ErrInvalidTimestamp = errors.New("invalid timestamp")
 
+	// errUnexpectedBaseFee is returned if a block before the magma fork has a base fee.
+	errUnexpectedBaseFee = errors.New("unexpected base fee before magma fork")
+
 	// errInvalidVotingChain is returned if an authorization list is attempted to
 	// be modified via out-of-range or non-contiguous headers.
 	errInvalidVotingChain = errors.New("invalid voting chain")
@@ -329,6 +333,14 @@ func (c *Clique) verifyCascadingFields(chain consensus.ChainReader, header *type
 	if parent.Time.Uint64()+c.config.Period > header.Time.Uint64() {
 		return ErrInvalidTimestamp
 	}
+	// Clique has no governance over the base fee, so the chain config is used
+	if chain.Config().IsMagma(header.Number) {
+		if err := misc.VerifyMagmaHeader(parent, header, chain.Config().Governance.KIP71Config()); err != nil {
+			return err
+		}
+	} else if header.BaseFee != nil {
+		return errUnexpectedBaseFee
+	}
 	// Retrieve the snapshot needed to verify this header and cache it
 	snap, err := c.snapshot(chain, number-1, header.ParentHash, parents)
 	if err != nil {
@@ -545,6 +557,10 @@ func (c *Clique) Prepare(chain consensus.ChainReader, header *types.Header) erro
 		header.Time = big.NewInt(t.Unix())
 		header.TimeFoS = uint8((t.UnixNano() / 1000 / 1000 / 10) % 100)
 	}
+	// set the base fee calculated from the parent after the magma fork
+	if chain.Config().IsMagma(header.Number) {
+		header.BaseFee = misc.NextMagmaBlockBaseFee(parent, chain.Config().Governance.KIP71Config())
+	}
 	return nil
 }
 
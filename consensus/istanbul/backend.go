@@ -85,4 +85,7 @@ type Backend interface {
 	SetCurrentView(view *View)
 
 	NodeType() common.ConnType
+
+	// HandleEvidence stores the evidence of a misbehaving validator and posts an EvidenceEvent
+	HandleEvidence(evidence *Evidence)
 }
@@ -21,8 +21,10 @@
 package backend
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 
@@ -32,6 +34,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/consensus/istanbul"
+	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
 	"github.com/klaytn/klaytn/networks/rpc"
 )
 
@@ -487,3 +490,70 @@ func (api *APIExtension) GetBlockWithConsensusInfoByHash(blockHash common.Hash)
 func (api *API) GetTimeout() uint64 {
 	return istanbul.DefaultConfig.Timeout
 }
+
+// GetConsensusRounds returns the consensus rounds of the latest `count` sequences observed by this node,
+// from the oldest one. All kept sequences are returned if count is not given.
+func (api *API) GetConsensusRounds(count *int) []*istanbulCore.ConsensusRound {
+	n := 0
+	if count != nil {
+		n = *count
+	}
+	return api.istanbul.core.GetConsensusRounds(n)
+}
+
+// GetEvidences returns the evidences of validators signing conflicting messages found by this node
+// in the sequences from `start` to `end`. The evidences of all sequences from `start` are returned
+// if `end` is not given or is the latest block number, since the sequence in progress may have evidences.
+func (api *API) GetEvidences(start, end *rpc.BlockNumber) ([]*istanbul.Evidence, error) {
+	if (start != nil && *start == rpc.PendingBlockNumber) || (end != nil && *end == rpc.PendingBlockNumber) {
+		return nil, errPendingNotAllowed
+	}
+	from, to := uint64(0), uint64(math.MaxUint64)
+	if start != nil {
+		if *start == rpc.LatestBlockNumber {
+			from = api.chain.CurrentHeader().Number.Uint64()
+		} else {
+			from = uint64(start.Int64())
+		}
+	}
+	if end != nil && *end != rpc.LatestBlockNumber {
+		to = uint64(end.Int64()) + 1
+	}
+	if from >= to {
+		return nil, errStartLargerThanEnd
+	}
+	return api.istanbul.evidences(from, to), nil
+}
+
+// Evidences creates a subscription that fires for each evidence of a validator signing conflicting messages.
+func (api *API) Evidences(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		evidenceSub := api.istanbul.istanbulEventMux.Subscribe(istanbul.EvidenceEvent{})
+		defer evidenceSub.Unsubscribe()
+
+		for {
+			select {
+			case ev, ok := <-evidenceSub.Chan():
+				if !ok {
+					return
+				}
+				if e, ok := ev.Data.(istanbul.EvidenceEvent); ok {
+					notifier.Notify(rpcSub.ID, e.Evidence)
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/klaytn/klaytn/governance"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/reward"
+	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/database"
 )
 
@@ -341,7 +342,9 @@ func (sb *backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 	err := sb.VerifyHeader(sb.chain, block.Header(), false)
 	// ignore errEmptyCommittedSeals error because we don't have the committed seals yet
 	if err == nil || err == errEmptyCommittedSeals {
-		return 0, nil
+		// the values of the governance parameter contract are verified against the state of the parent,
+		// which is available to the validators but not always to the nodes verifying the header only
+		return 0, sb.governance.VerifyGovParamChanges(block.Header())
 	} else if err == consensus.ErrFutureBlock {
 		return time.Unix(block.Header().Time.Int64(), 0).Sub(now()), consensus.ErrFutureBlock
 	}
@@ -423,3 +426,30 @@ func (sb *backend) HasBadProposal(hash common.Hash) bool {
 	}
 	return sb.hasBadBlock(hash)
 }
+
+// HandleEvidence implements istanbul.Backend.HandleEvidence
+func (sb *backend) HandleEvidence(evidence *istanbul.Evidence) {
+	data, err := rlp.EncodeToBytes(evidence)
+	if err != nil {
+		sb.logger.Error("Failed to encode the evidence", "err", err)
+		return
+	}
+	if err := sb.db.WriteIstanbulEvidence(evidence.Sequence, evidence.Hash(), data); err != nil {
+		sb.logger.Error("Failed to write the evidence", "err", err)
+	}
+	go sb.istanbulEventMux.Post(istanbul.EvidenceEvent{Evidence: evidence})
+}
+
+// evidences returns the evidences found in the sequences in the range [start, end).
+func (sb *backend) evidences(start, end uint64) []*istanbul.Evidence {
+	var evidences []*istanbul.Evidence
+	for _, data := range sb.db.ReadIstanbulEvidences(start, end) {
+		evidence := new(istanbul.Evidence)
+		if err := rlp.DecodeBytes(data, evidence); err != nil {
+			sb.logger.Error("Failed to decode the evidence", "err", err)
+			continue
+		}
+		evidences = append(evidences, evidence)
+	}
+	return evidences
+}
@@ -33,12 +33,14 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus/istanbul"
 	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
 	"github.com/klaytn/klaytn/consensus/istanbul/validator"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/storage/database"
@@ -1055,3 +1057,56 @@ func TestGetProposer(t *testing.T) {
 		t.Errorf("proposer mismatch: have %v, want %v", actual.Hex(), expected.Hex())
 	}
 }
+
+func TestHandleEvidence(t *testing.T) {
+	b := newTestBackend()
+	api := &API{istanbul: b}
+
+	sub := b.EventMux().Subscribe(istanbul.EvidenceEvent{})
+	defer sub.Unsubscribe()
+
+	var evidences []*istanbul.Evidence
+	for seq := uint64(5); seq <= 7; seq++ {
+		evidence := &istanbul.Evidence{
+			Type:      istanbul.DoublePrepare,
+			Validator: b.Address(),
+			Sequence:  seq,
+			Messages:  []hexutil.Bytes{{byte(seq)}, {byte(seq + 1)}},
+		}
+		b.HandleEvidence(evidence)
+		evidences = append(evidences, evidence)
+
+		select {
+		case ev := <-sub.Chan():
+			if have := ev.Data.(istanbul.EvidenceEvent).Evidence; have != evidence {
+				t.Errorf("evidence event mismatch: have %v, want %v", have, evidence)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	start, end := rpc.BlockNumber(6), rpc.BlockNumber(6)
+	for _, test := range []struct {
+		start, end *rpc.BlockNumber
+		expected   []*istanbul.Evidence
+	}{
+		{nil, nil, evidences},
+		{&start, nil, evidences[1:]},
+		{nil, &end, evidences[:2]},
+		{&start, &end, evidences[1:2]},
+	} {
+		have, err := api.GetEvidences(test.start, test.end)
+		if err != nil {
+			t.Fatalf("failed to get evidences: %v", err)
+		}
+		if len(have) != len(test.expected) {
+			t.Fatalf("evidences length mismatch: have %v, want %v", len(have), len(test.expected))
+		}
+		for i := range have {
+			if have[i].Hash() != test.expected[i].Hash() {
+				t.Errorf("evidence mismatch: have %v, want %v", have[i], test.expected[i])
+			}
+		}
+	}
+}
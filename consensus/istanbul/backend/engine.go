@@ -38,6 +38,7 @@ import (
 	"github.com/klaytn/klaytn/consensus/istanbul"
 	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
 	"github.com/klaytn/klaytn/consensus/istanbul/validator"
+	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/crypto/sha3"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
@@ -87,6 +88,8 @@ var (
 	errEmptyCommittedSeals = errors.New("zero committed seals")
 	// errMismatchTxhashes is returned if the TxHash in header is mismatch.
 	errMismatchTxhashes = errors.New("mismatch transactions hashes")
+	// errUnexpectedBaseFee is returned if a block before the magma fork has a base fee.
+	errUnexpectedBaseFee = errors.New("unexpected base fee before magma fork")
 )
 var (
 	defaultBlockScore = big.NewInt(1)
@@ -160,6 +163,18 @@ func (sb *backend) verifyCascadingFields(chain consensus.ChainReader, header *ty
 	if parent.Time.Uint64()+sb.config.BlockPeriod > header.Time.Uint64() {
 		return errInvalidTimestamp
 	}
+	// Verify the base fee which is calculated from the parent after the magma fork
+	if chain.Config().IsMagma(header.Number) {
+		kip71, err := sb.governance.KIP71ConfigAt(number)
+		if err != nil {
+			return err
+		}
+		if err := misc.VerifyMagmaHeader(parent, header, kip71); err != nil {
+			return err
+		}
+	} else if header.BaseFee != nil {
+		return errUnexpectedBaseFee
+	}
 	if err := sb.verifySigner(chain, header, parents); err != nil {
 		return err
 	}
@@ -301,6 +316,15 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 	// use the same blockscore for all blocks
 	header.BlockScore = defaultBlockScore
 
+	// set the base fee calculated from the parent after the magma fork
+	if chain.Config().IsMagma(header.Number) {
+		kip71, err := sb.governance.KIP71ConfigAt(number)
+		if err != nil {
+			return err
+		}
+		header.BaseFee = misc.NextMagmaBlockBaseFee(parent, kip71)
+	}
+
 	// Assemble the voting snapshot
 	snap, err := sb.snapshot(chain, number-1, header.ParentHash, nil)
 	if err != nil {
@@ -309,7 +333,11 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 
 	// If it reaches the Epoch, governance config will be added to block header
 	if number%sb.governance.Epoch() == 0 {
-		if g := sb.governance.GetGovernanceChange(); g != nil {
+		g, err := sb.governance.GetGovernanceChangeAt(header)
+		if err != nil {
+			return err
+		}
+		if g != nil {
 			if data, err := json.Marshal(g); err != nil {
 				logger.Error("Failed to encode governance changes!! Possible configuration mismatch!! ")
 			} else {
@@ -179,6 +179,7 @@ func (s *Snapshot) apply(headers []*types.Header, gov *governance.Governance, ad
 		snap.ValSet, snap.Votes, snap.Tally = gov.HandleGovernanceVote(snap.ValSet, snap.Votes, snap.Tally, header, validator, addr)
 
 		if number%snap.Epoch == 0 {
+			gov.WriteEpochRecord(number, snap.Epoch, header.Governance, snap.Tally)
 			if len(header.Governance) > 0 {
 				gov.UpdateGovernance(number, header.Governance)
 			}
@@ -61,6 +61,8 @@ func New(backend istanbul.Backend, config *istanbul.Config) Engine {
 		councilSizeGauge:   metrics.NewRegisteredGauge("consensus/istanbul/core/councilSize", nil),
 		committeeSizeGauge: metrics.NewRegisteredGauge("consensus/istanbul/core/committeeSize", nil),
 		hashLockGauge:      metrics.NewRegisteredGauge("consensus/istanbul/core/hashLock", nil),
+		rounds:             newRoundRecorder(consensusRoundsLimit),
+		evidences:          newEvidenceCollector(),
 	}
 	c.validateFn = c.checkValidatorSignature
 	return c
@@ -109,6 +111,11 @@ type core struct {
 
 	councilSizeGauge   metrics.Gauge
 	committeeSizeGauge metrics.Gauge
+
+	// the telemetry of the consensus rounds of the latest sequences
+	rounds *roundRecorder
+	// the collector of the evidences of validators signing conflicting messages
+	evidences *evidenceCollector
 }
 
 func (c *core) finalizeMessage(msg *message) ([]byte, error) {
@@ -183,9 +190,11 @@ func (c *core) commit() {
 	proposal := c.current.Proposal()
 	if proposal != nil {
 		committedSeals := make([][]byte, c.current.Commits.Size())
+		sealers := make([]common.Address, c.current.Commits.Size())
 		for i, v := range c.current.Commits.Values() {
 			committedSeals[i] = make([]byte, types.IstanbulExtraSeal)
 			copy(committedSeals[i][:], v.CommittedSeal[:])
+			sealers[i] = v.Address
 		}
 
 		if err := c.backend.Commit(proposal, committedSeals); err != nil {
@@ -193,6 +202,7 @@ func (c *core) commit() {
 			c.sendNextRoundChange("commit failure")
 			return
 		}
+		c.rounds.committed(c.currentView(), proposal.Hash(), sealers)
 	} else {
 		// TODO-Klaytn never happen, but if proposal is nil, mining is not working.
 		logger.Error("istanbul.core current.Proposal is NULL")
@@ -274,6 +284,7 @@ func (c *core) startNewRound(round *big.Int) {
 	c.updateRoundState(newView, c.valSet, roundChange)
 	// Calculate new proposer
 	c.valSet.CalcProposer(lastProposer, newView.Round.Uint64())
+	c.rounds.newRound(newView, c.valSet.GetProposer().Address())
 	c.waitingForRoundChange = false
 	c.setState(StateAcceptRequest)
 	if roundChange && c.isProposer() && c.current != nil {
@@ -305,6 +316,7 @@ func (c *core) catchUpRound(view *istanbul.View) {
 	// Need to keep block locked for round catching up
 	c.updateRoundState(view, c.valSet, true)
 	c.roundChangeSet.Clear(view.Round)
+	c.rounds.newRound(view, c.valSet.GetProposer().Address())
 
 	c.newRoundChangeTimer()
 	logger.Warn("[RC] Catch up round", "new_round", view.Round, "new_seq", view.Sequence, "new_proposer", c.valSet.GetProposer())
@@ -32,6 +32,7 @@ Core package is composed of following files
  - `core.go`: Defines core struct and its methods related to timer setup, start new round and round state update
  - `errors.go`: Defines consensus message related errors
  - `events.go`: Defines backlog event and timeout event
+ - `evidence.go`: Detects validators signing conflicting messages or committed seals in a view, and verifies the evidences
  - `final_committed.go`: Start a new round when a final committed proposal is stored
  - `handler.go`: Implements core.Engine.Start and Stop. Provides event and message hendlers
  - `message_set.go`: Defines messageSet struct which has a validator set and messages from other nodes
@@ -40,6 +41,7 @@ Core package is composed of following files
  - `request.go`: Implements core methods which handle, check, store and process preprepare messages
  - `roundchange.go`: Implement core methods receiving and handling roundchange messages
  - `roundstate.go`: Defines roundState struct which has messages of each phase for a round
+ - `telemetry.go`: Records message arrival times, round changes and committed seals of the latest sequences, served by istanbul_getConsensusRounds
  - `types.go`: Defines Engine interface and message, State type
 */
 package core
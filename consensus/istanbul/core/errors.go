@@ -45,4 +45,11 @@ var (
 	errFailedDecodeCommit = errors.New("failed to decode COMMIT")
 	// errFailedDecodeMessageSet is returned when the message set is malformed.
 	errFailedDecodeMessageSet = errors.New("failed to decode message set")
+	// errInvalidEvidence is returned when the evidence is malformed.
+	errInvalidEvidence = errors.New("invalid evidence")
+	// errInvalidEvidenceSignature is returned when a message of the evidence is not signed by
+	// the validator of the evidence.
+	errInvalidEvidenceSignature = errors.New("invalid evidence signature")
+	// errNotConflictingEvidence is returned when the messages of the evidence do not conflict.
+	errNotConflictingEvidence = errors.New("messages of evidence do not conflict")
 )
@@ -0,0 +1,260 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	// evidenceSequenceLimit is the distance from the current sequence within which messages are checked for conflicts.
+	evidenceSequenceLimit = 16
+	// evidenceMessagesLimit is the number of messages kept for each validator in a sequence.
+	evidenceMessagesLimit = 64
+)
+
+// voteKey identifies the message a validator is allowed to sign only once.
+type voteKey struct {
+	code  uint64
+	round uint64
+	addr  common.Address
+}
+
+// signedVote is a signed message kept to be compared with the later messages of the same validator.
+type signedVote struct {
+	digest  common.Hash
+	payload []byte
+	sealed  bool // whether the message has the committed seal of the digest signed by its sender
+}
+
+// sequenceVotes is the signed messages received in a sequence.
+type sequenceVotes struct {
+	votes    map[voteKey]*signedVote
+	counts   map[common.Address]int
+	reported map[common.Hash]bool
+}
+
+func newSequenceVotes() *sequenceVotes {
+	return &sequenceVotes{
+		votes:    make(map[voteKey]*signedVote),
+		counts:   make(map[common.Address]int),
+		reported: make(map[common.Hash]bool),
+	}
+}
+
+// evidenceCollector detects validators signing conflicting messages in the recent sequences.
+// It is used only by the message handling routine of the core, so it is not safe for concurrent use.
+type evidenceCollector struct {
+	sequences map[uint64]*sequenceVotes
+	current   uint64
+
+	// the counter to record the number of the evidences found
+	evidenceCounter metrics.Counter
+}
+
+func newEvidenceCollector() *evidenceCollector {
+	return &evidenceCollector{
+		sequences:       make(map[uint64]*sequenceVotes),
+		evidenceCounter: metrics.NewRegisteredCounter("consensus/istanbul/core/evidence", nil),
+	}
+}
+
+// observe compares the message with the message of the same validator received before in the view,
+// and returns the evidence if they conflict and the conflict is newly found.
+func (ec *evidenceCollector) observe(msg *message, current uint64) *istanbul.Evidence {
+	if msg.Code != msgPreprepare && msg.Code != msgPrepare && msg.Code != msgCommit {
+		return nil
+	}
+	ec.prune(current)
+	view, digest, err := decodeVote(msg)
+	if err != nil {
+		return nil
+	}
+	seq, round := view.Sequence.Uint64(), view.Round.Uint64()
+	if seq+evidenceSequenceLimit < current || seq > current+evidenceSequenceLimit {
+		return nil
+	}
+
+	votes, ok := ec.sequences[seq]
+	if !ok {
+		votes = newSequenceVotes()
+		ec.sequences[seq] = votes
+	}
+
+	key := voteKey{code: msg.Code, round: round, addr: msg.Address}
+	if first, ok := votes.votes[key]; !ok {
+		if votes.counts[msg.Address] >= evidenceMessagesLimit || !isSignedBySender(msg) {
+			return nil
+		}
+		payload, err := msg.Payload()
+		if err != nil {
+			return nil
+		}
+		sealed := msg.Code == msgCommit && hasValidCommittedSeal(msg, digest)
+		votes.votes[key] = &signedVote{digest: digest, payload: payload, sealed: sealed}
+		votes.counts[msg.Address]++
+	} else if first.digest != digest {
+		return ec.newEvidence(votes, msg, view, digest, first)
+	}
+	return nil
+}
+
+// newEvidence returns the evidence of the message conflicting with the first one of the view,
+// or nil if the evidence is invalid or has been reported already. Two commit messages whose
+// committed seals are both valid are reported as conflicting committed seals.
+func (ec *evidenceCollector) newEvidence(votes *sequenceVotes, msg *message, view *istanbul.View, digest common.Hash, first *signedVote) *istanbul.Evidence {
+	payload, err := msg.Payload()
+	if err != nil {
+		return nil
+	}
+	typ := evidenceTypes[msg.Code]
+	if first.sealed && msg.Code == msgCommit && hasValidCommittedSeal(msg, digest) {
+		typ = istanbul.ConflictingCommittedSeals
+	}
+	evidence := &istanbul.Evidence{
+		Type:      typ,
+		Validator: msg.Address,
+		Sequence:  view.Sequence.Uint64(),
+		Round:     view.Round.Uint64(),
+		Messages:  []hexutil.Bytes{first.payload, payload},
+	}
+	hash := evidence.Hash()
+	if votes.reported[hash] || VerifyEvidence(evidence) != nil {
+		return nil
+	}
+	votes.reported[hash] = true
+	ec.evidenceCounter.Inc(1)
+	return evidence
+}
+
+// prune removes the messages of the sequences too far behind the current one.
+func (ec *evidenceCollector) prune(current uint64) {
+	if current == ec.current {
+		return
+	}
+	ec.current = current
+	for seq := range ec.sequences {
+		if seq+evidenceSequenceLimit < current {
+			delete(ec.sequences, seq)
+		}
+	}
+}
+
+// evidenceTypes maps the code of a message to the type of the evidence of signing it twice in a view.
+var evidenceTypes = map[uint64]istanbul.EvidenceType{
+	msgPreprepare: istanbul.DoublePreprepare,
+	msgPrepare:    istanbul.DoublePrepare,
+	msgCommit:     istanbul.DoubleCommit,
+}
+
+// VerifyEvidence checks that the messages of the evidence are signed by its validator
+// and conflict with each other as its type describes.
+func VerifyEvidence(evidence *istanbul.Evidence) error {
+	if len(evidence.Messages) != 2 {
+		return errInvalidEvidence
+	}
+	var code uint64
+	switch evidence.Type {
+	case istanbul.DoublePreprepare:
+		code = msgPreprepare
+	case istanbul.DoublePrepare:
+		code = msgPrepare
+	case istanbul.DoubleCommit, istanbul.ConflictingCommittedSeals:
+		code = msgCommit
+	default:
+		return errInvalidEvidence
+	}
+
+	var digests [2]common.Hash
+	for i, payload := range evidence.Messages {
+		msg := new(message)
+		if err := msg.FromPayload(payload, nil); err != nil {
+			return errInvalidEvidence
+		}
+		if msg.Code != code || msg.Address != evidence.Validator {
+			return errInvalidEvidence
+		}
+		view, digest, err := decodeVote(msg)
+		if err != nil || view.Sequence.Uint64() != evidence.Sequence || view.Round.Uint64() != evidence.Round {
+			return errInvalidEvidence
+		}
+		if !isSignedBySender(msg) {
+			return errInvalidEvidenceSignature
+		}
+		if evidence.Type == istanbul.ConflictingCommittedSeals && !hasValidCommittedSeal(msg, digest) {
+			return errInvalidEvidenceSignature
+		}
+		digests[i] = digest
+	}
+
+	if digests[0] == digests[1] {
+		return errNotConflictingEvidence
+	}
+	return nil
+}
+
+// decodeVote returns the view of the message and the hash of the proposal it votes for.
+func decodeVote(msg *message) (*istanbul.View, common.Hash, error) {
+	switch msg.Code {
+	case msgPreprepare:
+		var preprepare *istanbul.Preprepare
+		if err := msg.Decode(&preprepare); err != nil {
+			return nil, common.Hash{}, err
+		}
+		if !isValidView(preprepare.View) || preprepare.Proposal == nil {
+			return nil, common.Hash{}, errInvalidMessage
+		}
+		return preprepare.View, preprepare.Proposal.Hash(), nil
+	case msgPrepare, msgCommit:
+		var subject *istanbul.Subject
+		if err := msg.Decode(&subject); err != nil {
+			return nil, common.Hash{}, err
+		}
+		if !isValidView(subject.View) {
+			return nil, common.Hash{}, errInvalidMessage
+		}
+		return subject.View, subject.Digest, nil
+	default:
+		return nil, common.Hash{}, errInvalidMessage
+	}
+}
+
+func isValidView(view *istanbul.View) bool {
+	return view != nil && view.Sequence != nil && view.Round != nil && view.Sequence.IsUint64() && view.Round.IsUint64()
+}
+
+// isSignedBySender returns whether the message is signed by the address in it.
+func isSignedBySender(msg *message) bool {
+	payload, err := msg.PayloadNoSig()
+	if err != nil {
+		return false
+	}
+	signer, err := istanbul.GetSignatureAddress(payload, msg.Signature)
+	return err == nil && signer == msg.Address
+}
+
+// hasValidCommittedSeal returns whether the committed seal of the message is signed by its sender for the digest.
+func hasValidCommittedSeal(msg *message, digest common.Hash) bool {
+	if len(msg.CommittedSeal) == 0 {
+		return false
+	}
+	signer, err := istanbul.GetSignatureAddress(PrepareCommittedSeal(digest), msg.CommittedSeal)
+	return err == nil && signer == msg.Address
+}
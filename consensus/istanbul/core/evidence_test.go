@@ -0,0 +1,167 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// genSignedVote generates a message of the given code signed by the given key.
+// A commit message has the committed seal of the digest as the core makes.
+func genSignedVote(t *testing.T, code uint64, view *istanbul.View, digest common.Hash, addr common.Address, key *ecdsa.PrivateKey) *message {
+	var subject interface{} = &istanbul.Subject{View: view, Digest: digest}
+	if code == msgPreprepare {
+		subject = &istanbul.Preprepare{View: view, Proposal: types.NewBlockWithHeader(&types.Header{Number: view.Sequence, Extra: digest.Bytes()})}
+	}
+	encodedSubject, err := Encode(subject)
+	assert.NoError(t, err)
+
+	msg := &message{Code: code, Msg: encodedSubject, Address: addr}
+	if code == msgCommit {
+		msg.CommittedSeal, err = crypto.Sign(crypto.Keccak256(PrepareCommittedSeal(digest)), key)
+		assert.NoError(t, err)
+	}
+	data, err := msg.PayloadNoSig()
+	assert.NoError(t, err)
+	msg.Signature, err = crypto.Sign(crypto.Keccak256(data), key)
+	assert.NoError(t, err)
+	return msg
+}
+
+// resignVote replaces the committed seal of the message and signs it again with the given key.
+func resignVote(t *testing.T, msg *message, committedSeal []byte, key *ecdsa.PrivateKey) *message {
+	msg.CommittedSeal = committedSeal
+	data, err := msg.PayloadNoSig()
+	assert.NoError(t, err)
+	msg.Signature, err = crypto.Sign(crypto.Keccak256(data), key)
+	assert.NoError(t, err)
+	return msg
+}
+
+func TestEvidenceCollector(t *testing.T) {
+	addrs, keys := genValidators(2)
+	addr, key := addrs[0], keys[addrs[0]]
+	digestA, digestB := common.HexToHash("0xa"), common.HexToHash("0xb")
+
+	for _, tc := range []struct {
+		code uint64
+		typ  istanbul.EvidenceType
+	}{
+		{msgPreprepare, istanbul.DoublePreprepare},
+		{msgPrepare, istanbul.DoublePrepare},
+		{msgCommit, istanbul.ConflictingCommittedSeals},
+	} {
+		ec := newEvidenceCollector()
+		first := genSignedVote(t, tc.code, newTestView(10, 1), digestA, addr, key)
+		assert.Nil(t, ec.observe(first, 10))
+		// the same message again is not a conflict
+		assert.Nil(t, ec.observe(first, 10))
+		// a message of another round is not a conflict, since an unlocked validator can vote for another block
+		assert.Nil(t, ec.observe(genSignedVote(t, tc.code, newTestView(10, 2), digestB, addr, key), 10))
+		// a message with the address of the validator but signed by another one is ignored
+		assert.Nil(t, ec.observe(genSignedVote(t, tc.code, newTestView(10, 1), digestB, addr, keys[addrs[1]]), 10))
+
+		evidence := ec.observe(genSignedVote(t, tc.code, newTestView(10, 1), digestB, addr, key), 10)
+		if assert.NotNil(t, evidence) {
+			assert.Equal(t, tc.typ, evidence.Type)
+			assert.Equal(t, addr, evidence.Validator)
+			assert.Equal(t, uint64(10), evidence.Sequence)
+			assert.Equal(t, uint64(1), evidence.Round)
+			assert.NoError(t, VerifyEvidence(evidence))
+		}
+		// the same conflict is reported only once
+		assert.Nil(t, ec.observe(genSignedVote(t, tc.code, newTestView(10, 1), digestB, addr, key), 10))
+	}
+
+	// commit messages without valid committed seals are a double commit
+	ec := newEvidenceCollector()
+	assert.Nil(t, ec.observe(genSignedVote(t, msgCommit, newTestView(10, 1), digestA, addr, key), 10))
+	evidence := ec.observe(resignVote(t, genSignedVote(t, msgCommit, newTestView(10, 1), digestB, addr, key), nil, key), 10)
+	if assert.NotNil(t, evidence) {
+		assert.Equal(t, istanbul.DoubleCommit, evidence.Type)
+		assert.NoError(t, VerifyEvidence(evidence))
+	}
+
+	// messages of the sequences far from the current one are not kept
+	ec = newEvidenceCollector()
+	assert.Nil(t, ec.observe(genSignedVote(t, msgPrepare, newTestView(10, 0), digestA, addr, key), 10))
+	assert.Nil(t, ec.observe(genSignedVote(t, msgPrepare, newTestView(10, 0), digestB, addr, key), 10+evidenceSequenceLimit+1))
+	assert.Empty(t, ec.sequences)
+}
+
+func TestVerifyEvidence(t *testing.T) {
+	addrs, keys := genValidators(2)
+	addr, key := addrs[0], keys[addrs[0]]
+	digestA, digestB := common.HexToHash("0xa"), common.HexToHash("0xb")
+
+	payload := func(msg *message) []byte {
+		b, err := msg.Payload()
+		assert.NoError(t, err)
+		return b
+	}
+	evidence := func(typ istanbul.EvidenceType, first, second *message) *istanbul.Evidence {
+		view, _, err := decodeVote(first)
+		assert.NoError(t, err)
+		return &istanbul.Evidence{
+			Type:      typ,
+			Validator: addr,
+			Sequence:  view.Sequence.Uint64(),
+			Round:     view.Round.Uint64(),
+			Messages:  []hexutil.Bytes{payload(first), payload(second)},
+		}
+	}
+
+	prepareA := genSignedVote(t, msgPrepare, newTestView(10, 1), digestA, addr, key)
+	prepareB := genSignedVote(t, msgPrepare, newTestView(10, 1), digestB, addr, key)
+	assert.NoError(t, VerifyEvidence(evidence(istanbul.DoublePrepare, prepareA, prepareB)))
+
+	// wrong type
+	assert.Equal(t, errInvalidEvidence, VerifyEvidence(evidence(istanbul.DoubleCommit, prepareA, prepareB)))
+	// same digest
+	assert.Equal(t, errNotConflictingEvidence, VerifyEvidence(evidence(istanbul.DoublePrepare, prepareA, prepareA)))
+	// different rounds
+	prepareNextRound := genSignedVote(t, msgPrepare, newTestView(10, 2), digestB, addr, key)
+	assert.Equal(t, errInvalidEvidence, VerifyEvidence(evidence(istanbul.DoublePrepare, prepareA, prepareNextRound)))
+	// different sequences
+	prepareNextSeq := genSignedVote(t, msgPrepare, newTestView(11, 1), digestB, addr, key)
+	assert.Equal(t, errInvalidEvidence, VerifyEvidence(evidence(istanbul.DoublePrepare, prepareA, prepareNextSeq)))
+	// signed by another validator
+	forged := genSignedVote(t, msgPrepare, newTestView(10, 1), digestB, addr, keys[addrs[1]])
+	assert.Equal(t, errInvalidEvidenceSignature, VerifyEvidence(evidence(istanbul.DoublePrepare, prepareA, forged)))
+
+	commitA := genSignedVote(t, msgCommit, newTestView(10, 1), digestA, addr, key)
+	commitB := genSignedVote(t, msgCommit, newTestView(10, 1), digestB, addr, key)
+	assert.NoError(t, VerifyEvidence(evidence(istanbul.DoubleCommit, commitA, commitB)))
+	assert.NoError(t, VerifyEvidence(evidence(istanbul.ConflictingCommittedSeals, commitA, commitB)))
+	// committed seals of different rounds are not a conflict
+	commitNextRound := genSignedVote(t, msgCommit, newTestView(10, 2), digestB, addr, key)
+	assert.Equal(t, errInvalidEvidence, VerifyEvidence(evidence(istanbul.ConflictingCommittedSeals, commitA, commitNextRound)))
+	// a committed seal of another digest
+	commitWrongSeal := resignVote(t, genSignedVote(t, msgCommit, newTestView(10, 1), digestB, addr, key), commitA.CommittedSeal, key)
+	assert.NoError(t, VerifyEvidence(evidence(istanbul.DoubleCommit, commitA, commitWrongSeal)))
+	assert.Equal(t, errInvalidEvidenceSignature, VerifyEvidence(evidence(istanbul.ConflictingCommittedSeals, commitA, commitWrongSeal)))
+	// an unknown type
+	assert.Equal(t, errInvalidEvidence, VerifyEvidence(evidence(istanbul.EvidenceType(4), commitA, commitB)))
+}
@@ -48,6 +48,11 @@ func (c *core) Stop() error {
 	return nil
 }
 
+// GetConsensusRounds implements core.Engine.GetConsensusRounds
+func (c *core) GetConsensusRounds(count int) []*ConsensusRound {
+	return c.rounds.list(count)
+}
+
 // ----------------------------------------------------------------------------
 
 // Subscribe both internal and external events
@@ -174,6 +179,12 @@ func (c *core) handleMsg(payload []byte) error {
 func (c *core) handleCheckedMsg(msg *message, src istanbul.Validator) error {
 	logger := c.logger.NewWith("address", c.address, "from", src)
 
+	if evidence := c.evidences.observe(msg, c.currentView().Sequence.Uint64()); evidence != nil {
+		logger.Warn("Found a validator signing conflicting messages", "type", evidence.Type, "validator", evidence.Validator,
+			"sequence", evidence.Sequence, "round", evidence.Round)
+		c.backend.HandleEvidence(evidence)
+	}
+
 	// Store the message if it's a future message
 	testBacklog := func(err error) error {
 		if err == errFutureMessage {
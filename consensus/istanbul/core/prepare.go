@@ -76,6 +76,7 @@ func (c *core) handlePrepare(msg *message, src istanbul.Validator) error {
 	}
 
 	c.acceptPrepare(msg, src)
+	c.rounds.message(msgPrepare, prepare.View, src.Address())
 
 	// Change to Prepared state if we've received enough PREPARE/COMMIT messages or it is locked
 	// and we are in earlier state before Prepared state.
@@ -108,6 +108,8 @@ func (c *core) handlePreprepare(msg *message, src istanbul.Validator) error {
 		return err
 	}
 
+	c.rounds.message(msgPreprepare, preprepare.View, src.Address())
+
 	// Here is about to accept the PRE-PREPARE
 	if c.state == StateAcceptRequest {
 		// Send ROUND CHANGE if the locked proposal and the received proposal are different
@@ -0,0 +1,218 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/rcrowley/go-metrics"
+)
+
+// consensusRoundsLimit is the number of the latest sequences whose consensus rounds are kept.
+const consensusRoundsLimit = 256
+
+// ConsensusRound is the telemetry of the consensus on a sequence, that is, a block number.
+// The message arrival times are of the last round of the sequence, and only the first
+// arrival from each validator is kept.
+type ConsensusRound struct {
+	Sequence       uint64                       `json:"sequence"`
+	Round          uint64                       `json:"round"`
+	RoundChanges   uint64                       `json:"roundChanges"`
+	Proposer       common.Address               `json:"proposer"`
+	StartedAt      time.Time                    `json:"startedAt"`
+	RoundStartedAt time.Time                    `json:"roundStartedAt"`
+	Preprepares    map[common.Address]time.Time `json:"preprepares"`
+	Prepares       map[common.Address]time.Time `json:"prepares"`
+	Commits        map[common.Address]time.Time `json:"commits"`
+	Proposal       common.Hash                  `json:"proposal"`
+	CommittedAt    time.Time                    `json:"committedAt"`
+	CommittedSeals []common.Address             `json:"committedSeals"` // validators whose committed seals were included in the block
+}
+
+func (r *ConsensusRound) resetMessages() {
+	r.Preprepares = make(map[common.Address]time.Time)
+	r.Prepares = make(map[common.Address]time.Time)
+	r.Commits = make(map[common.Address]time.Time)
+}
+
+func (r *ConsensusRound) copy() *ConsensusRound {
+	cpy := *r
+	cpy.resetMessages()
+	for addr, t := range r.Preprepares {
+		cpy.Preprepares[addr] = t
+	}
+	for addr, t := range r.Prepares {
+		cpy.Prepares[addr] = t
+	}
+	for addr, t := range r.Commits {
+		cpy.Commits[addr] = t
+	}
+	cpy.CommittedSeals = append([]common.Address(nil), r.CommittedSeals...)
+	return &cpy
+}
+
+// roundRecorder keeps the consensus rounds of the latest sequences in a ring buffer.
+type roundRecorder struct {
+	mu     sync.RWMutex
+	rounds []*ConsensusRound
+	next   int
+
+	// the gauge to record the round change count of the last committed sequence
+	roundChangesGauge metrics.Gauge
+	// the gauge to record the number of committed seals included in the last committed block
+	committedSealsGauge metrics.Gauge
+	// the timer to record the duration from the start of a round to the arrival of its preprepare
+	preprepareTimer metrics.Timer
+	// the timer to record the duration from the arrival of a preprepare to the commit of its proposal
+	commitTimer metrics.Timer
+}
+
+func newRoundRecorder(limit int) *roundRecorder {
+	return &roundRecorder{
+		rounds:              make([]*ConsensusRound, 0, limit),
+		roundChangesGauge:   metrics.NewRegisteredGauge("consensus/istanbul/core/roundChanges", nil),
+		committedSealsGauge: metrics.NewRegisteredGauge("consensus/istanbul/core/committedSeals", nil),
+		preprepareTimer:     metrics.NewRegisteredTimer("consensus/istanbul/core/preprepareDelay", nil),
+		commitTimer:         metrics.NewRegisteredTimer("consensus/istanbul/core/commitDelay", nil),
+	}
+}
+
+// latest returns the record of the latest sequence. It should be called with the lock held.
+func (rr *roundRecorder) latest() *ConsensusRound {
+	if len(rr.rounds) == 0 {
+		return nil
+	}
+	return rr.rounds[(rr.next+cap(rr.rounds)-1)%cap(rr.rounds)]
+}
+
+// current returns the record of the given view if it is of the latest round. It should be called with the lock held.
+func (rr *roundRecorder) current(view *istanbul.View) *ConsensusRound {
+	r := rr.latest()
+	if r == nil || r.Sequence != view.Sequence.Uint64() || r.Round != view.Round.Uint64() {
+		return nil
+	}
+	return r
+}
+
+// newRound records the start of a round. A round of a new sequence starts a new record.
+func (rr *roundRecorder) newRound(view *istanbul.View, proposer common.Address) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	now := time.Now()
+	seq, round := view.Sequence.Uint64(), view.Round.Uint64()
+	if r := rr.latest(); r != nil && r.Sequence == seq {
+		if r.Round != round {
+			r.Round = round
+			r.RoundChanges++
+			r.RoundStartedAt = now
+			r.resetMessages()
+		}
+		r.Proposer = proposer
+		return
+	}
+
+	r := &ConsensusRound{
+		Sequence:       seq,
+		Round:          round,
+		Proposer:       proposer,
+		StartedAt:      now,
+		RoundStartedAt: now,
+	}
+	r.resetMessages()
+	if len(rr.rounds) < cap(rr.rounds) {
+		rr.rounds = append(rr.rounds, r)
+	} else {
+		rr.rounds[rr.next] = r
+	}
+	rr.next = (rr.next + 1) % cap(rr.rounds)
+}
+
+// message records the arrival of a consensus message of the given view from the given validator.
+func (rr *roundRecorder) message(code uint64, view *istanbul.View, src common.Address) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	r := rr.current(view)
+	if r == nil {
+		return
+	}
+	var arrivals map[common.Address]time.Time
+	switch code {
+	case msgPreprepare:
+		arrivals = r.Preprepares
+	case msgPrepare:
+		arrivals = r.Prepares
+	case msgCommit:
+		arrivals = r.Commits
+	default:
+		return
+	}
+	if _, ok := arrivals[src]; ok {
+		return
+	}
+	now := time.Now()
+	arrivals[src] = now
+	if code == msgPreprepare && len(arrivals) == 1 {
+		rr.preprepareTimer.Update(now.Sub(r.RoundStartedAt))
+	}
+}
+
+// committed records the commit of the proposal of the given view with the committed seals of the given validators.
+func (rr *roundRecorder) committed(view *istanbul.View, proposal common.Hash, seals []common.Address) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	r := rr.current(view)
+	if r == nil {
+		return
+	}
+	r.Proposal = proposal
+	r.CommittedAt = time.Now()
+	r.CommittedSeals = seals
+
+	rr.roundChangesGauge.Update(int64(r.RoundChanges))
+	rr.committedSealsGauge.Update(int64(len(seals)))
+	if t, ok := r.Preprepares[r.Proposer]; ok {
+		rr.commitTimer.Update(r.CommittedAt.Sub(t))
+	}
+}
+
+// list returns the records of the latest `count` sequences from the oldest one.
+// All records are returned if count is not positive.
+func (rr *roundRecorder) list(count int) []*ConsensusRound {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+
+	size := len(rr.rounds)
+	if count <= 0 || count > size {
+		count = size
+	}
+	ret := make([]*ConsensusRound, 0, count)
+	for i := size - count; i < size; i++ {
+		// The oldest record is at rr.next once the buffer is full
+		idx := i
+		if size == cap(rr.rounds) {
+			idx = (rr.next + i) % size
+		}
+		ret = append(ret, rr.rounds[idx].copy())
+	}
+	return ret
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestView(seq, round int64) *istanbul.View {
+	return &istanbul.View{Sequence: big.NewInt(seq), Round: big.NewInt(round)}
+}
+
+func TestRoundRecorder(t *testing.T) {
+	rr := newRoundRecorder(3)
+	proposer := common.HexToAddress("0x1")
+	validators := []common.Address{proposer, common.HexToAddress("0x2"), common.HexToAddress("0x3")}
+
+	rr.newRound(newTestView(1, 0), validators[1])
+	rr.message(msgPreprepare, newTestView(1, 0), validators[1])
+	rr.message(msgPrepare, newTestView(1, 0), validators[0])
+
+	// A round change resets the arrivals and messages of other views are ignored
+	rr.newRound(newTestView(1, 1), proposer)
+	rr.message(msgPrepare, newTestView(1, 0), validators[2])
+	rr.message(msgPreprepare, newTestView(1, 1), proposer)
+	for _, v := range validators {
+		rr.message(msgPrepare, newTestView(1, 1), v)
+		rr.message(msgCommit, newTestView(1, 1), v)
+	}
+	first := rr.list(0)[0].Prepares[proposer]
+	rr.message(msgPrepare, newTestView(1, 1), proposer) // only the first arrival is kept
+	rr.committed(newTestView(1, 1), common.HexToHash("0xa"), validators[:2])
+
+	rounds := rr.list(0)
+	if assert.Len(t, rounds, 1) {
+		r := rounds[0]
+		assert.Equal(t, uint64(1), r.Sequence)
+		assert.Equal(t, uint64(1), r.Round)
+		assert.Equal(t, uint64(1), r.RoundChanges)
+		assert.Equal(t, proposer, r.Proposer)
+		assert.Len(t, r.Preprepares, 1)
+		assert.Len(t, r.Prepares, 3)
+		assert.Len(t, r.Commits, 3)
+		assert.Equal(t, first, r.Prepares[proposer])
+		assert.Equal(t, common.HexToHash("0xa"), r.Proposal)
+		assert.Equal(t, validators[:2], r.CommittedSeals)
+		assert.False(t, r.CommittedAt.IsZero())
+
+		// The returned records are copies
+		delete(r.Prepares, proposer)
+		assert.Len(t, rr.list(0)[0].Prepares, 3)
+	}
+
+	// The buffer keeps the latest sequences only
+	for seq := int64(2); seq <= 5; seq++ {
+		rr.newRound(newTestView(seq, 0), proposer)
+	}
+	rounds = rr.list(0)
+	if assert.Len(t, rounds, 3) {
+		assert.Equal(t, uint64(3), rounds[0].Sequence)
+		assert.Equal(t, uint64(5), rounds[2].Sequence)
+	}
+	rounds = rr.list(2)
+	if assert.Len(t, rounds, 2) {
+		assert.Equal(t, uint64(4), rounds[0].Sequence)
+		assert.Equal(t, uint64(5), rounds[1].Sequence)
+	}
+}
@@ -31,6 +31,9 @@ import (
 type Engine interface {
 	Start() error
 	Stop() error
+
+	// GetConsensusRounds returns the consensus rounds of the latest `count` sequences.
+	GetConsensusRounds(count int) []*ConsensusRound
 }
 
 type State uint64
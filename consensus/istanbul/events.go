@@ -40,3 +40,8 @@ type CommitEvent struct {
 // FinalCommittedEvent is posted when a proposal is committed
 type FinalCommittedEvent struct {
 }
+
+// EvidenceEvent is posted when a validator is found to have signed conflicting messages
+type EvidenceEvent struct {
+	Evidence *Evidence
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package istanbul
+
+import (
+	"fmt"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+// EvidenceType is the kind of misbehavior an Evidence proves.
+type EvidenceType uint64
+
+const (
+	// DoublePreprepare is two preprepare messages of a view proposing different blocks.
+	DoublePreprepare EvidenceType = iota
+	// DoublePrepare is two prepare messages of a view for different blocks.
+	DoublePrepare
+	// DoubleCommit is two commit messages of a view for different blocks.
+	DoubleCommit
+	// ConflictingCommittedSeals is two commit messages of a view whose committed seals,
+	// which can be put in the blocks, are for different blocks.
+	ConflictingCommittedSeals
+)
+
+var evidenceTypeNames = map[EvidenceType]string{
+	DoublePreprepare:          "doublePreprepare",
+	DoublePrepare:             "doublePrepare",
+	DoubleCommit:              "doubleCommit",
+	ConflictingCommittedSeals: "conflictingCommittedSeals",
+}
+
+func (t EvidenceType) String() string {
+	if name, ok := evidenceTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint64(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t EvidenceType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *EvidenceType) UnmarshalText(input []byte) error {
+	for typ, name := range evidenceTypeNames {
+		if name == string(input) {
+			*t = typ
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown evidence type %q", input)
+}
+
+// Evidence is the proof that a validator signed two conflicting consensus messages.
+// Messages holds the two signed messages as they were received, so anyone can verify
+// the signatures without trusting the node which collected them.
+type Evidence struct {
+	Type      EvidenceType    `json:"type"`
+	Validator common.Address  `json:"validator"`
+	Sequence  uint64          `json:"sequence"`
+	Round     uint64          `json:"round"`
+	Messages  []hexutil.Bytes `json:"messages"`
+}
+
+// Hash returns the hash of the evidence which identifies it.
+func (e *Evidence) Hash() common.Hash {
+	return RLPHash(e)
+}
@@ -178,6 +178,18 @@ func (mr *MockBackendMockRecorder) GossipSubPeer(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GossipSubPeer", reflect.TypeOf((*MockBackend)(nil).GossipSubPeer), arg0, arg1, arg2)
 }
 
+// HandleEvidence mocks base method
+func (m *MockBackend) HandleEvidence(arg0 *istanbul.Evidence) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleEvidence", arg0)
+}
+
+// HandleEvidence indicates an expected call of HandleEvidence
+func (mr *MockBackendMockRecorder) HandleEvidence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvidence", reflect.TypeOf((*MockBackend)(nil).HandleEvidence), arg0)
+}
+
 // HasBadProposal mocks base method
 func (m *MockBackend) HasBadProposal(arg0 common.Hash) bool {
 	m.ctrl.T.Helper()
@@ -0,0 +1,97 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/params"
+)
+
+// NextMagmaBlockBaseFee calculates the base fee of the block following parentHeader.
+// The base fee of the first magma block is the lower bound. Afterwards, the base fee
+// rises if the parent used more gas than the gas target and falls otherwise, by at most
+// 1/BaseFeeDenominator of the parent base fee, staying in the configured bounds.
+func NextMagmaBlockBaseFee(parentHeader *types.Header, kip71 *params.KIP71Config) *big.Int {
+	lowerBoundBaseFee := new(big.Int).SetUint64(kip71.LowerBoundBaseFee)
+	upperBoundBaseFee := new(big.Int).SetUint64(kip71.UpperBoundBaseFee)
+
+	if parentHeader.BaseFee == nil {
+		return lowerBoundBaseFee
+	}
+
+	parentBaseFee := parentHeader.BaseFee
+	parentGasUsed := parentHeader.GasUsed
+	if parentGasUsed > kip71.MaxBlockGasUsedForBaseFee {
+		parentGasUsed = kip71.MaxBlockGasUsedForBaseFee
+	}
+	gasTarget := kip71.GasTarget
+	if gasTarget == 0 || kip71.BaseFeeDenominator == 0 {
+		return bounded(parentBaseFee, lowerBoundBaseFee, upperBoundBaseFee)
+	}
+
+	var nextBaseFee *big.Int
+	switch {
+	case parentGasUsed == gasTarget:
+		nextBaseFee = new(big.Int).Set(parentBaseFee)
+
+	case parentGasUsed > gasTarget:
+		// delta = max(parentBaseFee * (parentGasUsed - gasTarget) / gasTarget / denominator, 1)
+		delta := new(big.Int).SetUint64(parentGasUsed - gasTarget)
+		delta.Mul(delta, parentBaseFee)
+		delta.Div(delta, new(big.Int).SetUint64(gasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(kip71.BaseFeeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+		nextBaseFee = delta.Add(parentBaseFee, delta)
+
+	default:
+		// delta = parentBaseFee * (gasTarget - parentGasUsed) / gasTarget / denominator
+		delta := new(big.Int).SetUint64(gasTarget - parentGasUsed)
+		delta.Mul(delta, parentBaseFee)
+		delta.Div(delta, new(big.Int).SetUint64(gasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(kip71.BaseFeeDenominator))
+		nextBaseFee = delta.Sub(parentBaseFee, delta)
+	}
+	return bounded(nextBaseFee, lowerBoundBaseFee, upperBoundBaseFee)
+}
+
+// VerifyMagmaHeader verifies that the base fee of the header is the one calculated from its parent.
+func VerifyMagmaHeader(parentHeader, header *types.Header, kip71 *params.KIP71Config) error {
+	if header.BaseFee == nil {
+		return fmt.Errorf("header is missing baseFee")
+	}
+	if expected := NextMagmaBlockBaseFee(parentHeader, kip71); header.BaseFee.Cmp(expected) != 0 {
+		return fmt.Errorf("invalid baseFee: have %s, want %s, parentBaseFee %s, parentGasUsed %d",
+			header.BaseFee, expected, parentHeader.BaseFee, parentHeader.GasUsed)
+	}
+	return nil
+}
+
+// bounded returns a copy of baseFee clamped to [lowerBound, upperBound].
+func bounded(baseFee, lowerBound, upperBound *big.Int) *big.Int {
+	if baseFee.Cmp(lowerBound) < 0 {
+		return lowerBound
+	}
+	if baseFee.Cmp(upperBound) > 0 {
+		return upperBound
+	}
+	return new(big.Int).Set(baseFee)
+}
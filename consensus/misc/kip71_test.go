@@ -0,0 +1,73 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func testKIP71Config() *params.KIP71Config {
+	return &params.KIP71Config{
+		LowerBoundBaseFee:         25,
+		UpperBoundBaseFee:         750,
+		GasTarget:                 1000,
+		MaxBlockGasUsedForBaseFee: 2000,
+		BaseFeeDenominator:        10,
+	}
+}
+
+func TestNextMagmaBlockBaseFee(t *testing.T) {
+	testCases := []struct {
+		parentBaseFee *big.Int
+		parentGasUsed uint64
+		expected      uint64
+	}{
+		{nil, 1000, 25},                 // the first magma block starts from the lower bound
+		{big.NewInt(100), 1000, 100},    // gas used equals the target
+		{big.NewInt(100), 1500, 105},    // +100 * 500 / 1000 / 10
+		{big.NewInt(100), 5000, 110},    // gas used is capped to MaxBlockGasUsedForBaseFee
+		{big.NewInt(100), 1001, 101},    // the increase is at least 1
+		{big.NewInt(100), 0, 90},        // -100 * 1000 / 1000 / 10
+		{big.NewInt(26), 0, 25},         // bounded by the lower bound
+		{big.NewInt(740), 2000, 750},    // bounded by the upper bound
+		{big.NewInt(1000), 1000, 750},   // bounded after the upper bound is lowered
+		{big.NewInt(10), 1000, 25},      // bounded after the lower bound is raised
+		{big.NewInt(100000), 0, 750},    // bounded after a large decrease
+		{big.NewInt(300), 500, 285},     // -300 * 500 / 1000 / 10
+		{big.NewInt(300), 999, 300},     // the decrease can be 0
+		{big.NewInt(25), 0, 25},         // stays at the lower bound
+		{big.NewInt(750), 1000000, 750}, // stays at the upper bound
+	}
+
+	for i, tc := range testCases {
+		parent := &types.Header{BaseFee: tc.parentBaseFee, GasUsed: tc.parentGasUsed}
+		assert.Equal(t, tc.expected, NextMagmaBlockBaseFee(parent, testKIP71Config()).Uint64(), "test case %d", i)
+	}
+}
+
+func TestVerifyMagmaHeader(t *testing.T) {
+	parent := &types.Header{BaseFee: big.NewInt(100), GasUsed: 1500}
+
+	assert.NoError(t, VerifyMagmaHeader(parent, &types.Header{BaseFee: big.NewInt(105)}, testKIP71Config()))
+	assert.Error(t, VerifyMagmaHeader(parent, &types.Header{BaseFee: big.NewInt(100)}, testKIP71Config()))
+	assert.Error(t, VerifyMagmaHeader(parent, &types.Header{}, testKIP71Config()))
+}
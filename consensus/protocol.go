@@ -30,13 +30,14 @@ import (
 const (
 	Klay62 = 62
 	Klay63 = 63
+	Klay65 = 65
 )
 
 var (
 	KlayProtocol = Protocol{
 		Name:     "klay",
-		Versions: []uint{Klay63, Klay62},
-		Lengths:  []uint64{17, 8},
+		Versions: []uint{Klay65, Klay63, Klay62},
+		Lengths:  []uint64{22, 17, 8},
 	}
 )
 
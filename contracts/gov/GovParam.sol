@@ -0,0 +1,58 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+pragma solidity ^0.5.6;
+
+/// @title GovParam
+/// @notice Keeps governance parameters which override the ones set by header votes.
+/// The owner is expected to be a multisig wallet of the council.
+/// @dev Klaytn nodes read `_params` directly from the storage, so the layout must not be changed:
+/// `owner` at slot 0 and `_params` at slot 1. A value is encoded in the same way as a governance vote value,
+/// e.g. a big-endian integer for "kip71.gastarget" or a UTF-8 string for "reward.ratio".
+contract GovParam {
+    address public owner;
+    mapping(string => bytes) private _params;
+
+    event SetParam(string name, bytes value);
+    event OwnershipTransferred(address indexed previousOwner, address indexed newOwner);
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "GovParam: caller is not the owner");
+        _;
+    }
+
+    constructor() public {
+        owner = msg.sender;
+        emit OwnershipTransferred(address(0), msg.sender);
+    }
+
+    /// @notice Sets a parameter. An empty value removes it, so the value set by header votes is used.
+    /// The change takes effect from the second epoch boundary after it.
+    function setParam(string calldata name, bytes calldata value) external onlyOwner {
+        _params[name] = value;
+        emit SetParam(name, value);
+    }
+
+    function getParam(string calldata name) external view returns (bytes memory) {
+        return _params[name];
+    }
+
+    function transferOwnership(address newOwner) external onlyOwner {
+        require(newOwner != address(0), "GovParam: new owner is the zero address");
+        emit OwnershipTransferred(owner, newOwner);
+        owner = newOwner;
+    }
+}
@@ -59,7 +59,10 @@ func (s *KafkaSuite) SetupTest() {
 }
 
 func (s *KafkaSuite) TearDownTest() {
-	s.kfk.Close()
+	// TearDownTest is called even if SetupTest skipped the test
+	if s.kfk != nil {
+		s.kfk.Close()
+	}
 }
 
 func (s *KafkaSuite) TestKafka_split() {
@@ -63,7 +63,7 @@ func setExpectation(m *mocks.MockBlockchainAPI, contract *common.Address, data,
 		Data: data,
 	}
 
-	m.EXPECT().Call(gomock.Any(), gomock.Eq(arg), gomock.Eq(rpc.LatestBlockNumber)).Return(result, nil).Times(1)
+	m.EXPECT().Call(gomock.Any(), gomock.Eq(arg), gomock.Eq(rpc.LatestBlockNumber), gomock.Nil()).Return(result, nil).Times(1)
 }
 
 func (s *SuiteContractCaller) TestContractCaller_IsKIP13_Success() {
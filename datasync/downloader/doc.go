@@ -34,9 +34,10 @@ Downloader related functions and variables are defined in the files listed below
   - downloader_test.go  : Functions for testing the downloader package.
   - events.go           : Definitions of event types.
   - metrics.go          : Metric variables for packet transmissions and receptions.
-  - modes.go            : A definition of type for SyncMode including "FullSync", "FastSync", and "LightSync".
+  - modes.go            : A definition of type for SyncMode including "FullSync", "FastSync", "LightSync" and "SnapSync".
   - peer.go             : Functions that request a packet to a peer, check, and set the network status of a peer.
   - queue.go            : Functions for managing and scheduling received headers, bodies, and receipts.
+  - snapsync.go         : Functions for retrieving the state in ranges with Merkle proofs during snap sync.
   - types.go            : Definitions of the types for downloaded packets.
 */
 package downloader
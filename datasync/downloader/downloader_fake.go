@@ -42,6 +42,13 @@ func (*FakeDownloader) DeliverHeaders(id string, headers []*types.Header) error
 func (*FakeDownloader) DeliverNodeData(id string, data [][]byte) error               { return nil }
 func (*FakeDownloader) DeliverReceipts(id string, receipts [][]*types.Receipt) error { return nil }
 
+func (*FakeDownloader) DeliverAccountRange(id string, hashes []common.Hash, accounts [][]byte, proof [][]byte) error {
+	return nil
+}
+func (*FakeDownloader) DeliverStorageRanges(id string, hashes [][]common.Hash, slots [][][]byte, proof [][]byte) error {
+	return nil
+}
+
 func (*FakeDownloader) Terminate() {}
 func (*FakeDownloader) Synchronise(id string, head common.Hash, td *big.Int, mode SyncMode) error {
 	return nil
@@ -44,5 +44,8 @@ var (
 	stateInMeter   = metrics.NewRegisteredMeter("klay/downloader/states/in", nil)
 	stateDropMeter = metrics.NewRegisteredMeter("klay/downloader/states/drop", nil)
 
+	snapInMeter   = metrics.NewRegisteredMeter("klay/downloader/snap/in", nil)
+	snapDropMeter = metrics.NewRegisteredMeter("klay/downloader/snap/drop", nil)
+
 	throttleCounter = metrics.NewRegisteredCounter("klay/downloader/throttle", nil)
 )
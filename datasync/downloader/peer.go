@@ -91,6 +91,13 @@ type Peer interface {
 	RequestNodeData([]common.Hash) error
 }
 
+// SnapPeer encapsulates the methods required to retrieve flat state ranges
+// along with their Merkle proofs from a remote peer.
+type SnapPeer interface {
+	RequestAccountRange(root common.Hash, origin, limit common.Hash, bytes uint64) error
+	RequestStorageRanges(root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64) error
+}
+
 // lightPeerWrapper wraps a LightPeer struct, stubbing out the Peer-only methods.
 type lightPeerWrapper struct {
 	peer LightPeer
@@ -223,6 +230,43 @@ func (p *peerConnection) FetchNodeData(hashes []common.Hash) error {
 	return nil
 }
 
+// FetchAccountRange sends an account range retrieval request to the remote peer.
+func (p *peerConnection) FetchAccountRange(root common.Hash, origin, limit common.Hash, bytes uint64) error {
+	// Sanity check the protocol version
+	snapPeer, ok := p.peer.(SnapPeer)
+	if p.version < 65 || !ok {
+		panic(fmt.Sprintf("account range fetch [klay/65+] requested on klay/%d", p.version))
+	}
+	// Short circuit if the peer is already fetching
+	if !atomic.CompareAndSwapInt32(&p.stateIdle, 0, 1) {
+		return errAlreadyFetching
+	}
+	p.stateStarted = time.Now()
+
+	go snapPeer.RequestAccountRange(root, origin, limit, bytes)
+
+	return nil
+}
+
+// FetchStorageRanges sends a storage range retrieval request of the given
+// accounts to the remote peer.
+func (p *peerConnection) FetchStorageRanges(root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64) error {
+	// Sanity check the protocol version
+	snapPeer, ok := p.peer.(SnapPeer)
+	if p.version < 65 || !ok {
+		panic(fmt.Sprintf("storage range fetch [klay/65+] requested on klay/%d", p.version))
+	}
+	// Short circuit if the peer is already fetching
+	if !atomic.CompareAndSwapInt32(&p.stateIdle, 0, 1) {
+		return errAlreadyFetching
+	}
+	p.stateStarted = time.Now()
+
+	go snapPeer.RequestStorageRanges(root, accounts, origin, limit, bytes)
+
+	return nil
+}
+
 // SetHeadersIdle sets the peer to idle, allowing it to execute new header retrieval
 // requests. Its estimated header retrieval throughput is updated with that measured
 // just now.
@@ -480,7 +524,7 @@ func (ps *peerSet) HeaderIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.headerThroughput
 	}
-	return ps.idlePeers(62, 64, idleCheck, throughput)
+	return ps.idlePeers(62, 65, idleCheck, throughput)
 }
 
 // BodyIdlePeers retrieves a flat list of all the currently body-idle peers within
@@ -494,7 +538,7 @@ func (ps *peerSet) BodyIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.blockThroughput
 	}
-	return ps.idlePeers(62, 64, idleCheck, throughput)
+	return ps.idlePeers(62, 65, idleCheck, throughput)
 }
 
 // ReceiptIdlePeers retrieves a flat list of all the currently receipt-idle peers
@@ -508,7 +552,7 @@ func (ps *peerSet) ReceiptIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.receiptThroughput
 	}
-	return ps.idlePeers(63, 64, idleCheck, throughput)
+	return ps.idlePeers(63, 65, idleCheck, throughput)
 }
 
 // NodeDataIdlePeers retrieves a flat list of all the currently node-data-idle
@@ -522,7 +566,24 @@ func (ps *peerSet) NodeDataIdlePeers() ([]*peerConnection, int) {
 		defer p.lock.RUnlock()
 		return p.stateThroughput
 	}
-	return ps.idlePeers(63, 64, idleCheck, throughput)
+	return ps.idlePeers(63, 65, idleCheck, throughput)
+}
+
+// SnapIdlePeers retrieves a flat list of all the currently state-idle peers
+// serving flat state ranges within the active peer set, ordered by their reputation.
+func (ps *peerSet) SnapIdlePeers() ([]*peerConnection, int) {
+	idleCheck := func(p *peerConnection) bool {
+		if _, ok := p.peer.(SnapPeer); !ok {
+			return false
+		}
+		return atomic.LoadInt32(&p.stateIdle) == 0
+	}
+	throughput := func(p *peerConnection) float64 {
+		p.lock.RLock()
+		defer p.lock.RUnlock()
+		return p.stateThroughput
+	}
+	return ps.idlePeers(65, 65, idleCheck, throughput)
 }
 
 // idlePeers retrieves a flat list of all currently idle peers satisfying the
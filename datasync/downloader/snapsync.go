@@ -0,0 +1,563 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/snapshot"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types/account"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+)
+
+const (
+	snapAccountConcurrency = 16               // Number of account ranges the hash space is split into
+	snapStorageAccounts    = 128              // Maximum number of accounts whose storage is requested at once
+	snapSoftResponseLimit  = 512 * 1024       // Target maximum size of returned state ranges
+	snapFallbackTimeout    = 10 * time.Second // Time to wait for a peer serving state ranges before healing only
+	snapStatusInterval     = time.Minute      // Interval between persisting the range retrieval progress
+)
+
+var (
+	// emptyRoot is the known root hash of an empty trie.
+	emptyRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+	// maxHash is the last hash of the hash space.
+	maxHash = common.HexToHash("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+)
+
+// snapAccountTask is a contiguous range of the account hash space to retrieve.
+type snapAccountTask struct {
+	Next common.Hash // Next account hash to retrieve
+	Last common.Hash // Last account hash of the range
+}
+
+// snapStorageTask is the remaining storage range of an account to retrieve.
+type snapStorageTask struct {
+	Account common.Hash // Hash of the account owning the storage
+	Root    common.Hash // Storage root of the account
+	Next    common.Hash // Next storage slot hash to retrieve
+}
+
+// snapProgress is the progress of a snap sync. It is kept in the downloader
+// across the pivot moves and persisted to resume the sync after a restart.
+type snapProgress struct {
+	AccountTasks []*snapAccountTask // Account ranges not yet retrieved
+	StorageTasks []*snapStorageTask // Storage ranges not yet retrieved
+
+	Generated     bool          // Whether the trie has been regenerated from the retrieved ranges
+	GeneratedRoot common.Hash   // Root of the regenerated trie
+	Storages      []common.Hash // Storage roots failed to be regenerated from the retrieved ranges
+	Codes         []common.Hash // Code hashes of the retrieved contracts
+
+	Accounts uint64 // Number of accounts retrieved
+	Slots    uint64 // Number of storage slots retrieved
+}
+
+// newSnapProgress creates a snap sync progress splitting the account hash
+// space into snapAccountConcurrency ranges.
+func newSnapProgress() *snapProgress {
+	var (
+		progress = new(snapProgress)
+		next     = new(big.Int)
+		step     = new(big.Int).Exp(common.Big2, common.Big256, nil)
+	)
+	step.Div(step, big.NewInt(snapAccountConcurrency))
+	for i := 0; i < snapAccountConcurrency; i++ {
+		last := new(big.Int).Add(next, step)
+		last.Sub(last, common.Big1)
+		progress.AccountTasks = append(progress.AccountTasks, &snapAccountTask{
+			Next: common.BigToHash(next),
+			Last: common.BigToHash(last),
+		})
+		next = new(big.Int).Add(last, common.Big1)
+	}
+	return progress
+}
+
+// snapReq represents a state range request sent to a single peer.
+type snapReq struct {
+	peer         *peerConnection
+	timer        *time.Timer
+	accountTask  *snapAccountTask   // Account range requested, nil if storage is requested
+	storageTasks []*snapStorageTask // Storage ranges requested
+}
+
+// loadSnapProgress returns the progress of the running snap sync, resuming the
+// one persisted in the database if there is no sync in memory.
+func (d *Downloader) loadSnapProgress() *snapProgress {
+	if d.snapProgress != nil {
+		return d.snapProgress
+	}
+	if enc := d.stateDB.ReadSnapshotSyncStatus(); len(enc) > 0 {
+		progress := new(snapProgress)
+		if err := rlp.DecodeBytes(enc, progress); err != nil {
+			logger.Error("Failed to decode snap sync status", "err", err)
+		} else {
+			logger.Info("Resuming snap sync", "accounts", progress.Accounts, "slots", progress.Slots,
+				"accountTasks", len(progress.AccountTasks), "storageTasks", len(progress.StorageTasks))
+			d.snapProgress = progress
+			return progress
+		}
+	}
+	// Starting a fresh sync, clean up the stale snapshot if any.
+	if err := snapshot.Wipe(d.stateDB); err != nil {
+		logger.Error("Failed to wipe stale snapshot", "err", err)
+	}
+	d.snapProgress = newSnapProgress()
+	return d.snapProgress
+}
+
+// saveSnapProgress persists the progress of the running snap sync including
+// the ranges currently being retrieved.
+func (d *Downloader) saveSnapProgress(progress *snapProgress, active map[string]*snapReq) {
+	saved := *progress
+	saved.AccountTasks = append([]*snapAccountTask{}, progress.AccountTasks...)
+	saved.StorageTasks = append([]*snapStorageTask{}, progress.StorageTasks...)
+	for _, req := range active {
+		if req.accountTask != nil {
+			saved.AccountTasks = append(saved.AccountTasks, req.accountTask)
+		}
+		saved.StorageTasks = append(saved.StorageTasks, req.storageTasks...)
+	}
+	enc, err := rlp.EncodeToBytes(&saved)
+	if err != nil {
+		logger.Error("Failed to encode snap sync status", "err", err)
+		return
+	}
+	d.stateDB.WriteSnapshotSyncStatus(enc)
+}
+
+// syncRanges retrieves the flat state in contiguous ranges verified by Merkle
+// range proofs, regenerates the state trie from them and prepares the trie
+// sync scheduler to heal the regenerated trie.
+func (s *stateSync) syncRanges() error {
+	progress := s.d.loadSnapProgress()
+
+	if len(progress.AccountTasks) > 0 || len(progress.StorageTasks) > 0 {
+		if err := s.retrieveRanges(progress); err != nil {
+			return err
+		}
+	}
+	if !progress.Generated {
+		contracts := make(map[common.Hash]struct{})
+		root, err := snapshot.GenerateTrie(s.d.stateDB, statedb.NewDatabase(s.d.stateDB), func(storageRoot, codeHash common.Hash, complete bool) {
+			if !complete {
+				progress.Storages = append(progress.Storages, storageRoot)
+			}
+			if _, ok := contracts[codeHash]; !ok {
+				contracts[codeHash] = struct{}{}
+				progress.Codes = append(progress.Codes, codeHash)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to regenerate state trie: %v", err)
+		}
+		progress.Generated, progress.GeneratedRoot = true, root
+		s.d.saveSnapProgress(progress, nil)
+	}
+	// Heal the regenerated trie, filling in the contract codes and the storage
+	// tries which cannot be reached from the difference of the state tries.
+	s.sched = state.NewStateSync(s.root, s.d.stateDB, nil, nil)
+	for _, root := range progress.Storages {
+		s.sched.AddSubTrie(root, 64, common.Hash{}, nil)
+	}
+	for _, hash := range progress.Codes {
+		s.sched.AddRawEntry(hash, 64, common.Hash{})
+	}
+	return nil
+}
+
+// finalizeSnapshot marks the flat snapshot valid after the trie is healed. If
+// the trie regenerated from the flat snapshot didn't match the state root, the
+// flat snapshot is stale and is generated again from the healed trie.
+func (s *stateSync) finalizeSnapshot() error {
+	progress := s.d.loadSnapProgress()
+	if progress.GeneratedRoot == s.root && len(progress.Storages) == 0 {
+		s.d.stateDB.WriteSnapshotRoot(s.root)
+	} else {
+		logger.Info("Regenerating snapshot from the healed state trie", "root", s.root)
+		if err := snapshot.Wipe(s.d.stateDB); err != nil {
+			return err
+		}
+		if err := snapshot.Generate(s.d.stateDB, statedb.NewDatabase(s.d.stateDB), s.root); err != nil {
+			return err
+		}
+	}
+	s.d.stateDB.DeleteSnapshotSyncStatus()
+	s.d.snapProgress = nil
+
+	logger.Info("Snap sync completed", "root", s.root, "accounts", progress.Accounts, "slots", progress.Slots)
+	return nil
+}
+
+// retrieveRanges is the event loop retrieving the account and storage ranges
+// from the peers serving them, until all ranges are retrieved or the sync is
+// canceled.
+func (s *stateSync) retrieveRanges(progress *snapProgress) error {
+	newPeer := make(chan *peerConnection, 1024)
+	newPeerSub := s.d.peers.SubscribeNewPeers(newPeer)
+	defer newPeerSub.Unsubscribe()
+
+	peerDrop := make(chan *peerConnection, 1024)
+	peerDropSub := s.d.peers.SubscribePeerDrops(peerDrop)
+	defer peerDropSub.Unsubscribe()
+
+	var (
+		active   = make(map[string]*snapReq) // Currently in-flight requests
+		timeout  = make(chan *snapReq)       // Timed out active requests
+		fallback *time.Timer                 // Timer to give up waiting for peers serving state ranges
+		status   = time.NewTicker(snapStatusInterval)
+	)
+	defer status.Stop()
+	defer func() {
+		s.d.saveSnapProgress(progress, active)
+		for _, req := range active {
+			req.timer.Stop()
+			req.peer.SetNodeDataIdle(0, time.Now())
+		}
+		if fallback != nil {
+			fallback.Stop()
+		}
+	}()
+
+	for len(progress.AccountTasks) > 0 || len(progress.StorageTasks) > 0 || len(active) > 0 {
+		s.assignRangeTasks(progress, active, timeout)
+
+		// Start the fallback timer if no peer is retrieving the ranges.
+		var fallbackCh <-chan time.Time
+		if len(active) == 0 {
+			if fallback == nil {
+				fallback = time.NewTimer(snapFallbackTimeout)
+			}
+			fallbackCh = fallback.C
+		} else if fallback != nil {
+			fallback.Stop()
+			fallback = nil
+		}
+
+		select {
+		case <-newPeer:
+			// New peer arrived, try to assign it download tasks
+
+		case <-s.cancel:
+			return errCancelStateFetch
+
+		case <-s.d.cancelCh:
+			return errCanceled
+
+		case <-fallbackCh:
+			logger.Warn("No peer serves state ranges, healing the state trie only")
+			return nil
+
+		case <-status.C:
+			s.d.saveSnapProgress(progress, active)
+
+		case pack := <-s.snapCh:
+			req := active[pack.PeerId()]
+			if req == nil {
+				logger.Debug("Unrequested state range", "peer", pack.PeerId(), "len", pack.Items())
+				continue
+			}
+			req.timer.Stop()
+			delete(active, pack.PeerId())
+
+			if err := s.processRange(progress, req, pack); err != nil {
+				logger.Warn("Invalid state range delivered", "peer", req.peer.id, "err", err)
+				s.revertRangeRequest(progress, req)
+				if s.d.dropPeer != nil {
+					s.d.dropPeer(req.peer.id)
+				}
+				continue
+			}
+			req.peer.SetNodeDataIdle(pack.Items(), time.Now())
+
+		case p := <-peerDrop:
+			req := active[p.id]
+			if req == nil {
+				continue
+			}
+			req.timer.Stop()
+			delete(active, p.id)
+			s.revertRangeRequest(progress, req)
+
+		case req := <-timeout:
+			// Ignore the stale timeout fired simultaneously with the delivery.
+			if active[req.peer.id] != req {
+				continue
+			}
+			delete(active, req.peer.id)
+			s.revertRangeRequest(progress, req)
+			req.peer.SetNodeDataIdle(0, time.Now())
+		}
+	}
+	return nil
+}
+
+// assignRangeTasks assigns the pending storage or account ranges to all the
+// idle peers serving state ranges.
+func (s *stateSync) assignRangeTasks(progress *snapProgress, active map[string]*snapReq, timeout chan *snapReq) {
+	peers, _ := s.d.peers.SnapIdlePeers()
+	for _, p := range peers {
+		if len(progress.AccountTasks) == 0 && len(progress.StorageTasks) == 0 {
+			return
+		}
+		if p.Lacks(s.root) {
+			continue
+		}
+		req := &snapReq{peer: p}
+
+		// Storage ranges are preferred to keep the number of pending tasks bounded.
+		var err error
+		if len(progress.StorageTasks) > 0 {
+			req.storageTasks = popStorageTasks(progress)
+
+			accounts := make([]common.Hash, len(req.storageTasks))
+			for i, task := range req.storageTasks {
+				accounts[i] = task.Account
+			}
+			err = p.FetchStorageRanges(s.root, accounts, req.storageTasks[0].Next, maxHash, snapSoftResponseLimit)
+		} else {
+			req.accountTask, progress.AccountTasks = progress.AccountTasks[0], progress.AccountTasks[1:]
+			err = p.FetchAccountRange(s.root, req.accountTask.Next, req.accountTask.Last, snapSoftResponseLimit)
+		}
+		if err != nil {
+			s.revertRangeRequest(progress, req)
+			continue
+		}
+		req.timer = time.AfterFunc(s.d.requestTTL(), func() {
+			select {
+			case timeout <- req:
+			case <-s.done:
+			}
+		})
+		active[p.id] = req
+	}
+}
+
+// popStorageTasks takes the storage tasks to be requested at once. A storage
+// partially retrieved is requested alone, since the origin of the range is
+// applied only to the first account.
+func popStorageTasks(progress *snapProgress) []*snapStorageTask {
+	n := 1
+	if progress.StorageTasks[0].Next == (common.Hash{}) {
+		for n < len(progress.StorageTasks) && n < snapStorageAccounts && progress.StorageTasks[n].Next == (common.Hash{}) {
+			n++
+		}
+	}
+	tasks := progress.StorageTasks[:n:n]
+	progress.StorageTasks = progress.StorageTasks[n:]
+	return tasks
+}
+
+// revertRangeRequest puts the tasks of a failed request back into the queue.
+func (s *stateSync) revertRangeRequest(progress *snapProgress, req *snapReq) {
+	if req.accountTask != nil {
+		progress.AccountTasks = append(progress.AccountTasks, req.accountTask)
+	}
+	progress.StorageTasks = append(progress.StorageTasks, req.storageTasks...)
+}
+
+// processRange verifies a delivered state range and writes it into the flat
+// snapshot, queueing up the remaining part of the range.
+func (s *stateSync) processRange(progress *snapProgress, req *snapReq, pack dataPack) error {
+	switch pack := pack.(type) {
+	case *accountRangePack:
+		if req.accountTask == nil {
+			return errors.New("unexpected account range")
+		}
+		return s.processAccountRange(progress, req, pack)
+	case *storageRangesPack:
+		if req.accountTask != nil {
+			return errors.New("unexpected storage ranges")
+		}
+		return s.processStorageRanges(progress, req, pack)
+	default:
+		return fmt.Errorf("unknown state range type %T", pack)
+	}
+}
+
+// processAccountRange verifies and writes a delivered account range.
+func (s *stateSync) processAccountRange(progress *snapProgress, req *snapReq, pack *accountRangePack) error {
+	task := req.accountTask
+	if len(pack.hashes) == 0 && len(pack.proof) == 0 {
+		// The peer doesn't have the state of the root anymore.
+		req.peer.MarkLacking(s.root)
+		s.revertRangeRequest(progress, req)
+		return nil
+	}
+	if len(pack.hashes) != len(pack.accounts) {
+		return fmt.Errorf("inconsistent account range, hashes: %d, accounts: %d", len(pack.hashes), len(pack.accounts))
+	}
+	keys := make([][]byte, len(pack.hashes))
+	for i, hash := range pack.hashes {
+		keys[i] = common.CopyBytes(hash[:])
+	}
+	end := task.Next[:]
+	if len(keys) > 0 {
+		end = keys[len(keys)-1]
+	}
+	cont, err := statedb.VerifyRangeProof(s.root, task.Next[:], end, keys, pack.accounts, newProofDB(pack.proof))
+	if err != nil {
+		return err
+	}
+	// The progress is updated only after the whole range is processed, since
+	// the request is reverted as it is if it fails in the middle.
+	var (
+		batch        = s.d.stateDB.NewBatch(database.SnapshotDB)
+		accounts     uint64
+		storageTasks []*snapStorageTask
+	)
+	for i, hash := range pack.hashes {
+		// The peer may deliver accounts beyond the range to prove its end.
+		if bytes.Compare(hash[:], task.Last[:]) > 0 {
+			cont = false
+			break
+		}
+		s.d.stateDB.PutAccountSnapshotToBatch(batch, hash, pack.accounts[i])
+		accounts++
+
+		serializer := account.NewAccountSerializer()
+		if err := rlp.DecodeBytes(pack.accounts[i], serializer); err != nil {
+			return err
+		}
+		if pa := account.GetProgramAccount(serializer.GetAccount()); pa != nil {
+			if root := pa.GetStorageRoot(); root != (common.Hash{}) && root != emptyRoot {
+				storageTasks = append(storageTasks, &snapStorageTask{Account: hash, Root: root})
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("DB write error: %v", err)
+	}
+	progress.Accounts += accounts
+	progress.StorageTasks = append(progress.StorageTasks, storageTasks...)
+	// Queue up the rest of the range if the range is not completed.
+	if cont && len(pack.hashes) > 0 {
+		last := pack.hashes[len(pack.hashes)-1]
+		if next, ok := incHash(last); ok && bytes.Compare(last[:], task.Last[:]) < 0 {
+			task.Next = next
+			progress.AccountTasks = append(progress.AccountTasks, task)
+		}
+	}
+	logger.Debug("Retrieved account range", "peer", req.peer.id, "count", len(pack.hashes), "accounts", progress.Accounts, "slots", progress.Slots)
+	return nil
+}
+
+// processStorageRanges verifies and writes delivered storage ranges. All the
+// ranges except the last one should be complete, and only the last one can be
+// proven to be a partial range.
+func (s *stateSync) processStorageRanges(progress *snapProgress, req *snapReq, pack *storageRangesPack) error {
+	if len(pack.slots) == 0 && len(pack.proof) == 0 {
+		// The peer doesn't have the state of the root anymore.
+		req.peer.MarkLacking(s.root)
+		s.revertRangeRequest(progress, req)
+		return nil
+	}
+	if len(pack.hashes) != len(pack.slots) || len(pack.slots) > len(req.storageTasks) {
+		return fmt.Errorf("inconsistent storage ranges, hashes: %d, slots: %d, requested: %d", len(pack.hashes), len(pack.slots), len(req.storageTasks))
+	}
+	// The progress is updated only after all the ranges are processed, since
+	// the request is reverted as it is if it fails in the middle.
+	var (
+		batch        = s.d.stateDB.NewBatch(database.SnapshotDB)
+		numSlots     uint64
+		storageTasks []*snapStorageTask
+	)
+	for i, slots := range pack.slots {
+		task := req.storageTasks[i]
+		if len(pack.hashes[i]) != len(slots) {
+			return fmt.Errorf("inconsistent storage range, hashes: %d, slots: %d", len(pack.hashes[i]), len(slots))
+		}
+		keys := make([][]byte, len(pack.hashes[i]))
+		for j, hash := range pack.hashes[i] {
+			keys[j] = common.CopyBytes(hash[:])
+		}
+		// Only the last range may be partial and proven with the edge proofs.
+		var proofDB database.DBManager
+		if i == len(pack.slots)-1 && len(pack.proof) > 0 {
+			proofDB = newProofDB(pack.proof)
+		} else if task.Next != (common.Hash{}) {
+			return errors.New("partial storage range without proof")
+		}
+		end := task.Next[:]
+		if len(keys) > 0 {
+			end = keys[len(keys)-1]
+		}
+		cont, err := statedb.VerifyRangeProof(task.Root, task.Next[:], end, keys, slots, proofDB)
+		if err != nil {
+			return err
+		}
+		for j, hash := range pack.hashes[i] {
+			s.d.stateDB.PutStorageSnapshotToBatch(batch, task.Account, hash, slots[j])
+		}
+		numSlots += uint64(len(slots))
+
+		if cont && len(keys) > 0 {
+			if next, ok := incHash(pack.hashes[i][len(keys)-1]); ok {
+				storageTasks = append(storageTasks, &snapStorageTask{Account: task.Account, Root: task.Root, Next: next})
+			}
+		}
+		if batch.ValueSize() > database.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return fmt.Errorf("DB write error: %v", err)
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("DB write error: %v", err)
+	}
+	progress.Slots += numSlots
+	progress.StorageTasks = append(progress.StorageTasks, storageTasks...)
+
+	// Queue up the storage not delivered by the peer.
+	progress.StorageTasks = append(progress.StorageTasks, req.storageTasks[len(pack.slots):]...)
+
+	logger.Debug("Retrieved storage ranges", "peer", req.peer.id, "count", len(pack.slots), "accounts", progress.Accounts, "slots", progress.Slots)
+	return nil
+}
+
+// newProofDB creates a proof database containing the given proof nodes.
+func newProofDB(proof [][]byte) database.DBManager {
+	proofDB := database.NewMemoryDBManager()
+	for _, node := range proof {
+		proofDB.WriteMerkleProof(crypto.Keccak256(node), node)
+	}
+	return proofDB
+}
+
+// incHash returns the next hash in lexicographical order. It returns false if
+// the given hash is the last one.
+func incHash(h common.Hash) (common.Hash, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i]++
+		if h[i] != 0 {
+			return h, true
+		}
+	}
+	return h, false
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProcessStorageRanges_Failed checks if the progress is untouched when a
+// delivered storage range fails to be verified after the former ranges.
+func TestProcessStorageRanges_Failed(t *testing.T) {
+	hashes := []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")}
+	slots := [][]byte{{0x01}, {0x02}}
+
+	tr := new(statedb.Trie)
+	for i, hash := range hashes {
+		require.NoError(t, tr.TryUpdate(hash[:], slots[i]))
+	}
+	req := &snapReq{
+		peer: &peerConnection{id: "peer"},
+		storageTasks: []*snapStorageTask{
+			{Account: common.HexToHash("0xa1"), Root: tr.Hash()},
+			{Account: common.HexToHash("0xa2"), Root: common.HexToHash("0xbad")},
+		},
+	}
+	pack := &storageRangesPack{
+		peerId: "peer",
+		hashes: [][]common.Hash{hashes, hashes},
+		slots:  [][][]byte{slots, slots},
+	}
+	s := &stateSync{d: &Downloader{stateDB: database.NewMemoryDBManager()}}
+	progress := &snapProgress{}
+
+	assert.Error(t, s.processStorageRanges(progress, req, pack))
+	assert.Equal(t, uint64(0), progress.Slots)
+	assert.Empty(t, progress.StorageTasks)
+
+	// The request is put back as it was
+	s.revertRangeRequest(progress, req)
+	assert.Equal(t, req.storageTasks, progress.StorageTasks)
+	assert.Equal(t, common.Hash{}, progress.StorageTasks[0].Next)
+}
@@ -82,6 +82,8 @@ func (d *Downloader) stateFetcher() {
 			}
 		case <-d.stateCh:
 			// Ignore state responses while no sync is running.
+		case <-d.snapCh:
+			// Ignore state range responses while no sync is running.
 		case <-d.quitCh:
 			return
 		}
@@ -154,6 +156,16 @@ func (d *Downloader) runStateSync(s *stateSync) *stateSync {
 			finished = append(finished, req)
 			delete(active, pack.PeerId())
 
+			// Handle incoming state range packs:
+		case pack := <-d.snapCh:
+			// Hand over to the range retrieval of the current sync, or drop if
+			// the sync is not retrieving ranges anymore.
+			select {
+			case s.snapCh <- pack:
+			default:
+				logger.Debug("Unrequested state range", "peer", pack.PeerId(), "len", pack.Items())
+			}
+
 			// Handle dropped peer connections:
 		case p := <-peerDrop:
 			// Skip if no request is currently pending
@@ -214,7 +226,11 @@ func (d *Downloader) runStateSync(s *stateSync) *stateSync {
 // stateSync schedules requests for downloading a particular state trie defined
 // by a given state root.
 type stateSync struct {
-	d *Downloader // Downloader instance to access and manage current peerset
+	d    *Downloader // Downloader instance to access and manage current peerset
+	root common.Hash // State root currently being synced
+
+	snap   bool          // Whether the state ranges are retrieved before healing the trie
+	snapCh chan dataPack // Channel receiving the state ranges from the fetcher
 
 	sched  *statedb.TrieSync          // State trie sync scheduler defining the tasks
 	keccak hash.Hash                  // Keccak256 hasher to verify deliveries with
@@ -239,23 +255,45 @@ type stateTask struct {
 // newStateSync creates a new state trie download scheduler. This method does not
 // yet start the sync. The user needs to call run to initiate.
 func newStateSync(d *Downloader, root common.Hash) *stateSync {
-	return &stateSync{
+	s := &stateSync{
 		d:       d,
-		sched:   state.NewStateSync(root, d.stateDB, d.stateBloom, nil),
+		root:    root,
 		keccak:  sha3.NewKeccak256(),
 		tasks:   make(map[common.Hash]*stateTask),
 		deliver: make(chan *stateReq),
 		cancel:  make(chan struct{}),
 		done:    make(chan struct{}),
 	}
+	// In snap sync, the scheduler is created after the state ranges are
+	// retrieved and the trie is regenerated from them.
+	if d.getMode() == SnapSync {
+		s.snap = true
+		s.snapCh = make(chan dataPack, 1)
+	} else {
+		s.sched = state.NewStateSync(root, d.stateDB, d.stateBloom, nil)
+	}
+	return s
 }
 
 // run starts the task assignment and response processing loop, blocking until
 // it finishes, and finally notifying any goroutines waiting for the loop to
 // finish.
 func (s *stateSync) run() {
+	defer close(s.done)
+
+	if s.snap {
+		if s.err = s.syncRanges(); s.err != nil {
+			return
+		}
+	}
 	s.err = s.loop()
-	close(s.done)
+	if s.snap {
+		if s.err == nil {
+			s.err = s.finalizeSnapshot()
+		} else {
+			s.d.saveSnapProgress(s.d.loadSnapProgress(), nil)
+		}
+	}
 }
 
 // Wait blocks until the sync is done or canceled.
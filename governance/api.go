@@ -61,6 +61,7 @@ var (
 	errPermissionDenied       = errors.New("You don't have the right to vote")
 	errRemoveSelf             = errors.New("You can't vote on removing yourself")
 	errInvalidKeyValue        = errors.New("Your vote couldn't be placed. Please check your vote's key and value")
+	errInvalidBlockRange      = errors.New("Invalid block range: fromBlock is larger than toBlock")
 )
 
 // TODO-Klaytn-Governance: Refine this API and consider the gas price of txpool
@@ -247,6 +248,59 @@ func (api *PublicGovernanceAPI) NodeAddress() common.Address {
 	return api.governance.nodeAddress.Load().(common.Address)
 }
 
+// HistoryFilter selects the governance history records to be returned.
+// Omitted fields match all records, and toBlock defaults to the latest block.
+type HistoryFilter struct {
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Key       string           `json:"key"`
+	Voter     *common.Address  `json:"voter"`
+}
+
+// VoteHistory returns the votes decoded from the headers of the blocks in the given range,
+// filtered by the key and the voter of the votes.
+func (api *PublicGovernanceAPI) VoteHistory(filter HistoryFilter) ([]*VoteRecord, error) {
+	from, to, err := api.historyRange(filter)
+	if err != nil {
+		return nil, err
+	}
+	return api.governance.VoteHistory(from, to, filter.Key, filter.Voter), nil
+}
+
+// EpochHistory returns the tallies and the resulting governance changes at the epoch blocks in the given range.
+// If a key is given, only the epochs which tallied or changed the key are returned. The voter is not used.
+func (api *PublicGovernanceAPI) EpochHistory(filter HistoryFilter) ([]*EpochRecord, error) {
+	from, to, err := api.historyRange(filter)
+	if err != nil {
+		return nil, err
+	}
+	return api.governance.EpochHistory(from, to, filter.Key), nil
+}
+
+func (api *PublicGovernanceAPI) historyRange(filter HistoryFilter) (uint64, uint64, error) {
+	from, to := uint64(0), api.governance.blockChain.CurrentHeader().Number.Uint64()
+	if filter.FromBlock != nil {
+		if *filter.FromBlock == rpc.PendingBlockNumber {
+			return 0, 0, kerrors.ErrPendingBlockNotSupported
+		} else if *filter.FromBlock == rpc.LatestBlockNumber {
+			from = to
+		} else {
+			from = uint64(filter.FromBlock.Int64())
+		}
+	}
+	if filter.ToBlock != nil {
+		if *filter.ToBlock == rpc.PendingBlockNumber {
+			return 0, 0, kerrors.ErrPendingBlockNotSupported
+		} else if *filter.ToBlock != rpc.LatestBlockNumber {
+			to = uint64(filter.ToBlock.Int64())
+		}
+	}
+	if from > to {
+		return 0, 0, errInvalidBlockRange
+	}
+	return from, to, nil
+}
+
 func (api *PublicGovernanceAPI) isGovernanceModeBallot() bool {
 	if GovernanceModeMap[api.governance.GovernanceMode()] == params.GovernanceMode_Ballot {
 		return true
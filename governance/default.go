@@ -26,6 +26,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/log"
@@ -44,27 +45,34 @@ var (
 	ErrItemNotFound       = errors.New("Failed to find governance item")
 	ErrItemNil            = errors.New("Governance Item is nil")
 	ErrUnknownKey         = errors.New("Governnace value of the given key not found")
+	ErrGovParamState      = errors.New("State of the governance parameter contract is not available")
 )
 
 var (
 	GovernanceKeyMap = map[string]int{
-		"governance.governancemode":     params.GovernanceMode,
-		"governance.governingnode":      params.GoverningNode,
-		"istanbul.epoch":                params.Epoch,
-		"istanbul.policy":               params.Policy,
-		"istanbul.committeesize":        params.CommitteeSize,
-		"governance.unitprice":          params.UnitPrice,
-		"reward.mintingamount":          params.MintingAmount,
-		"reward.ratio":                  params.Ratio,
-		"reward.useginicoeff":           params.UseGiniCoeff,
-		"reward.deferredtxfee":          params.DeferredTxFee,
-		"reward.minimumstake":           params.MinimumStake,
-		"reward.stakingupdateinterval":  params.StakeUpdateInterval,
-		"reward.proposerupdateinterval": params.ProposerRefreshInterval,
-		"governance.addvalidator":       params.AddValidator,
-		"governance.removevalidator":    params.RemoveValidator,
-		"param.txgashumanreadable":      params.ConstTxGasHumanReadable,
-		"istanbul.timeout":              params.Timeout,
+		"governance.governancemode":       params.GovernanceMode,
+		"governance.governingnode":        params.GoverningNode,
+		"istanbul.epoch":                  params.Epoch,
+		"istanbul.policy":                 params.Policy,
+		"istanbul.committeesize":          params.CommitteeSize,
+		"governance.unitprice":            params.UnitPrice,
+		"reward.mintingamount":            params.MintingAmount,
+		"reward.ratio":                    params.Ratio,
+		"reward.useginicoeff":             params.UseGiniCoeff,
+		"reward.deferredtxfee":            params.DeferredTxFee,
+		"reward.minimumstake":             params.MinimumStake,
+		"reward.stakingupdateinterval":    params.StakeUpdateInterval,
+		"reward.proposerupdateinterval":   params.ProposerRefreshInterval,
+		"governance.addvalidator":         params.AddValidator,
+		"governance.removevalidator":      params.RemoveValidator,
+		"param.txgashumanreadable":        params.ConstTxGasHumanReadable,
+		"istanbul.timeout":                params.Timeout,
+		"kip71.lowerboundbasefee":         params.LowerBoundBaseFee,
+		"kip71.upperboundbasefee":         params.UpperBoundBaseFee,
+		"kip71.gastarget":                 params.GasTarget,
+		"kip71.maxblockgasusedforbasefee": params.MaxBlockGasUsedForBaseFee,
+		"kip71.basefeedenominator":        params.BaseFeeDenominator,
+		"governance.govparamcontract":     params.GovParamContract,
 	}
 
 	GovernanceForbiddenKeyMap = map[string]int{
@@ -74,24 +82,30 @@ var (
 	}
 
 	GovernanceKeyMapReverse = map[int]string{
-		params.GovernanceMode:          "governance.governancemode",
-		params.GoverningNode:           "governance.governingnode",
-		params.Epoch:                   "istanbul.epoch",
-		params.CliqueEpoch:             "clique.epoch",
-		params.Policy:                  "istanbul.policy",
-		params.CommitteeSize:           "istanbul.committeesize",
-		params.UnitPrice:               "governance.unitprice",
-		params.MintingAmount:           "reward.mintingamount",
-		params.Ratio:                   "reward.ratio",
-		params.UseGiniCoeff:            "reward.useginicoeff",
-		params.DeferredTxFee:           "reward.deferredtxfee",
-		params.MinimumStake:            "reward.minimumstake",
-		params.StakeUpdateInterval:     "reward.stakingupdateinterval",
-		params.ProposerRefreshInterval: "reward.proposerupdateinterval",
-		params.AddValidator:            "governance.addvalidator",
-		params.RemoveValidator:         "governance.removevalidator",
-		params.ConstTxGasHumanReadable: "param.txgashumanreadable",
-		params.Timeout:                 "istanbul.timeout",
+		params.GovernanceMode:            "governance.governancemode",
+		params.GoverningNode:             "governance.governingnode",
+		params.Epoch:                     "istanbul.epoch",
+		params.CliqueEpoch:               "clique.epoch",
+		params.Policy:                    "istanbul.policy",
+		params.CommitteeSize:             "istanbul.committeesize",
+		params.UnitPrice:                 "governance.unitprice",
+		params.MintingAmount:             "reward.mintingamount",
+		params.Ratio:                     "reward.ratio",
+		params.UseGiniCoeff:              "reward.useginicoeff",
+		params.DeferredTxFee:             "reward.deferredtxfee",
+		params.MinimumStake:              "reward.minimumstake",
+		params.StakeUpdateInterval:       "reward.stakingupdateinterval",
+		params.ProposerRefreshInterval:   "reward.proposerupdateinterval",
+		params.AddValidator:              "governance.addvalidator",
+		params.RemoveValidator:           "governance.removevalidator",
+		params.ConstTxGasHumanReadable:   "param.txgashumanreadable",
+		params.Timeout:                   "istanbul.timeout",
+		params.LowerBoundBaseFee:         "kip71.lowerboundbasefee",
+		params.UpperBoundBaseFee:         "kip71.upperboundbasefee",
+		params.GasTarget:                 "kip71.gastarget",
+		params.MaxBlockGasUsedForBaseFee: "kip71.maxblockgasusedforbasefee",
+		params.BaseFeeDenominator:        "kip71.basefeedenominator",
+		params.GovParamContract:          "governance.govparamcontract",
 	}
 
 	ProposerPolicyMap = map[string]int{
@@ -163,6 +177,8 @@ type txPool interface {
 // blockChain is an interface for blockchain.Blockchain used in governance package.
 type blockChain interface {
 	CurrentHeader() *types.Header
+	GetHeader(hash common.Hash, number uint64) *types.Header
+	StateAt(root common.Hash) (*state.StateDB, error)
 	SetProposerPolicy(val uint64)
 	SetUseGiniCoeff(val bool)
 }
@@ -520,9 +536,10 @@ func (g *Governance) ParseVoteValue(gVote *GovernanceVote) (*GovernanceVote, err
 	switch k {
 	case params.GovernanceMode, params.MintingAmount, params.MinimumStake, params.Ratio:
 		val = string(gVote.Value.([]uint8))
-	case params.GoverningNode, params.AddValidator, params.RemoveValidator:
+	case params.GoverningNode, params.AddValidator, params.RemoveValidator, params.GovParamContract:
 		val = common.BytesToAddress(gVote.Value.([]uint8))
-	case params.Epoch, params.CommitteeSize, params.UnitPrice, params.StakeUpdateInterval, params.ProposerRefreshInterval, params.ConstTxGasHumanReadable, params.Policy, params.Timeout:
+	case params.Epoch, params.CommitteeSize, params.UnitPrice, params.StakeUpdateInterval, params.ProposerRefreshInterval, params.ConstTxGasHumanReadable, params.Policy, params.Timeout,
+		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator:
 		gVote.Value = append(make([]byte, 8-len(gVote.Value.([]uint8))), gVote.Value.([]uint8)...)
 		val = binary.BigEndian.Uint64(gVote.Value.([]uint8))
 	case params.UseGiniCoeff, params.DeferredTxFee:
@@ -547,13 +564,14 @@ func (gov *Governance) ReflectVotes(vote GovernanceVote) {
 
 func (gov *Governance) updateChangeSet(vote GovernanceVote) bool {
 	switch GovernanceKeyMap[vote.Key] {
-	case params.GoverningNode:
+	case params.GoverningNode, params.GovParamContract:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(common.Address))
 		return true
 	case params.GovernanceMode, params.Ratio:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(string))
 		return true
-	case params.Epoch, params.StakeUpdateInterval, params.ProposerRefreshInterval, params.CommitteeSize, params.UnitPrice, params.ConstTxGasHumanReadable, params.Policy, params.Timeout:
+	case params.Epoch, params.StakeUpdateInterval, params.ProposerRefreshInterval, params.CommitteeSize, params.UnitPrice, params.ConstTxGasHumanReadable, params.Policy, params.Timeout,
+		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(uint64))
 		return true
 	case params.MintingAmount, params.MinimumStake:
@@ -749,19 +767,12 @@ func (gov *Governance) UpdateGovernance(number uint64, governance []byte) {
 	// Store updated governance information if exist
 	if number%epoch == 0 {
 		if len(governance) > 0 {
-			tempData := []byte("")
-			tempItems := make(map[string]interface{})
-			tempSet := NewGovernanceSet()
-			if err := rlp.DecodeBytes(governance, &tempData); err != nil {
+			tempItems, err := decodeGovernanceChanges(governance)
+			if err != nil {
 				logger.Error("Failed to decode governance data", "number", number, "err", err, "data", governance)
 				return
 			}
-			if err := json.Unmarshal(tempData, &tempItems); err != nil {
-				logger.Error("Failed to unmarshal governance data", "number", number, "err", err, "data", tempData)
-				return
-
-			}
-			tempItems = adjustDecodedSet(tempItems)
+			tempSet := NewGovernanceSet()
 			tempSet.Import(tempItems)
 
 			// Store new currentSet to governance database
@@ -800,7 +811,7 @@ func adjustDecodedSet(src map[string]interface{}) map[string]interface{} {
 		if x.Kind() == reflect.Float64 {
 			src[k] = uint64(v.(float64))
 		}
-		if GovernanceKeyMap[k] == params.GoverningNode {
+		if GovernanceKeyMap[k] == params.GoverningNode || GovernanceKeyMap[k] == params.GovParamContract {
 			if reflect.TypeOf(v) == stringT {
 				src[k] = common.HexToAddress(v.(string))
 			} else {
@@ -831,15 +842,25 @@ func (gov *Governance) VerifyGovernance(received []byte) error {
 	}
 	rChangeSet = adjustDecodedSet(rChangeSet)
 
-	if len(rChangeSet) == gov.changeSet.Size() {
+	// The items set in the governance parameter contract override the header votes,
+	// so they are verified against the state by VerifyGovParamChanges instead.
+	changeSet := gov.changeSet.Items()
+	if gov.govParamContractDesignated(rChangeSet) {
+		for k := range GovParamContractKeyMap {
+			delete(rChangeSet, k)
+			delete(changeSet, k)
+		}
+	}
+
+	if len(rChangeSet) == len(changeSet) {
 		for k, v := range rChangeSet {
-			if GovernanceKeyMap[k] == params.GoverningNode {
+			if GovernanceKeyMap[k] == params.GoverningNode || GovernanceKeyMap[k] == params.GovParamContract {
 				if reflect.TypeOf(v) == stringT {
 					v = common.HexToAddress(v.(string))
 				}
 			}
 
-			have, _ := gov.changeSet.GetValue(GovernanceKeyMap[k])
+			have := changeSet[k]
 			if have != v {
 				logger.Error("Verification Error", "key", k, "received", rChangeSet[k], "have", have, "receivedType", reflect.TypeOf(rChangeSet[k]), "haveType", reflect.TypeOf(have))
 				return ErrVoteValueMismatch
@@ -957,6 +978,18 @@ func GetGovernanceItemsFromChainConfig(config *params.ChainConfig) GovernanceSet
 			params.ProposerRefreshInterval: governance.Reward.ProposerUpdateInterval,
 		}
 
+		if governance.GovParamContract != (common.Address{}) {
+			governanceMap[params.GovParamContract] = governance.GovParamContract
+		}
+
+		if kip71 := governance.KIP71; kip71 != nil {
+			governanceMap[params.LowerBoundBaseFee] = kip71.LowerBoundBaseFee
+			governanceMap[params.UpperBoundBaseFee] = kip71.UpperBoundBaseFee
+			governanceMap[params.GasTarget] = kip71.GasTarget
+			governanceMap[params.MaxBlockGasUsedForBaseFee] = kip71.MaxBlockGasUsedForBaseFee
+			governanceMap[params.BaseFeeDenominator] = kip71.BaseFeeDenominator
+		}
+
 		for k, v := range governanceMap {
 			if err := g.SetValue(k, v); err != nil {
 				writeFailLog(k, err)
@@ -1049,6 +1082,42 @@ func (gov *Governance) UseGiniCoeff() bool {
 	return gov.GetGovernanceValue(params.UseGiniCoeff).(bool)
 }
 
+// KIP71ConfigAt returns the dynamic base fee parameters effective at the given block number.
+// A parameter which has never been set by governance comes from the chain config.
+func (gov *Governance) KIP71ConfigAt(num uint64) (*params.KIP71Config, error) {
+	_, data, err := gov.ReadGovernance(num)
+	if err != nil {
+		return nil, err
+	}
+	return gov.makeKIP71Config(func(key int) interface{} { return data[GovernanceKeyMapReverse[key]] }), nil
+}
+
+// currentKIP71Config returns the dynamic base fee parameters of the current governance set.
+// A parameter which has never been set by governance comes from the chain config.
+func (gov *Governance) currentKIP71Config() *params.KIP71Config {
+	return gov.makeKIP71Config(gov.GetGovernanceValue)
+}
+
+// makeKIP71Config returns the dynamic base fee parameters of the chain config overridden by
+// the governance items returned by the given function.
+func (gov *Governance) makeKIP71Config(value func(key int) interface{}) *params.KIP71Config {
+	kip71 := *gov.ChainConfig.Governance.KIP71Config()
+
+	items := map[int]*uint64{
+		params.LowerBoundBaseFee:         &kip71.LowerBoundBaseFee,
+		params.UpperBoundBaseFee:         &kip71.UpperBoundBaseFee,
+		params.GasTarget:                 &kip71.GasTarget,
+		params.MaxBlockGasUsedForBaseFee: &kip71.MaxBlockGasUsedForBaseFee,
+		params.BaseFeeDenominator:        &kip71.BaseFeeDenominator,
+	}
+	for key, field := range items {
+		if v, ok := value(key).(uint64); ok {
+			*field = v
+		}
+	}
+	return &kip71
+}
+
 func (gov *Governance) ChainId() uint64 {
 	return gov.ChainConfig.ChainID.Uint64()
 }
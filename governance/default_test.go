@@ -104,6 +104,22 @@ var tstData = []voteValue{
 	{k: "istanbul.timeout", v: true, e: false},
 	{k: "istanbul.timeout", v: "10", e: false},
 	{k: "istanbul.timeout", v: 5.3, e: false},
+	{k: "kip71.lowerboundbasefee", v: uint64(25000000000), e: true},
+	{k: "kip71.upperboundbasefee", v: uint64(750000000000), e: true},
+	{k: "kip71.gastarget", v: uint64(30000000), e: true},
+	{k: "kip71.maxblockgasusedforbasefee", v: uint64(60000000), e: true},
+	{k: "kip71.basefeedenominator", v: uint64(20), e: true},
+	{k: "kip71.basefeedenominator", v: "20", e: false},
+	{k: "kip71.gastarget", v: -1, e: false},
+	{k: "kip71.gastarget", v: uint64(0), e: false},
+	{k: "kip71.basefeedenominator", v: uint64(0), e: false},
+	{k: "kip71.lowerboundbasefee", v: uint64(750000000001), e: false},
+	{k: "kip71.upperboundbasefee", v: uint64(24999999999), e: false},
+	{k: "kip71.gastarget", v: uint64(60000001), e: false},
+	{k: "kip71.maxblockgasusedforbasefee", v: uint64(29999999), e: false},
+	{k: "governance.govparamcontract", v: "0x0000000000000000000000000000000000000400", e: true},
+	{k: "governance.govparamcontract", v: common.HexToAddress("0x0000000000000000000000000000000000000400"), e: true},
+	{k: "governance.govparamcontract", v: "contract", e: false},
 }
 
 var goodVotes = []voteValue{
@@ -116,6 +132,7 @@ var goodVotes = []voteValue{
 	{k: "reward.mintingamount", v: "9600000000000000000", e: true},
 	{k: "reward.ratio", v: "10/10/80", e: true},
 	{k: "istanbul.timeout", v: uint64(5000), e: true},
+	{k: "kip71.gastarget", v: uint64(20000000), e: true},
 }
 
 func getTestConfig() *params.ChainConfig {
@@ -176,6 +193,29 @@ func TestGovernance_ValidateVote(t *testing.T) {
 	}
 }
 
+func TestGovernance_ValidateVote_KIP71(t *testing.T) {
+	gov := getGovernance()
+
+	// The upper bound can't be lower than the effective lower bound
+	_, ok := gov.ValidateVote(&GovernanceVote{Key: "kip71.upperboundbasefee", Value: uint64(100000000000)})
+	assert.True(t, ok)
+	_, ok = gov.ValidateVote(&GovernanceVote{Key: "kip71.upperboundbasefee", Value: uint64(25000000000)})
+	assert.True(t, ok)
+
+	// The votes are validated against the effective values, not the values in the chain config
+	gov.currentSet.SetValue(params.LowerBoundBaseFee, uint64(50000000000))
+	gov.currentSet.SetValue(params.MaxBlockGasUsedForBaseFee, uint64(40000000))
+
+	_, ok = gov.ValidateVote(&GovernanceVote{Key: "kip71.upperboundbasefee", Value: uint64(25000000000)})
+	assert.False(t, ok)
+	_, ok = gov.ValidateVote(&GovernanceVote{Key: "kip71.gastarget", Value: uint64(50000000)})
+	assert.False(t, ok)
+	_, ok = gov.ValidateVote(&GovernanceVote{Key: "kip71.gastarget", Value: uint64(40000000)})
+	assert.True(t, ok)
+	_, ok = gov.ValidateVote(&GovernanceVote{Key: "kip71.maxblockgasusedforbasefee", Value: uint64(60000000)})
+	assert.True(t, ok)
+}
+
 func TestGovernance_AddVote(t *testing.T) {
 	gov := getGovernance()
 
@@ -783,3 +823,24 @@ func TestGovernance_HandleGovernanceVote_Ballot_mode(t *testing.T) {
 	}
 	gov.voteMap.Clear()
 }
+
+func TestGovernance_KIP71ConfigAt(t *testing.T) {
+	gov := getGovernance()
+
+	// Without any governance item, the parameters come from the chain config
+	kip71, err := gov.KIP71ConfigAt(0)
+	assert.NoError(t, err)
+	assert.Equal(t, params.GetDefaultKIP71Config(), kip71)
+
+	config := getTestConfig()
+	config.Governance.KIP71 = params.GetDefaultKIP71Config()
+	config.Governance.KIP71.GasTarget = 20000000
+	config.Governance.KIP71.BaseFeeDenominator = 64
+	AddGovernanceCacheForTest(gov, 0, config)
+
+	kip71, err = gov.KIP71ConfigAt(0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20000000), kip71.GasTarget)
+	assert.Equal(t, uint64(64), kip71.BaseFeeDenominator)
+	assert.Equal(t, params.DefaultLowerBoundBaseFee, kip71.LowerBoundBaseFee)
+}
@@ -40,6 +40,12 @@ Following keys can be handled as of 7/20/2019.
   - "reward.useginicoeff"         : To change the application of gini coefficient to reduce gap between CCOs
   - "reward.deferredtxfee"        : To change the way of distributing tx fee
   - "reward.minimumstake"         : To change the minimum amount of stake to participate in the governance council
+  - "kip71.lowerboundbasefee"     : To change the minimum base fee after the magma fork
+  - "kip71.upperboundbasefee"     : To change the maximum base fee after the magma fork
+  - "kip71.gastarget"             : To change the block gas usage at which the base fee stays the same
+  - "kip71.maxblockgasusedforbasefee" : To change the cap of the block gas usage used to calculate the base fee
+  - "kip71.basefeedenominator"    : To change the bound divisor of the base fee change between blocks
+  - "governance.govparamcontract" : To designate the governance parameter contract, or to stop using it with the zero address
 
 
 How governance works
@@ -54,12 +60,24 @@ parse the header and handle it. This process is handled by snapshot.go in the co
 If a vote satisfies the requirement (more than 50% of votes in favor of), it will update the governance struct and many other packages
 like "reward", "txpool" and so on will reference it.
 
+Governance parameter contract
+
+If "governance.govparamcontract" is set, the items listed in GovParamContractKeyMap can also be changed by the contract
+(contracts/gov/GovParam.sol) without votes. The proposer of an epoch block reads the contract storage from the state of
+the parent block and puts the values into the governance field of the header, overriding the changes by header votes.
+The validators verify them against their own state before committing the block. Since the values are carried by the header,
+every node stores them in the governance database in the same way as the changes by header votes, even if it skipped the
+state of the block by fast sync or pruned it afterwards. A value set in the contract takes precedence over the value set
+by header votes, which in turn takes precedence over the chain config. Empty or invalid values in the contract are ignored.
+
 
 Source Files
 
 Governance related functions and variables are defined in the files listed below
   - default.go    : the governance struct, cache and persistence
   - handler.go    : functions to handle votes and its application
+  - govparam.go   : functions to read governance items from the governance parameter contract
+  - history.go    : the history of votes and epoch changes, queried by governance.voteHistory and governance.epochHistory
   - api.go        : console APIs to get governance information and to cast a vote
 
 */
@@ -0,0 +1,211 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+)
+
+// maxGovParamValueLength is the maximum length of a value read from the governance parameter contract.
+const maxGovParamValueLength = 1024
+
+// govParamMappingSlot is the storage slot of the parameter mapping of the governance parameter contract.
+// The contract (contracts/gov/GovParam.sol) keeps its owner at slot 0 and a mapping(string => bytes) at slot 1.
+var govParamMappingSlot = common.BigToHash(big.NewInt(1))
+
+// GovParamContractKeyMap lists the governance items which can be set by the governance parameter contract.
+// Their values are carried by the governance field of every epoch header along with the changes by header
+// votes, so they take effect at the same epoch boundary as a passed vote would. The other items can only be
+// changed by header votes.
+var GovParamContractKeyMap = map[string]int{
+	"reward.mintingamount":            params.MintingAmount,
+	"reward.ratio":                    params.Ratio,
+	"kip71.lowerboundbasefee":         params.LowerBoundBaseFee,
+	"kip71.upperboundbasefee":         params.UpperBoundBaseFee,
+	"kip71.gastarget":                 params.GasTarget,
+	"kip71.maxblockgasusedforbasefee": params.MaxBlockGasUsedForBaseFee,
+	"kip71.basefeedenominator":        params.BaseFeeDenominator,
+}
+
+// stateReader is the part of state.StateDB used to read the governance parameter contract.
+type stateReader interface {
+	GetState(addr common.Address, hash common.Hash) common.Hash
+}
+
+// GetGovernanceChangeAt returns the governance changes to be carried by the given epoch header.
+// The values set in the governance parameter contract, read from the state of the parent block,
+// override the changes by header votes.
+func (g *Governance) GetGovernanceChangeAt(header *types.Header) (map[string]interface{}, error) {
+	changes := g.GetGovernanceChange()
+	items, err := g.govParamItems(header, changes)
+	if err != nil || len(items) == 0 {
+		return changes, err
+	}
+	if changes == nil {
+		changes = make(map[string]interface{}, len(items))
+	}
+	for k, v := range items {
+		changes[k] = v
+	}
+	return changes, nil
+}
+
+// VerifyGovParamChanges returns an error if the given epoch header does not carry the values set in
+// the governance parameter contract, read from the state of the parent block.
+func (g *Governance) VerifyGovParamChanges(header *types.Header) error {
+	changes := make(map[string]interface{})
+	if len(header.Governance) > 0 {
+		var err error
+		if changes, err = decodeGovernanceChanges(header.Governance); err != nil {
+			return ErrDecodeGovChange
+		}
+	}
+	items, err := g.govParamItems(header, changes)
+	if err != nil {
+		return err
+	}
+	for k, v := range items {
+		if changes[k] != v {
+			logger.Error("Verification Error", "key", k, "received", changes[k], "have", v)
+			return ErrVoteValueMismatch
+		}
+	}
+	return nil
+}
+
+// govParamContractDesignated returns true if a governance parameter contract is designated
+// by the given governance changes or by the current governance set.
+func (g *Governance) govParamContractDesignated(changes map[string]interface{}) bool {
+	contract, ok := changes[GovernanceKeyMapReverse[params.GovParamContract]].(common.Address)
+	if !ok {
+		contract, _ = g.GetGovernanceValue(params.GovParamContract).(common.Address)
+	}
+	return contract != (common.Address{})
+}
+
+// govParamItems returns the governance items set in the governance parameter contract, read from the state
+// of the parent block of the given epoch header. The contract is designated by the given governance changes
+// of the header, or by the governance data stored before the header.
+func (g *Governance) govParamItems(header *types.Header, changes map[string]interface{}) (map[string]interface{}, error) {
+	num := header.Number.Uint64()
+	if g.ChainConfig.Istanbul == nil || num == 0 || num%g.Epoch() != 0 {
+		return nil, nil
+	}
+
+	key := GovernanceKeyMapReverse[params.GovParamContract]
+	contract, ok := changes[key].(common.Address)
+	if !ok {
+		_, data, err := g.ReadGovernance(num)
+		if err != nil {
+			return nil, err
+		}
+		contract, _ = data[key].(common.Address)
+	}
+	if contract == (common.Address{}) {
+		return nil, nil
+	}
+
+	if g.blockChain == nil {
+		return nil, ErrGovParamState
+	}
+	parent := g.blockChain.GetHeader(header.ParentHash, num-1)
+	if parent == nil {
+		logger.Error("Failed to find the parent of the epoch block", "number", num)
+		return nil, ErrGovParamState
+	}
+	stateDB, err := g.blockChain.StateAt(parent.Root)
+	if err != nil {
+		logger.Error("Failed to read the state of the governance parameter contract", "number", num, "err", err)
+		return nil, ErrGovParamState
+	}
+	return g.parseGovParamValues(contract, readGovParamValues(stateDB, contract)), nil
+}
+
+// readGovParamValues reads the non-empty values of the governance items which can be set by the contract.
+func readGovParamValues(st stateReader, contract common.Address) map[string][]byte {
+	values := make(map[string][]byte)
+	for name := range GovParamContractKeyMap {
+		if value := readGovParamValue(st, contract, name); len(value) > 0 {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// parseGovParamValues parses the values read from the contract into governance items.
+// A value is encoded in the same way as the value of a header vote. Invalid values are ignored.
+func (g *Governance) parseGovParamValues(contract common.Address, values map[string][]byte) map[string]interface{} {
+	items := make(map[string]interface{})
+	for name, value := range values {
+		key, ok := GovParamContractKeyMap[name]
+		if !ok {
+			continue
+		}
+		if t := GovernanceItems[key].t; (t == uint64T || t == boolT) && len(value) > 8 {
+			logger.Warn("Ignoring too long governance parameter", "contract", contract, "key", name, "value", hexutil.Bytes(value))
+			continue
+		}
+
+		vote, err := g.ParseVoteValue(&GovernanceVote{Key: name, Value: value})
+		if err != nil {
+			logger.Warn("Ignoring undecodable governance parameter", "contract", contract, "key", name, "err", err)
+			continue
+		}
+		if _, ok := g.ValidateVote(vote); !ok {
+			logger.Warn("Ignoring invalid governance parameter", "contract", contract, "key", name, "value", vote.Value)
+			continue
+		}
+		items[name] = vote.Value
+	}
+	return items
+}
+
+// readGovParamValue reads the value of the given key from the parameter mapping of the contract,
+// following the storage layout of a Solidity mapping(string => bytes).
+func readGovParamValue(st stateReader, contract common.Address, name string) []byte {
+	slot := crypto.Keccak256Hash([]byte(name), govParamMappingSlot.Bytes())
+	word := st.GetState(contract, slot)
+
+	// A short value is kept in the slot itself, with its length*2 in the lowest byte
+	if word[common.HashLength-1]&1 == 0 {
+		length := int(word[common.HashLength-1] / 2)
+		if length >= common.HashLength {
+			return nil
+		}
+		return common.CopyBytes(word[:length])
+	}
+
+	// A long value keeps its length*2+1 in the slot and the data from the slot keccak256(slot)
+	length := new(big.Int).Rsh(word.Big(), 1)
+	if !length.IsUint64() || length.Uint64() > maxGovParamValueLength {
+		return nil
+	}
+	value := make([]byte, 0, length.Uint64()+common.HashLength)
+	pos := crypto.Keccak256Hash(slot.Bytes()).Big()
+	for uint64(len(value)) < length.Uint64() {
+		data := st.GetState(contract, common.BigToHash(pos))
+		value = append(value, data.Bytes()...)
+		pos.Add(pos, common.Big1)
+	}
+	return value[:length.Uint64()]
+}
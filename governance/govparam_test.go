@@ -0,0 +1,200 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testGovParamChain struct {
+	header  *types.Header
+	stateDB *state.StateDB
+}
+
+func (bc *testGovParamChain) CurrentHeader() *types.Header                { return bc.header }
+func (bc *testGovParamChain) GetHeader(common.Hash, uint64) *types.Header { return bc.header }
+func (bc *testGovParamChain) StateAt(common.Hash) (*state.StateDB, error) { return bc.stateDB, nil }
+func (bc *testGovParamChain) SetProposerPolicy(val uint64)                {}
+func (bc *testGovParamChain) SetUseGiniCoeff(val bool)                    {}
+
+// setGovParam stores the value in the same way as GovParam.setParam does.
+func setGovParam(stateDB *state.StateDB, contract common.Address, name string, value []byte) {
+	slot := crypto.Keccak256Hash([]byte(name), govParamMappingSlot.Bytes())
+	if len(value) < common.HashLength {
+		word := common.Hash{}
+		copy(word[:], value)
+		word[common.HashLength-1] = byte(len(value) * 2)
+		stateDB.SetState(contract, slot, word)
+		return
+	}
+	stateDB.SetState(contract, slot, common.BigToHash(big.NewInt(int64(len(value)*2+1))))
+	pos := crypto.Keccak256Hash(slot.Bytes()).Big()
+	for i := 0; i < len(value); i += common.HashLength {
+		word := common.Hash{}
+		copy(word[:], value[i:])
+		stateDB.SetState(contract, common.BigToHash(pos), word)
+		pos.Add(pos, common.Big1)
+	}
+}
+
+// encodeGovernanceChanges encodes the changes in the same way as the governance field of a header.
+func encodeGovernanceChanges(t *testing.T, changes map[string]interface{}) []byte {
+	data, err := json.Marshal(changes)
+	require.NoError(t, err)
+	b, err := rlp.EncodeToBytes(data)
+	require.NoError(t, err)
+	return b
+}
+
+func newGovParamStateDB() *state.StateDB {
+	stateDB, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	return stateDB
+}
+
+func TestReadGovParamValue(t *testing.T) {
+	stateDB := newGovParamStateDB()
+	contract := common.HexToAddress("0x0000000000000000000000000000000000000400")
+
+	values := map[string][]byte{
+		"short": []byte("34/54/12"),
+		"edge":  make([]byte, common.HashLength-1),
+		"long":  []byte("a value which is longer than a single storage slot of the contract"),
+	}
+	for name, value := range values {
+		setGovParam(stateDB, contract, name, value)
+	}
+
+	for name, value := range values {
+		assert.Equal(t, value, readGovParamValue(stateDB, contract, name), name)
+	}
+	assert.Empty(t, readGovParamValue(stateDB, contract, "unset"))
+}
+
+func TestGovernance_GetGovernanceChangeAt(t *testing.T) {
+	contract := common.HexToAddress("0x0000000000000000000000000000000000000400")
+	config := getTestConfig()
+	config.Governance.GovParamContract = contract
+	gov := NewGovernanceInitialize(config, database.NewMemoryDBManager())
+
+	epoch := config.Istanbul.Epoch
+	header := &types.Header{Number: new(big.Int).SetUint64(epoch)}
+
+	// Without the state of the parent block, the values of the contract cannot be read
+	_, err := gov.GetGovernanceChangeAt(header)
+	assert.Equal(t, ErrGovParamState, err)
+
+	stateDB := newGovParamStateDB()
+	setGovParam(stateDB, contract, "reward.ratio", []byte("50/40/10"))
+	setGovParam(stateDB, contract, "kip71.gastarget", []byte{0x01, 0x31, 0x2d, 0x00}) // 20000000
+	setGovParam(stateDB, contract, "reward.mintingamount", []byte("many"))            // invalid
+	setGovParam(stateDB, contract, "istanbul.epoch", []byte{0x10})                    // not allowed
+	gov.SetBlockchain(&testGovParamChain{header: &types.Header{Number: new(big.Int).SetUint64(epoch - 1)}, stateDB: stateDB})
+
+	// The values of the contract override the changes by header votes
+	gov.changeSet.SetValue(params.Ratio, "30/30/40")
+	gov.changeSet.SetValue(params.BaseFeeDenominator, uint64(64))
+	changes, err := gov.GetGovernanceChangeAt(header)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"reward.ratio":             "50/40/10",
+		"kip71.gastarget":          uint64(20000000),
+		"kip71.basefeedenominator": uint64(64),
+	}, changes)
+
+	// Only epoch blocks carry the values of the contract
+	changes, err = gov.GetGovernanceChangeAt(&types.Header{Number: new(big.Int).SetUint64(epoch + 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, "30/30/40", changes["reward.ratio"])
+	assert.NotContains(t, changes, "kip71.gastarget")
+
+	// The validators accept the header carrying the values of the contract only
+	header.Governance = encodeGovernanceChanges(t, map[string]interface{}{
+		"reward.ratio":             "50/40/10",
+		"kip71.gastarget":          uint64(20000000),
+		"kip71.basefeedenominator": uint64(64),
+	})
+	assert.NoError(t, gov.VerifyGovParamChanges(header))
+
+	tampered := &types.Header{Number: header.Number, Governance: encodeGovernanceChanges(t, map[string]interface{}{
+		"reward.ratio":             "30/30/40",
+		"kip71.gastarget":          uint64(20000000),
+		"kip71.basefeedenominator": uint64(64),
+	})}
+	assert.Equal(t, ErrVoteValueMismatch, gov.VerifyGovParamChanges(tampered))
+	assert.Equal(t, ErrVoteValueMismatch, gov.VerifyGovParamChanges(&types.Header{Number: header.Number}))
+
+	// The check of the header votes leaves the values of the contract to VerifyGovParamChanges
+	assert.NoError(t, gov.VerifyGovernance(encodeGovernanceChanges(t, map[string]interface{}{
+		"reward.ratio":             "50/40/10",
+		"kip71.basefeedenominator": uint64(64),
+	})))
+
+	// Every node stores the values carried by the header without reading the state
+	gov.SetBlockchain(nil)
+	gov.UpdateGovernance(epoch, header.Governance)
+	_, data, err := gov.ReadGovernance(2 * epoch)
+	assert.NoError(t, err)
+	assert.Equal(t, "50/40/10", data["reward.ratio"])
+	assert.Equal(t, config.Governance.Reward.MintingAmount.String(), data["reward.mintingamount"])
+	assert.Equal(t, config.Istanbul.Epoch, data["istanbul.epoch"])
+
+	kip71, err := gov.KIP71ConfigAt(2 * epoch)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20000000), kip71.GasTarget)
+	assert.Equal(t, uint64(64), kip71.BaseFeeDenominator)
+}
+
+func TestGovernance_GetGovernanceChangeAt_Designated(t *testing.T) {
+	contract := common.HexToAddress("0x0000000000000000000000000000000000000400")
+	config := getTestConfig()
+	gov := NewGovernanceInitialize(config, database.NewMemoryDBManager())
+
+	epoch := config.Istanbul.Epoch
+	header := &types.Header{Number: new(big.Int).SetUint64(epoch)}
+
+	// Without a designated contract, the state is not needed
+	changes, err := gov.GetGovernanceChangeAt(header)
+	assert.NoError(t, err)
+	assert.Nil(t, changes)
+	assert.NoError(t, gov.VerifyGovParamChanges(header))
+
+	stateDB := newGovParamStateDB()
+	setGovParam(stateDB, contract, "reward.ratio", []byte("50/40/10"))
+	gov.SetBlockchain(&testGovParamChain{header: &types.Header{Number: new(big.Int).SetUint64(epoch - 1)}, stateDB: stateDB})
+
+	// The contract designated by the changes of the epoch block is read at the block
+	gov.changeSet.SetValue(params.GovParamContract, contract)
+	changes, err = gov.GetGovernanceChangeAt(header)
+	assert.NoError(t, err)
+	assert.Equal(t, contract, changes["governance.govparamcontract"])
+	assert.Equal(t, "50/40/10", changes["reward.ratio"])
+
+	header.Governance = encodeGovernanceChanges(t, changes)
+	assert.NoError(t, gov.VerifyGovParamChanges(header))
+}
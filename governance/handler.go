@@ -45,23 +45,29 @@ var (
 )
 
 var GovernanceItems = map[int]check{
-	params.GovernanceMode:          {stringT, checkGovernanceMode, nil},
-	params.GoverningNode:           {addressT, checkAddress, nil},
-	params.UnitPrice:               {uint64T, checkUint64andBool, updateUnitPrice},
-	params.AddValidator:            {addressT, checkAddress, nil},
-	params.RemoveValidator:         {addressT, checkAddress, nil},
-	params.MintingAmount:           {stringT, checkBigInt, nil},
-	params.Ratio:                   {stringT, checkRatio, nil},
-	params.UseGiniCoeff:            {boolT, checkUint64andBool, updateUseGiniCoeff},
-	params.DeferredTxFee:           {boolT, checkUint64andBool, nil},
-	params.MinimumStake:            {stringT, checkBigInt, nil},
-	params.StakeUpdateInterval:     {uint64T, checkUint64andBool, updateStakingUpdateInterval},
-	params.ProposerRefreshInterval: {uint64T, checkUint64andBool, updateProposerUpdateInterval},
-	params.Epoch:                   {uint64T, checkUint64andBool, nil},
-	params.Policy:                  {uint64T, checkUint64andBool, updateProposerPolicy},
-	params.CommitteeSize:           {uint64T, checkUint64andBool, nil},
-	params.ConstTxGasHumanReadable: {uint64T, checkUint64andBool, updateTxGasHumanReadable},
-	params.Timeout:                 {uint64T, checkUint64andBool, nil},
+	params.GovernanceMode:            {stringT, checkGovernanceMode, nil},
+	params.GoverningNode:             {addressT, checkAddress, nil},
+	params.UnitPrice:                 {uint64T, checkUint64andBool, updateUnitPrice},
+	params.AddValidator:              {addressT, checkAddress, nil},
+	params.RemoveValidator:           {addressT, checkAddress, nil},
+	params.MintingAmount:             {stringT, checkBigInt, nil},
+	params.Ratio:                     {stringT, checkRatio, nil},
+	params.UseGiniCoeff:              {boolT, checkUint64andBool, updateUseGiniCoeff},
+	params.DeferredTxFee:             {boolT, checkUint64andBool, nil},
+	params.MinimumStake:              {stringT, checkBigInt, nil},
+	params.StakeUpdateInterval:       {uint64T, checkUint64andBool, updateStakingUpdateInterval},
+	params.ProposerRefreshInterval:   {uint64T, checkUint64andBool, updateProposerUpdateInterval},
+	params.Epoch:                     {uint64T, checkUint64andBool, nil},
+	params.Policy:                    {uint64T, checkUint64andBool, updateProposerPolicy},
+	params.CommitteeSize:             {uint64T, checkUint64andBool, nil},
+	params.ConstTxGasHumanReadable:   {uint64T, checkUint64andBool, updateTxGasHumanReadable},
+	params.Timeout:                   {uint64T, checkUint64andBool, nil},
+	params.LowerBoundBaseFee:         {uint64T, checkUint64andBool, nil},
+	params.UpperBoundBaseFee:         {uint64T, checkUint64andBool, nil},
+	params.GasTarget:                 {uint64T, checkNonZeroUint64, nil},
+	params.MaxBlockGasUsedForBaseFee: {uint64T, checkUint64andBool, nil},
+	params.BaseFeeDenominator:        {uint64T, checkNonZeroUint64, nil},
+	params.GovParamContract:          {addressT, checkAddress, nil},
 }
 
 func updateTxGasHumanReadable(g *Governance, k string, v interface{}) {
@@ -167,11 +173,30 @@ func (gov *Governance) ValidateVote(vote *GovernanceVote) (*GovernanceVote, bool
 	vote.Value = gov.adjustValueType(vote.Key, vote.Value)
 
 	if gov.checkKey(vote.Key) && gov.checkType(vote) {
-		return vote, GovernanceItems[key].validator(vote.Key, vote.Value)
+		return vote, GovernanceItems[key].validator(vote.Key, vote.Value) && gov.checkKIP71(key, vote.Value)
 	}
 	return vote, false
 }
 
+// checkKIP71 checks if the dynamic base fee parameters stay consistent when the given one is
+// changed from the currently effective value.
+func (gov *Governance) checkKIP71(key int, v interface{}) bool {
+	kip71 := gov.currentKIP71Config()
+	switch key {
+	case params.LowerBoundBaseFee:
+		kip71.LowerBoundBaseFee = v.(uint64)
+	case params.UpperBoundBaseFee:
+		kip71.UpperBoundBaseFee = v.(uint64)
+	case params.GasTarget:
+		kip71.GasTarget = v.(uint64)
+	case params.MaxBlockGasUsedForBaseFee:
+		kip71.MaxBlockGasUsedForBaseFee = v.(uint64)
+	default:
+		return true
+	}
+	return kip71.LowerBoundBaseFee <= kip71.UpperBoundBaseFee && kip71.GasTarget <= kip71.MaxBlockGasUsedForBaseFee
+}
+
 func checkRatio(k string, v interface{}) bool {
 	x := strings.Split(v.(string), "/")
 	if len(x) != params.RewardSliceCount {
@@ -207,6 +232,13 @@ func checkUint64andBool(k string, v interface{}) bool {
 	return false
 }
 
+func checkNonZeroUint64(k string, v interface{}) bool {
+	if x, ok := v.(uint64); ok && x != 0 {
+		return true
+	}
+	return false
+}
+
 func checkProposerPolicy(k string, v interface{}) bool {
 	if _, ok := ProposerPolicyMap[v.(string)]; ok {
 		return true
@@ -241,6 +273,11 @@ func (gov *Governance) HandleGovernanceVote(valset istanbul.ValidatorSet, votes
 			return valset, votes, tally
 		}
 
+		// Record the vote for the history whether it is accepted or not
+		accepted := false
+		record := *gVote
+		defer func() { gov.writeVoteRecord(header, &record, accepted) }()
+
 		// If the given key is forbidden, stop processing
 		if _, ok := GovernanceForbiddenKeyMap[gVote.Key]; ok {
 			logger.Warn("Forbidden vote key was received", "key", gVote.Key, "value", gVote.Value, "from", gVote.Validator)
@@ -268,6 +305,7 @@ func (gov *Governance) HandleGovernanceVote(valset istanbul.ValidatorSet, votes
 		number := header.Number.Uint64()
 		// Check vote's validity
 		if gVote, ok := gov.ValidateVote(gVote); ok {
+			accepted = true
 			governanceMode := GovernanceModeMap[gov.GovernanceMode()]
 			governingNode := gov.GoverningNode()
 
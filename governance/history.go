@@ -0,0 +1,191 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// VoteRecord is a governance vote decoded from a block header.
+type VoteRecord struct {
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Validator   common.Address `json:"validator"`
+	Key         string         `json:"key"`
+	Value       interface{}    `json:"value"`
+	Accepted    bool           `json:"accepted"` // false if the vote was ignored
+}
+
+// EpochRecord is the governance tally and the resulting changes at an epoch block.
+type EpochRecord struct {
+	BlockNumber    uint64                 `json:"blockNumber"`
+	Tally          []GovernanceTallyItem  `json:"tally"`
+	Previous       map[string]interface{} `json:"previous,omitempty"` // values of the changed items before the changes
+	Changes        map[string]interface{} `json:"changes,omitempty"`
+	EffectiveBlock uint64                 `json:"effectiveBlock"` // the first block where the changes take effect
+}
+
+// decodeGovernanceChanges decodes the governance changes written in the header of an epoch block.
+func decodeGovernanceChanges(governance []byte) (map[string]interface{}, error) {
+	data := []byte("")
+	if err := rlp.DecodeBytes(governance, &data); err != nil {
+		return nil, err
+	}
+	items := make(map[string]interface{})
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return adjustDecodedSet(items), nil
+}
+
+// adjustDecodedValue converts a value of the given key decoded from JSON into its governance item type.
+func adjustDecodedValue(key string, value interface{}) interface{} {
+	return adjustDecodedSet(map[string]interface{}{key: value})[key]
+}
+
+// writeVoteRecord stores the vote of the given header.
+func (gov *Governance) writeVoteRecord(header *types.Header, vote *GovernanceVote, accepted bool) {
+	if gov.db == nil {
+		return
+	}
+	record := &VoteRecord{
+		BlockNumber: header.Number.Uint64(),
+		BlockHash:   header.Hash(),
+		Validator:   vote.Validator,
+		Key:         vote.Key,
+		Value:       vote.Value,
+		Accepted:    accepted,
+	}
+	b, err := json.Marshal(record)
+	if err != nil {
+		logger.Error("Failed to marshal a governance vote record", "number", record.BlockNumber, "err", err)
+		return
+	}
+	if err := gov.db.WriteGovernanceVoteRecord(record.BlockNumber, b); err != nil {
+		logger.Error("Failed to write a governance vote record", "number", record.BlockNumber, "err", err)
+	}
+}
+
+// WriteEpochRecord stores the tally and the governance changes at the given epoch block.
+// Nothing is stored for an epoch without any vote or change.
+func (gov *Governance) WriteEpochRecord(number uint64, epoch uint64, governance []byte, tally []GovernanceTallyItem) {
+	if gov.db == nil {
+		return
+	}
+	record := &EpochRecord{
+		BlockNumber:    number,
+		Tally:          tally,
+		EffectiveBlock: number + epoch,
+	}
+	if len(governance) > 0 {
+		changes, err := decodeGovernanceChanges(governance)
+		if err != nil {
+			logger.Error("Failed to decode governance changes", "number", number, "err", err)
+		} else {
+			record.Changes = changes
+			record.Previous = make(map[string]interface{})
+			if _, previous, err := gov.ReadGovernance(number); err == nil {
+				for k := range changes {
+					if v, ok := previous[k]; ok {
+						record.Previous[k] = v
+					}
+				}
+			}
+		}
+	}
+	if len(record.Tally) == 0 && len(record.Changes) == 0 {
+		return
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		logger.Error("Failed to marshal a governance epoch record", "number", number, "err", err)
+		return
+	}
+	if err := gov.db.WriteGovernanceEpochRecord(number, b); err != nil {
+		logger.Error("Failed to write a governance epoch record", "number", number, "err", err)
+	}
+}
+
+// historyEnd returns the exclusive end of the block range which ends at the given block.
+func historyEnd(to uint64) uint64 {
+	if to == math.MaxUint64 {
+		return to
+	}
+	return to + 1
+}
+
+// VoteHistory returns the votes recorded in the blocks from `from` to `to`, both inclusive.
+// The votes are filtered by the given key and voter unless the key is empty or the voter is nil.
+func (gov *Governance) VoteHistory(from, to uint64, key string, voter *common.Address) []*VoteRecord {
+	key = gov.getKey(key)
+	records := make([]*VoteRecord, 0)
+	for _, b := range gov.db.ReadGovernanceVoteRecords(from, historyEnd(to)) {
+		record := new(VoteRecord)
+		if err := json.Unmarshal(b, record); err != nil {
+			logger.Error("Failed to unmarshal a governance vote record", "err", err)
+			continue
+		}
+		if (key != "" && record.Key != key) || (voter != nil && record.Validator != *voter) {
+			continue
+		}
+		record.Value = adjustDecodedValue(record.Key, record.Value)
+		records = append(records, record)
+	}
+	return records
+}
+
+// EpochHistory returns the tallies and the changes recorded at the epoch blocks from `from` to `to`,
+// both inclusive. Unless the given key is empty, only the epochs which tallied or changed the key are returned.
+func (gov *Governance) EpochHistory(from, to uint64, key string) []*EpochRecord {
+	key = gov.getKey(key)
+	records := make([]*EpochRecord, 0)
+	for _, b := range gov.db.ReadGovernanceEpochRecords(from, historyEnd(to)) {
+		record := new(EpochRecord)
+		if err := json.Unmarshal(b, record); err != nil {
+			logger.Error("Failed to unmarshal a governance epoch record", "err", err)
+			continue
+		}
+		if key != "" && !record.hasKey(key) {
+			continue
+		}
+		for i := range record.Tally {
+			record.Tally[i].Value = adjustDecodedValue(record.Tally[i].Key, record.Tally[i].Value)
+		}
+		record.Previous = adjustDecodedSet(record.Previous)
+		record.Changes = adjustDecodedSet(record.Changes)
+		records = append(records, record)
+	}
+	return records
+}
+
+func (r *EpochRecord) hasKey(key string) bool {
+	if _, ok := r.Changes[key]; ok {
+		return true
+	}
+	for _, item := range r.Tally {
+		if item.Key == key {
+			return true
+		}
+	}
+	return false
+}
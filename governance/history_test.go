@@ -0,0 +1,99 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/istanbul/validator"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGovernance_VoteHistory(t *testing.T) {
+	council := getTestCouncil()
+	var valSet istanbul.ValidatorSet = validator.NewWeightedCouncil(council, getTestRewards(), getTestVotingPowers(len(council)), nil, istanbul.WeightedRandom, 21, 0, 0, nil)
+	gov := getGovernance()
+	self := council[len(council)-1]
+	gov.nodeAddress.Store(self)
+
+	votes := make([]GovernanceVote, 0)
+	tally := make([]GovernanceTallyItem, 0)
+
+	castVote := func(number int64, proposer int, key string, value interface{}) {
+		header := &types.Header{Number: big.NewInt(number), BlockScore: big.NewInt(1)}
+		gov.AddVote(key, value)
+		header.Vote = gov.GetEncodedVote(council[proposer], uint64(number))
+		valSet, votes, tally = gov.HandleGovernanceVote(valSet, votes, tally, header, council[proposer], self)
+		gov.voteMap.Clear()
+	}
+	castVote(1, 0, "governance.unitprice", uint64(22000))
+	castVote(2, 1, "kip71.gastarget", uint64(20000000))
+	castVote(3, 0, "reward.ratio", "40/40/20")
+
+	// An invalid vote is recorded but not accepted
+	header := &types.Header{Number: big.NewInt(4), BlockScore: big.NewInt(1)}
+	header.Vote, _ = rlp.EncodeToBytes(&GovernanceVote{Validator: council[2], Key: "reward.ratio", Value: []byte("40/40")})
+	gov.HandleGovernanceVote(valSet, votes, tally, header, council[2], self)
+
+	records := gov.VoteHistory(0, 10, "", nil)
+	if assert.Len(t, records, 4) {
+		assert.Equal(t, uint64(1), records[0].BlockNumber)
+		assert.Equal(t, council[0], records[0].Validator)
+		assert.Equal(t, uint64(22000), records[0].Value)
+		assert.True(t, records[0].Accepted)
+		assert.Equal(t, "40/40/20", records[2].Value)
+		assert.False(t, records[3].Accepted)
+	}
+
+	assert.Len(t, gov.VoteHistory(2, 3, "", nil), 2)
+	assert.Len(t, gov.VoteHistory(0, 10, "reward.ratio", nil), 2)
+	assert.Len(t, gov.VoteHistory(0, 10, "", &council[0]), 2)
+	assert.Len(t, gov.VoteHistory(0, 10, "Reward.Ratio", &council[0]), 1)
+	assert.Empty(t, gov.VoteHistory(5, 10, "", nil))
+}
+
+func TestGovernance_EpochHistory(t *testing.T) {
+	gov := getGovernance()
+	epoch := gov.Epoch()
+
+	changes, _ := json.Marshal(map[string]interface{}{"kip71.gastarget": uint64(20000000)})
+	encoded, _ := rlp.EncodeToBytes(changes)
+	tally := []GovernanceTallyItem{{Key: "kip71.gastarget", Value: uint64(20000000), Votes: 2000}}
+
+	gov.WriteEpochRecord(epoch, epoch, encoded, tally)
+	gov.WriteEpochRecord(2*epoch, epoch, nil, nil) // nothing to record
+	gov.WriteEpochRecord(3*epoch, epoch, nil, []GovernanceTallyItem{{Key: "reward.ratio", Value: "40/40/20", Votes: 1000}})
+
+	records := gov.EpochHistory(0, 3*epoch, "")
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, epoch, records[0].BlockNumber)
+		assert.Equal(t, 2*epoch, records[0].EffectiveBlock)
+		assert.Equal(t, tally, records[0].Tally)
+		assert.Equal(t, uint64(20000000), records[0].Changes["kip71.gastarget"])
+		assert.Equal(t, 3*epoch, records[1].BlockNumber)
+		assert.Empty(t, records[1].Changes)
+	}
+
+	assert.Len(t, gov.EpochHistory(0, 3*epoch, "kip71.gastarget"), 1)
+	assert.Len(t, gov.EpochHistory(0, 3*epoch, "reward.ratio"), 1)
+	assert.Len(t, gov.EpochHistory(epoch+1, 3*epoch-1, ""), 0)
+}
@@ -149,6 +149,18 @@ type FilterQuery struct {
 	// {{A}}, {B}}        matches topic A in first position, B in second position
 	// {{A, B}}, {C, D}}  matches topic (A OR B) in first position, (C OR D) in second position
 	Topics [][]common.Hash
+
+	// Cursor and Limit page through the matching logs. Only the logs after Cursor are
+	// returned, and at most Limit logs are returned if Limit is not zero.
+	Cursor *LogCursor
+	Limit  int
+}
+
+// LogCursor is the position of a log in the blockchain.
+// The position of the last log of a page is the cursor of the next page.
+type LogCursor struct {
+	BlockNumber uint64
+	Index       uint
 }
 
 // LogFilterer provides access to contract log events using a one-off query or continuous
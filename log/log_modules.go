@@ -122,6 +122,8 @@ const (
 	CMDKSEN
 	ChainDataFetcher
 	KAS
+	BlockchainSnapshot
+	NetworksGraphQL
 
 	// ModuleNameLen should be placed at the end of the list.
 	ModuleNameLen
@@ -195,4 +197,6 @@ var moduleNames = [ModuleNameLen]string{
 	"cmd/ksen",
 	"datasync/chaindatafetcher",
 	"kas",
+	"blockchain/snapshot",
+	"networks/graphql",
 }
@@ -0,0 +1,30 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package graphql provides a GraphQL interface to Klaytn chain data.
+
+Only the fields requested by a query are resolved, through the api.Backend
+interface, so that a single query can replace many JSON-RPC round trips.
+
+Source files
+
+Each file provides the following features
+ - graphql.go : resolvers of blocks, transactions, receipts, logs, accounts and account keys.
+ - schema.go : the GraphQL schema served by the resolvers.
+ - service.go : node service serving GraphQL queries over HTTP.
+*/
+package graphql
@@ -0,0 +1,233 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// query posts the GraphQL query to the handler and decodes the data of the response.
+func query(t *testing.T, handler http.Handler, q string, out interface{}) {
+	body, _ := json.Marshal(map[string]string{"query": q})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res struct {
+		Data   json.RawMessage
+		Errors []struct{ Message string }
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Empty(t, res.Errors)
+	require.NoError(t, json.Unmarshal(res.Data, out))
+}
+
+func TestGraphQLBlock(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	backend := mock_api.NewMockBackend(mockCtrl)
+	blockchain.InitDeriveSha(types.ImplDeriveShaOriginal)
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x1000")
+	signer := types.NewEIP155Signer(big.NewInt(1))
+
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(1),
+		types.TxValueKeyTo:       to,
+		types.TxValueKeyAmount:   big.NewInt(100),
+		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasPrice: big.NewInt(25000000000),
+		types.TxValueKeyFrom:     from,
+		types.TxValueKeyFeePayer: from,
+	})
+	require.NoError(t, err)
+	require.NoError(t, tx.SignWithKeys(signer, []*ecdsa.PrivateKey{key}))
+	require.NoError(t, tx.SignFeePayerWithKeys(signer, []*ecdsa.PrivateKey{key}))
+
+	topic := common.HexToHash("0x01")
+	log := &types.Log{Address: to, Topics: []common.Hash{topic}, Data: []byte{1}, BlockNumber: 7, TxHash: tx.Hash()}
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{log}, TxHash: tx.Hash(), GasUsed: 31000}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+	header := &types.Header{Number: big.NewInt(7), Time: big.NewInt(1000), BlockScore: big.NewInt(1), Bloom: receipt.Bloom}
+	block := types.NewBlock(header, []*types.Transaction{tx}, nil)
+
+	backend.EXPECT().HeaderByNumber(gomock.Any(), rpc.BlockNumber(7)).Return(block.Header(), nil).AnyTimes()
+	backend.EXPECT().BlockByNumber(gomock.Any(), rpc.BlockNumber(7)).Return(block, nil).AnyTimes()
+	backend.EXPECT().GetBlock(gomock.Any(), block.Hash()).Return(block, nil).AnyTimes()
+	backend.EXPECT().GetBlockReceipts(gomock.Any(), block.Hash()).Return(types.Receipts{receipt}).AnyTimes()
+
+	handler, err := newHandler(backend, Config{})
+	require.NoError(t, err)
+
+	var res struct {
+		Block struct {
+			Hash         common.Hash
+			Timestamp    hexutil.Uint64
+			Transactions []struct {
+				TypeName           string
+				From               struct{ Address common.Address }
+				FeePayer           struct{ Address common.Address }
+				FeePayerSignatures []struct{ R hexutil.Big }
+				Status             hexutil.Uint64
+				GasUsed            hexutil.Uint64
+			}
+			Logs []struct {
+				Topics      []common.Hash
+				Transaction struct{ Hash common.Hash }
+			}
+		}
+	}
+	query(t, handler, `{ block(number: 7) {
+		hash timestamp
+		transactions { typeName from { address } feePayer { address } feePayerSignatures { r } status gasUsed }
+		logs(filter: { topics: [["`+topic.Hex()+`"]] }) { topics transaction { hash } }
+	} }`, &res)
+
+	assert.Equal(t, block.Hash(), res.Block.Hash)
+	assert.Equal(t, hexutil.Uint64(1000), res.Block.Timestamp)
+	if assert.Len(t, res.Block.Transactions, 1) {
+		rtx := res.Block.Transactions[0]
+		assert.Equal(t, "TxTypeFeeDelegatedValueTransfer", rtx.TypeName)
+		assert.Equal(t, from, rtx.From.Address)
+		assert.Equal(t, from, rtx.FeePayer.Address)
+		assert.Len(t, rtx.FeePayerSignatures, 1)
+		assert.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), rtx.Status)
+		assert.Equal(t, hexutil.Uint64(31000), rtx.GasUsed)
+	}
+	if assert.Len(t, res.Block.Logs, 1) {
+		assert.Equal(t, []common.Hash{topic}, res.Block.Logs[0].Topics)
+		assert.Equal(t, tx.Hash(), res.Block.Logs[0].Transaction.Hash)
+	}
+
+	// Logs not matching the filter are skipped.
+	query(t, handler, `{ block(number: 7) { logs(filter: { addresses: ["`+from.Hex()+`"] }) { data } } }`, &res)
+	assert.Empty(t, res.Block.Logs)
+}
+
+func TestGraphQLBlockNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	backend := mock_api.NewMockBackend(mockCtrl)
+
+	backend.EXPECT().HeaderByNumber(gomock.Any(), rpc.BlockNumber(8)).Return(nil, nil)
+
+	handler, err := newHandler(backend, Config{})
+	require.NoError(t, err)
+
+	var res struct{ Block *struct{ Hash common.Hash } }
+	query(t, handler, `{ block(number: 8) { hash } }`, &res)
+	assert.Nil(t, res.Block)
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	backend := mock_api.NewMockBackend(mockCtrl)
+
+	handler, err := newHandler(backend, Config{MaxDepth: 3, MaxParallelism: 1, Timeout: time.Minute})
+	require.NoError(t, err)
+
+	// A query deeper than the limit is rejected without being resolved.
+	body, _ := json.Marshal(map[string]string{"query": `{ block(number: 8) { parent { parent { parent { hash } } } } }`})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	var res struct{ Errors []struct{ Message string } }
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.NotEmpty(t, res.Errors)
+
+	// The resolvers get the context with the timeout.
+	backend.EXPECT().HeaderByNumber(gomock.Any(), rpc.BlockNumber(8)).DoAndReturn(
+		func(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+			return nil, nil
+		})
+	var block struct{ Block *struct{ Hash common.Hash } }
+	query(t, handler, `{ block(number: 8) { hash } }`, &block)
+	assert.Nil(t, block.Block)
+}
+
+// queryErrors posts the GraphQL query to the handler and returns the error messages of the response.
+func queryErrors(t *testing.T, handler http.Handler, q string) []string {
+	body, _ := json.Marshal(map[string]string{"query": q})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+
+	var res struct{ Errors []struct{ Message string } }
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	var msgs []string
+	for _, err := range res.Errors {
+		msgs = append(msgs, err.Message)
+	}
+	return msgs
+}
+
+func TestGraphQLRangeLimits(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	backend := mock_api.NewMockBackend(mockCtrl)
+
+	head := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2)})
+	backend.EXPECT().CurrentBlock().Return(head).AnyTimes()
+
+	handler, err := newHandler(backend, Config{MaxBlockSpan: 3, MaxLogs: 2})
+	require.NoError(t, err)
+
+	// A block range wider than the limit is rejected without being resolved.
+	assert.NotEmpty(t, queryErrors(t, handler, `{ blocks(from: 0, to: 3) { number } }`))
+	assert.NotEmpty(t, queryErrors(t, handler, `{ logs(filter: { fromBlock: 0, toBlock: 3 }) { data } }`))
+
+	for num := int64(0); num < 3; num++ {
+		header := &types.Header{Number: big.NewInt(num), Time: big.NewInt(1000), BlockScore: big.NewInt(1)}
+		receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{{BlockNumber: uint64(num)}}}
+		backend.EXPECT().HeaderByNumber(gomock.Any(), rpc.BlockNumber(num)).Return(header, nil).AnyTimes()
+		backend.EXPECT().GetBlockReceipts(gomock.Any(), header.Hash()).Return(types.Receipts{receipt}).AnyTimes()
+	}
+
+	var blocks struct{ Blocks []struct{ Number hexutil.Uint64 } }
+	query(t, handler, `{ blocks(from: 0, to: 2) { number } }`, &blocks)
+	assert.Len(t, blocks.Blocks, 3)
+
+	// A logs query returning more logs than the limit is rejected.
+	var logs struct{ Logs []struct{ Data hexutil.Bytes } }
+	query(t, handler, `{ logs(filter: { fromBlock: 0, toBlock: 1 }) { data } }`, &logs)
+	assert.Len(t, logs.Logs, 2)
+	assert.NotEmpty(t, queryErrors(t, handler, `{ logs(filter: { fromBlock: 0, toBlock: 2 }) { data } }`))
+}
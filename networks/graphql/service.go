@@ -0,0 +1,142 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+var logger = log.NewModuleLogger(log.NetworksGraphQL)
+
+// Config is the limits of the queries served by a GraphQL service.
+type Config struct {
+	MaxDepth       int           // Maximum depth of a query (0 = no limit)
+	MaxParallelism int           // Maximum number of resolvers running in parallel for a query
+	Timeout        time.Duration // Maximum duration of a query (0 = no limit)
+	MaxBlockSpan   uint64        // Maximum number of blocks queried by blocks and logs (0 = no limit)
+	MaxLogs        int           // Maximum number of logs returned by logs (0 = no limit)
+}
+
+// Service encapsulates a GraphQL service.
+type Service struct {
+	endpoint string           // The host:port endpoint for this service.
+	cors     []string         // Allowed CORS domains
+	vhosts   []string         // Recognised vhosts
+	timeouts rpc.HTTPTimeouts // Timeout settings for HTTP requests.
+	backend  api.Backend      // The backend that queries will operate on.
+	handler  http.Handler     // The `http.Handler` used to answer queries.
+	listener net.Listener     // The listening socket.
+}
+
+// New constructs a new GraphQL service instance.
+func New(backend api.Backend, endpoint string, cors, vhosts []string, timeouts rpc.HTTPTimeouts, config Config) (*Service, error) {
+	handler, err := newHandler(backend, config)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		endpoint: endpoint,
+		cors:     cors,
+		vhosts:   vhosts,
+		timeouts: timeouts,
+		backend:  backend,
+		handler:  handler,
+	}, nil
+}
+
+// newHandler returns a new `http.Handler` that will answer GraphQL queries
+// posted to the /graphql endpoint within the limits of the given config.
+func newHandler(backend api.Backend, config Config) (http.Handler, error) {
+	opts := []graphql.SchemaOpt{graphql.MaxDepth(config.MaxDepth)}
+	if config.MaxParallelism > 0 {
+		opts = append(opts, graphql.MaxParallelism(config.MaxParallelism))
+	}
+	s, err := graphql.ParseSchema(schema, &Resolver{backend: backend, config: config}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var h http.Handler = &relay.Handler{Schema: s}
+	if config.Timeout > 0 {
+		h = timeoutHandler(h, config.Timeout)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", h)
+	mux.Handle("/graphql/", h)
+	return mux, nil
+}
+
+// timeoutHandler returns a handler passing a request context with the given timeout to the resolvers.
+func timeoutHandler(h http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Protocols returns the list of protocols exported by this service.
+func (s *Service) Protocols() []p2p.Protocol { return nil }
+
+// APIs returns the list of APIs exported by this service.
+func (s *Service) APIs() []rpc.API { return nil }
+
+// Start is called after all services have been constructed and the networking
+// layer was also initialized to spawn any goroutines required by the service.
+func (s *Service) Start(server p2p.Server) error {
+	var err error
+	if s.listener, err = net.Listen("tcp", s.endpoint); err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:      rpc.NewHTTPHandlerStack(s.handler, s.cors, s.vhosts),
+		ReadTimeout:  s.timeouts.ReadTimeout,
+		WriteTimeout: s.timeouts.WriteTimeout,
+		IdleTimeout:  s.timeouts.IdleTimeout,
+	}
+	go srv.Serve(s.listener)
+	logger.Info("GraphQL endpoint opened", "url", fmt.Sprintf("http://%s", s.endpoint))
+	return nil
+}
+
+// Stop terminates all goroutines belonging to the service, blocking until they
+// are all terminated.
+func (s *Service) Stop() error {
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+		logger.Info("GraphQL endpoint closed", "url", fmt.Sprintf("http://%s", s.endpoint))
+	}
+	return nil
+}
+
+// Components returns nil as the service does not share any component.
+func (s *Service) Components() []interface{} { return nil }
+
+// SetComponents does nothing as the service does not use any shared component.
+func (s *Service) SetComponents(components []interface{}) {}
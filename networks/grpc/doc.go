@@ -25,6 +25,7 @@ Source files
 Each file provides the following features
  - gClient.go : gRPC client implementation.
  - gServer.go : gRPC server implementation.
+ - gKlayServer.go : typed gRPC server implementation of the klay namespace on top of api.Backend.
  - klaytn.proto : Define a interface and messages to use in gRPC server and clients.
  - klaytn.pb.go : the generated Go file from klaytn.proto by protoc-gen-go.
 */
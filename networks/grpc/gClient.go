@@ -74,6 +74,18 @@ func (gkc *gKlaytnClient) makeKlaytnClient(timeout time.Duration) (KlaytnNodeCli
 	return NewKlaytnNodeClient(gkc.conn), nil
 }
 
+func (gkc *gKlaytnClient) makeKlayAPIClient(timeout time.Duration) (KlayAPIClient, error) {
+	gkc.ctx, gkc.cancel = context.WithTimeout(context.Background(), timeout)
+	conn, err := grpc.DialContext(gkc.ctx, gkc.addr, grpc.WithInsecure())
+	if err != nil {
+		logger.Error("failed to dial server", "err", err)
+		return nil, err
+	}
+	gkc.conn = conn
+
+	return NewKlayAPIClient(gkc.conn), nil
+}
+
 func (gkc *gKlaytnClient) makeRPCRequest(service string, method string, args []interface{}) (*RPCRequest, error) {
 	payload, err := json.Marshal(args)
 	if err != nil {
@@ -0,0 +1,511 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/bloombits"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/filters"
+	"github.com/klaytn/klaytn/rlp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chainEventChanSize is the size of channels listening to chain events.
+const chainEventChanSize = 10
+
+var (
+	errBlockNotFound       = status.Error(codes.NotFound, "block not found")
+	errTransactionNotFound = status.Error(codes.NotFound, "transaction not found")
+	errAccountNotFound     = status.Error(codes.NotFound, "account not found")
+	errLogsRangeReversed   = status.Error(codes.InvalidArgument, "fromBlock is after toBlock")
+)
+
+// Backend is the api.Backend serving the typed KlayAPI service,
+// which also serves the log filters.
+type Backend interface {
+	api.Backend
+
+	GetLogs(ctx context.Context, blockHash common.Hash) ([][]*types.Log, error)
+	SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLogsEvent) event.Subscription
+	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
+	BloomStatus() (uint64, uint64)
+	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
+}
+
+// klayServer is an implementation of KlayAPIServer on top of Backend.
+type klayServer struct {
+	b          Backend
+	blockchain *api.PublicBlockChainAPI
+	txpool     *api.PublicTransactionPoolAPI
+}
+
+func newKlayServer(b Backend) *klayServer {
+	return &klayServer{
+		b:          b,
+		blockchain: api.NewPublicBlockChainAPI(b),
+		txpool:     api.NewPublicTransactionPoolAPI(b, new(api.AddrLocker)),
+	}
+}
+
+// BlockNumber returns the number of the latest block.
+func (ks *klayServer) BlockNumber(ctx context.Context, _ *Empty) (*BlockNumberResponse, error) {
+	header, err := ks.b.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockNumberResponse{Number: header.Number.Uint64()}, nil
+}
+
+// GetBlock returns the selected block.
+func (ks *klayServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*Block, error) {
+	block, err := ks.block(ctx, req.Block)
+	if err != nil {
+		return nil, err
+	}
+	return newBlock(block, ks.b.GetTd(block.Hash()), req.FullTransactions), nil
+}
+
+// GetTransaction returns a mined or pending transaction.
+func (ks *klayServer) GetTransaction(ctx context.Context, req *TransactionHash) (*Transaction, error) {
+	hash := common.BytesToHash(req.Hash)
+	if tx, blockHash, blockNumber, index := ks.b.ChainDB().ReadTxAndLookupInfo(hash); tx != nil {
+		return newTransaction(tx, blockHash, blockNumber, index), nil
+	}
+	if tx := ks.b.GetPoolTransaction(hash); tx != nil {
+		return newTransaction(tx, common.Hash{}, 0, 0), nil
+	}
+	return nil, errTransactionNotFound
+}
+
+// GetTransactionReceipt returns the receipt of a mined transaction.
+func (ks *klayServer) GetTransactionReceipt(ctx context.Context, req *TransactionHash) (*Receipt, error) {
+	tx, blockHash, blockNumber, index, receipt := ks.b.GetTxLookupInfoAndReceipt(ctx, common.BytesToHash(req.Hash))
+	if tx == nil || receipt == nil {
+		return nil, errTransactionNotFound
+	}
+	return newReceipt(tx, blockHash, blockNumber, index, receipt), nil
+}
+
+// SendRawTransaction submits an RLP encoded signed transaction to the transaction pool.
+func (ks *klayServer) SendRawTransaction(ctx context.Context, req *SendRawTransactionRequest) (*TransactionHash, error) {
+	hash, err := ks.txpool.SendRawTransaction(ctx, req.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionHash{Hash: hash.Bytes()}, nil
+}
+
+// GetLogs returns the logs matching the filter in the block of the block hash
+// or in the block range, within the limits of the getLogs APIs.
+func (ks *klayServer) GetLogs(ctx context.Context, req *LogFilter) (*Logs, error) {
+	ctx, cancel := filters.WithGetLogsLimits(ctx)
+	defer cancel()
+
+	addresses, topics := logCriteria(req)
+
+	var filter *filters.Filter
+	if len(req.BlockHash) > 0 {
+		header, err := ks.header(ctx, &BlockSelector{Selector: &BlockSelector_Hash{Hash: req.BlockHash}})
+		if err != nil {
+			return nil, err
+		}
+		filter = filters.NewBlockFilter(ks.b, header.Hash(), addresses, topics)
+	} else {
+		from, err := ks.header(ctx, req.FromBlock)
+		if err != nil {
+			return nil, err
+		}
+		to, err := ks.header(ctx, req.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+		if from.Number.Cmp(to.Number) > 0 {
+			return nil, errLogsRangeReversed
+		}
+		filter = filters.NewRangeFilter(ks.b, from.Number.Int64(), to.Number.Int64(), addresses, topics)
+	}
+
+	found, err := filter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]*Log, len(found))
+	for i, log := range found {
+		logs[i] = newLog(log)
+	}
+	return &Logs{Logs: logs}, nil
+}
+
+// GetAccountKey returns the account key of the address at the selected block.
+func (ks *klayServer) GetAccountKey(ctx context.Context, req *AccountKeyRequest) (*AccountKey, error) {
+	blockNr, err := ks.blockNumber(ctx, req.Block)
+	if err != nil {
+		return nil, err
+	}
+	state, _, err := ks.b.StateAndHeaderByNumber(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, errBlockNotFound
+	}
+	address := common.BytesToAddress(req.Address)
+	if !state.Exist(address) {
+		return nil, errAccountNotFound
+	}
+	key := state.GetKey(address)
+	return newAccountKey(key), state.Error()
+}
+
+// Call executes a message call at the selected block without creating a transaction.
+func (ks *klayServer) Call(ctx context.Context, req *CallRequest) (*CallResponse, error) {
+	blockNr, err := ks.blockNumber(ctx, req.Block)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ks.blockchain.Call(ctx, newCallArgs(req), blockNr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CallResponse{Data: data}, nil
+}
+
+// EstimateGas returns the gas needed to execute the message call at the selected block.
+func (ks *klayServer) EstimateGas(ctx context.Context, req *CallRequest) (*EstimateGasResponse, error) {
+	blockNr, err := ks.blockNumber(ctx, req.Block)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := ks.blockchain.EstimateGas(ctx, newCallArgs(req), &blockNr, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EstimateGasResponse{Gas: uint64(gas)}, nil
+}
+
+// SubscribeNewHeads streams the header of every new chain head until the client goes away.
+func (ks *klayServer) SubscribeNewHeads(_ *Empty, stream KlayAPI_SubscribeNewHeadsServer) error {
+	headCh := make(chan blockchain.ChainHeadEvent, chainEventChanSize)
+	sub := ks.b.SubscribeChainHeadEvent(headCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-headCh:
+			if err := stream.Send(newHeader(ev.Block.Header())); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// SubscribeLogs streams the logs of new blocks matching the addresses and topics of the filter.
+func (ks *klayServer) SubscribeLogs(req *LogFilter, stream KlayAPI_SubscribeLogsServer) error {
+	addresses, topics := logCriteria(req)
+
+	chainCh := make(chan blockchain.ChainEvent, chainEventChanSize)
+	sub := ks.b.SubscribeChainEvent(chainCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-chainCh:
+			for _, log := range filters.FilterLogs(ev.Logs, addresses, topics) {
+				if err := stream.Send(newLog(log)); err != nil {
+					return err
+				}
+			}
+		case err := <-sub.Err():
+			return err
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// blockNumber converts the block selector into an rpc.BlockNumber.
+func (ks *klayServer) blockNumber(ctx context.Context, sel *BlockSelector) (rpc.BlockNumber, error) {
+	switch s := sel.GetSelector().(type) {
+	case *BlockSelector_Number:
+		return rpc.BlockNumber(s.Number), nil
+	case *BlockSelector_Hash:
+		block, err := ks.b.GetBlock(ctx, common.BytesToHash(s.Hash))
+		if err != nil {
+			return 0, err
+		}
+		if block == nil {
+			return 0, errBlockNotFound
+		}
+		return rpc.BlockNumber(block.NumberU64()), nil
+	case *BlockSelector_Tag:
+		switch s.Tag {
+		case BlockTag_PENDING:
+			return rpc.PendingBlockNumber, nil
+		case BlockTag_EARLIEST:
+			return rpc.EarliestBlockNumber, nil
+		}
+	}
+	return rpc.LatestBlockNumber, nil
+}
+
+// block returns the block designated by the selector.
+func (ks *klayServer) block(ctx context.Context, sel *BlockSelector) (*types.Block, error) {
+	var (
+		block *types.Block
+		err   error
+	)
+	if s, ok := sel.GetSelector().(*BlockSelector_Hash); ok {
+		block, err = ks.b.GetBlock(ctx, common.BytesToHash(s.Hash))
+	} else {
+		blockNr, _ := ks.blockNumber(ctx, sel)
+		block, err = ks.b.BlockByNumber(ctx, blockNr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	return block, nil
+}
+
+// header returns the header of the block designated by the selector.
+func (ks *klayServer) header(ctx context.Context, sel *BlockSelector) (*types.Header, error) {
+	if _, ok := sel.GetSelector().(*BlockSelector_Hash); ok {
+		block, err := ks.block(ctx, sel)
+		if err != nil {
+			return nil, err
+		}
+		return block.Header(), nil
+	}
+	blockNr, _ := ks.blockNumber(ctx, sel)
+	header, err := ks.b.HeaderByNumber(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errBlockNotFound
+	}
+	return header, nil
+}
+
+// logCriteria returns the addresses and topics of the filter.
+func logCriteria(filter *LogFilter) ([]common.Address, [][]common.Hash) {
+	addresses := make([]common.Address, len(filter.Addresses))
+	for i, addr := range filter.Addresses {
+		addresses[i] = common.BytesToAddress(addr)
+	}
+	topics := make([][]common.Hash, len(filter.Topics))
+	for i, sub := range filter.Topics {
+		for _, hash := range sub.Hashes {
+			topics[i] = append(topics[i], common.BytesToHash(hash))
+		}
+	}
+	return addresses, topics
+}
+
+func newCallArgs(req *CallRequest) api.CallArgs {
+	args := api.CallArgs{
+		From:     common.BytesToAddress(req.From),
+		Gas:      hexutil.Uint64(req.Gas),
+		GasPrice: hexutil.Big(*new(big.Int).SetBytes(req.GasPrice)),
+		Value:    hexutil.Big(*new(big.Int).SetBytes(req.Value)),
+		Data:     req.Data,
+	}
+	if len(req.To) > 0 {
+		to := common.BytesToAddress(req.To)
+		args.To = &to
+	}
+	return args
+}
+
+func newHeader(head *types.Header) *Header {
+	return &Header{
+		Hash:             head.Hash().Bytes(),
+		Number:           head.Number.Uint64(),
+		ParentHash:       head.ParentHash.Bytes(),
+		Rewardbase:       head.Rewardbase.Bytes(),
+		StateRoot:        head.Root.Bytes(),
+		TransactionsRoot: head.TxHash.Bytes(),
+		ReceiptsRoot:     head.ReceiptHash.Bytes(),
+		LogsBloom:        head.Bloom.Bytes(),
+		BlockScore:       head.BlockScore.Bytes(),
+		GasUsed:          head.GasUsed,
+		Timestamp:        head.Time.Uint64(),
+		TimestampFos:     uint32(head.TimeFoS),
+		ExtraData:        head.Extra,
+		GovernanceData:   head.Governance,
+		VoteData:         head.Vote,
+	}
+}
+
+func newBlock(block *types.Block, td *big.Int, fullTxs bool) *Block {
+	b := &Block{
+		Header: newHeader(block.Header()),
+		Size:   uint64(block.Size()),
+	}
+	if td != nil {
+		b.TotalBlockScore = td.Bytes()
+	}
+	for i, tx := range block.Transactions() {
+		if fullTxs {
+			b.Transactions = append(b.Transactions, newTransaction(tx, block.Hash(), block.NumberU64(), uint64(i)))
+		} else {
+			b.TransactionHashes = append(b.TransactionHashes, tx.Hash().Bytes())
+		}
+	}
+	return b
+}
+
+// sender returns the sender of the transaction like newRPCTransaction of the api package.
+func sender(tx *types.Transaction) common.Address {
+	var from common.Address
+	if tx.IsLegacyTransaction() {
+		signer := types.NewEIP155Signer(tx.ChainId())
+		from, _ = types.Sender(signer, tx)
+	} else {
+		from, _ = tx.From()
+	}
+	return from
+}
+
+func newSignatures(sigs types.TxSignatures) []*Signature {
+	ret := make([]*Signature, len(sigs))
+	for i, sig := range sigs {
+		ret[i] = &Signature{V: sig.V.Bytes(), R: sig.R.Bytes(), S: sig.S.Bytes()}
+	}
+	return ret
+}
+
+func newTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64) *Transaction {
+	raw, _ := rlp.EncodeToBytes(tx)
+	t := &Transaction{
+		Hash:       tx.Hash().Bytes(),
+		Type:       uint32(tx.Type()),
+		TypeName:   tx.Type().String(),
+		From:       sender(tx).Bytes(),
+		Nonce:      tx.Nonce(),
+		Gas:        tx.Gas(),
+		GasPrice:   tx.GasPrice().Bytes(),
+		Value:      tx.Value().Bytes(),
+		Input:      tx.Data(),
+		Signatures: newSignatures(tx.RawSignatureValues()),
+		Raw:        raw,
+	}
+	if to := tx.To(); to != nil {
+		t.To = to.Bytes()
+	}
+	if tx.Type().IsFeeDelegatedTransaction() {
+		if feePayer, err := tx.FeePayer(); err == nil {
+			t.FeePayer = feePayer.Bytes()
+		}
+		if sigs, err := tx.GetFeePayerSignatures(); err == nil {
+			t.FeePayerSignatures = newSignatures(sigs)
+		}
+		if ratio, ok := tx.FeeRatio(); ok {
+			t.FeeRatio = uint32(ratio)
+		}
+	}
+	if blockHash != (common.Hash{}) {
+		t.BlockHash = blockHash.Bytes()
+		t.BlockNumber = blockNumber
+		t.TransactionIndex = index
+	}
+	return t
+}
+
+func newLog(log *types.Log) *Log {
+	l := &Log{
+		Address:          log.Address.Bytes(),
+		Data:             log.Data,
+		BlockNumber:      log.BlockNumber,
+		TransactionHash:  log.TxHash.Bytes(),
+		TransactionIndex: uint64(log.TxIndex),
+		BlockHash:        log.BlockHash.Bytes(),
+		LogIndex:         uint64(log.Index),
+		Removed:          log.Removed,
+	}
+	for _, topic := range log.Topics {
+		l.Topics = append(l.Topics, topic.Bytes())
+	}
+	return l
+}
+
+func newReceipt(tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64, receipt *types.Receipt) *Receipt {
+	r := &Receipt{
+		TransactionHash:  tx.Hash().Bytes(),
+		TransactionIndex: index,
+		BlockHash:        blockHash.Bytes(),
+		BlockNumber:      blockNumber,
+		From:             sender(tx).Bytes(),
+		GasUsed:          receipt.GasUsed,
+		Status:           uint32(types.ReceiptStatusSuccessful),
+		LogsBloom:        receipt.Bloom.Bytes(),
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		r.Status = uint32(types.ReceiptStatusFailed)
+		r.TxError = uint32(receipt.Status)
+	}
+	if to := tx.To(); to != nil {
+		r.To = to.Bytes()
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		r.ContractAddress = receipt.ContractAddress.Bytes()
+	}
+	for _, log := range receipt.Logs {
+		r.Logs = append(r.Logs, newLog(log))
+	}
+	return r
+}
+
+func newAccountKey(key accountkey.AccountKey) *AccountKey {
+	k := &AccountKey{Type: AccountKeyType(key.Type())}
+	switch key := key.(type) {
+	case *accountkey.AccountKeyPublic:
+		k.PublicKey = crypto.CompressPubkey((*ecdsa.PublicKey)(key.PublicKeySerializable))
+	case *accountkey.AccountKeyWeightedMultiSig:
+		k.Threshold = uint32(key.Threshold)
+		for _, wk := range key.Keys {
+			k.WeightedKeys = append(k.WeightedKeys, &WeightedPublicKey{
+				Weight:    uint32(wk.Weight),
+				PublicKey: crypto.CompressPubkey((*ecdsa.PublicKey)(wk.Key)),
+			})
+		}
+	case *accountkey.AccountKeyRoleBased:
+		for _, rk := range *key {
+			k.RoleKeys = append(k.RoleKeys, newAccountKey(rk))
+		}
+	}
+	return k
+}
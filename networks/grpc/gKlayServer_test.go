@@ -0,0 +1,280 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package grpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/bloombits"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/filters"
+	mock_filters "github.com/klaytn/klaytn/node/cn/filters/mock"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestKlayAPI(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	backend := &testBackend{MockBackend: mock_api.NewMockBackend(mockCtrl)}
+	blockchain.InitDeriveSha(types.ImplDeriveShaOriginal)
+
+	key, _ := crypto.GenerateKey()
+	signer := types.NewEIP155Signer(big.NewInt(1))
+	to := common.HexToAddress("0x1000")
+	tx, err := types.SignTx(types.NewTransaction(3, to, big.NewInt(5), 21000, big.NewInt(25000000000), nil), signer, key)
+	assert.NoError(t, err)
+
+	header := &types.Header{Number: big.NewInt(7), Time: big.NewInt(1000), BlockScore: big.NewInt(1)}
+	block := types.NewBlock(header, []*types.Transaction{tx}, nil)
+	receipt := &types.Receipt{Status: types.ReceiptStatusErrDepth, TxHash: tx.Hash(), GasUsed: 21000}
+
+	headFeed := new(event.Feed)
+	backend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(header, nil).AnyTimes()
+	backend.EXPECT().BlockByNumber(gomock.Any(), rpc.BlockNumber(7)).Return(block, nil).AnyTimes()
+	backend.EXPECT().BlockByNumber(gomock.Any(), rpc.BlockNumber(8)).Return(nil, nil).AnyTimes()
+	backend.EXPECT().GetTd(block.Hash()).Return(big.NewInt(7)).AnyTimes()
+	backend.EXPECT().GetTxLookupInfoAndReceipt(gomock.Any(), tx.Hash()).Return(tx, block.Hash(), uint64(7), uint64(0), receipt).AnyTimes()
+	backend.EXPECT().SubscribeChainHeadEvent(gomock.Any()).DoAndReturn(func(ch chan<- blockchain.ChainHeadEvent) event.Subscription {
+		return headFeed.Subscribe(ch)
+	}).AnyTimes()
+
+	addr := "127.0.0.1:4001"
+	listener := &Listener{Addr: addr}
+	listener.SetRPCServer(rpc.NewServer())
+	listener.SetAPIBackend(backend)
+	go listener.Start()
+	defer listener.Stop()
+
+	time.Sleep(2 * time.Second)
+
+	kclient, _ := NewgKlaytnClient(addr)
+	defer kclient.Close()
+
+	client, err := kclient.makeKlayAPIClient(timeout)
+	assert.NoError(t, err)
+
+	// BlockNumber
+	number, err := client.BlockNumber(kclient.ctx, &Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), number.Number)
+
+	// GetBlock with transaction hashes and full transactions
+	selector := &BlockSelector{Selector: &BlockSelector_Number{Number: 7}}
+	res, err := client.GetBlock(kclient.ctx, &GetBlockRequest{Block: selector})
+	assert.NoError(t, err)
+	assert.Equal(t, block.Hash().Bytes(), res.Header.Hash)
+	assert.Equal(t, uint64(1000), res.Header.Timestamp)
+	assert.Equal(t, [][]byte{tx.Hash().Bytes()}, res.TransactionHashes)
+	assert.Empty(t, res.Transactions)
+
+	res, err = client.GetBlock(kclient.ctx, &GetBlockRequest{Block: selector, FullTransactions: true})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Transactions, 1) {
+		rtx := res.Transactions[0]
+		assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey).Bytes(), rtx.From)
+		assert.Equal(t, to.Bytes(), rtx.To)
+		assert.Equal(t, uint64(3), rtx.Nonce)
+		assert.Equal(t, big.NewInt(5).Bytes(), rtx.Value)
+		assert.Equal(t, "TxTypeLegacyTransaction", rtx.TypeName)
+		assert.Equal(t, uint64(7), rtx.BlockNumber)
+	}
+
+	_, err = client.GetBlock(kclient.ctx, &GetBlockRequest{Block: &BlockSelector{Selector: &BlockSelector_Number{Number: 8}}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// GetTransactionReceipt
+	rcpt, err := client.GetTransactionReceipt(kclient.ctx, &TransactionHash{Hash: tx.Hash().Bytes()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(types.ReceiptStatusFailed), rcpt.Status)
+	assert.Equal(t, uint32(types.ReceiptStatusErrDepth), rcpt.TxError)
+	assert.Equal(t, block.Hash().Bytes(), rcpt.BlockHash)
+	assert.Equal(t, uint64(21000), rcpt.GasUsed)
+
+	// SubscribeNewHeads
+	stream, err := client.SubscribeNewHeads(kclient.ctx, &Empty{})
+	assert.NoError(t, err)
+	for headFeed.Send(blockchain.ChainHeadEvent{Block: block}) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	head, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, block.Hash().Bytes(), head.Hash)
+	assert.Equal(t, uint64(7), head.Number)
+}
+
+// testBackend serves the api.Backend with the api mock and the log filters with the filters mock.
+type testBackend struct {
+	*mock_api.MockBackend
+	filters *mock_filters.MockBackend
+}
+
+func (b *testBackend) GetLogs(ctx context.Context, blockHash common.Hash) ([][]*types.Log, error) {
+	return b.filters.GetLogs(ctx, blockHash)
+}
+
+func (b *testBackend) SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLogsEvent) event.Subscription {
+	return b.filters.SubscribeRemovedLogsEvent(ch)
+}
+
+func (b *testBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
+	return b.filters.SubscribeLogsEvent(ch)
+}
+
+func (b *testBackend) BloomStatus() (uint64, uint64) {
+	return b.filters.BloomStatus()
+}
+
+func (b *testBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {
+	b.filters.ServiceFilter(ctx, session)
+}
+
+func TestKlayAPI_GetLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	backend := &testBackend{mock_api.NewMockBackend(mockCtrl), mock_filters.NewMockBackend(mockCtrl)}
+
+	var (
+		addr1   = common.HexToAddress("0x1111")
+		addr2   = common.HexToAddress("0x2222")
+		topic1  = common.HexToHash("0x01")
+		topic2  = common.HexToHash("0x02")
+		db      = database.NewMemoryDBManager()
+		headers []*types.Header
+	)
+	for num := uint64(0); num < 3; num++ {
+		logs := []*types.Log{
+			{Address: addr1, Topics: []common.Hash{topic1}, BlockNumber: num, TxHash: common.HexToHash("0x1")},
+			{Address: addr2, Topics: []common.Hash{topic2, topic1}, BlockNumber: num, TxHash: common.HexToHash("0x1"), Index: 1},
+		}
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(num),
+			Time:       big.NewInt(1000),
+			BlockScore: big.NewInt(1),
+			Bloom:      types.CreateBloom(types.Receipts{{Logs: logs}}),
+		}
+		db.WriteHeader(header)
+		headers = append(headers, header)
+
+		backend.EXPECT().HeaderByNumber(gomock.Any(), rpc.BlockNumber(num)).Return(header, nil).AnyTimes()
+		backend.filters.EXPECT().GetLogs(gomock.Any(), header.Hash()).Return([][]*types.Log{logs}, nil).AnyTimes()
+	}
+	backend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(headers[2], nil).AnyTimes()
+	backend.EXPECT().GetBlock(gomock.Any(), headers[1].Hash()).Return(types.NewBlockWithHeader(headers[1]), nil).AnyTimes()
+	backend.EXPECT().ChainDB().Return(db).AnyTimes()
+	backend.filters.EXPECT().BloomStatus().Return(uint64(4096), uint64(0)).AnyTimes()
+
+	addr := "127.0.0.1:4002"
+	listener := &Listener{Addr: addr}
+	listener.SetRPCServer(rpc.NewServer())
+	listener.SetAPIBackend(backend)
+	go listener.Start()
+	defer listener.Stop()
+
+	time.Sleep(2 * time.Second)
+
+	kclient, _ := NewgKlaytnClient(addr)
+	defer kclient.Close()
+
+	client, err := kclient.makeKlayAPIClient(timeout)
+	assert.NoError(t, err)
+
+	selector := func(num uint64) *BlockSelector {
+		return &BlockSelector{Selector: &BlockSelector_Number{Number: num}}
+	}
+
+	// block range
+	res, err := client.GetLogs(kclient.ctx, &LogFilter{FromBlock: selector(0), ToBlock: selector(2), Addresses: [][]byte{addr1.Bytes()}})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Logs, 3) {
+		for i, log := range res.Logs {
+			assert.Equal(t, addr1.Bytes(), log.Address)
+			assert.Equal(t, uint64(i), log.BlockNumber)
+		}
+	}
+
+	// block hash
+	res, err = client.GetLogs(kclient.ctx, &LogFilter{
+		BlockHash: headers[1].Hash().Bytes(),
+		Topics:    []*Topics{{}, {Hashes: [][]byte{topic1.Bytes()}}},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Logs, 1) {
+		assert.Equal(t, addr2.Bytes(), res.Logs[0].Address)
+		assert.Equal(t, uint64(1), res.Logs[0].BlockNumber)
+		assert.Equal(t, uint64(1), res.Logs[0].LogIndex)
+	}
+
+	// reversed block range
+	_, err = client.GetLogs(kclient.ctx, &LogFilter{FromBlock: selector(2), ToBlock: selector(0)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the block span limit of the getLogs APIs
+	defer func(span uint64) { filters.GetLogsMaxBlockSpan = span }(filters.GetLogsMaxBlockSpan)
+	filters.GetLogsMaxBlockSpan = 2
+	_, err = client.GetLogs(kclient.ctx, &LogFilter{FromBlock: selector(0), ToBlock: selector(2)})
+	assert.Error(t, err)
+	_, err = client.GetLogs(kclient.ctx, &LogFilter{FromBlock: selector(1), ToBlock: selector(2)})
+	assert.NoError(t, err)
+}
+
+func TestNewAccountKey(t *testing.T) {
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+	pub1 := crypto.CompressPubkey(&key1.PublicKey)
+	pub2 := crypto.CompressPubkey(&key2.PublicKey)
+
+	multisig := accountkey.NewAccountKeyWeightedMultiSigWithValues(2, accountkey.WeightedPublicKeys{
+		accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&key1.PublicKey)),
+		accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&key2.PublicKey)),
+	})
+	roleBased := accountkey.NewAccountKeyRoleBasedWithValues([]accountkey.AccountKey{
+		accountkey.NewAccountKeyPublicWithValue(&key1.PublicKey),
+		accountkey.NewAccountKeyLegacy(),
+		multisig,
+	})
+
+	assert.Equal(t, &AccountKey{Type: AccountKeyType_ACCOUNT_KEY_LEGACY}, newAccountKey(accountkey.NewAccountKeyLegacy()))
+	assert.Equal(t, &AccountKey{
+		Type: AccountKeyType_ACCOUNT_KEY_ROLE_BASED,
+		RoleKeys: []*AccountKey{
+			{Type: AccountKeyType_ACCOUNT_KEY_PUBLIC, PublicKey: pub1},
+			{Type: AccountKeyType_ACCOUNT_KEY_LEGACY},
+			{
+				Type:      AccountKeyType_ACCOUNT_KEY_WEIGHTED_MULTI_SIG,
+				Threshold: 2,
+				WeightedKeys: []*WeightedPublicKey{
+					{Weight: 1, PublicKey: pub1},
+					{Weight: 1, PublicKey: pub2},
+				},
+			},
+		},
+	}, newAccountKey(roleBased))
+}
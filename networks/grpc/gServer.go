@@ -40,9 +40,16 @@ var logger = log.NewModuleLogger(log.NetworksGRPC)
 type Listener struct {
 	Addr       string
 	handler    *rpc.Server
+	backend    Backend
 	grpcServer *grpc.Server
 }
 
+// APIBackendProvider is implemented by services providing the Backend
+// which serves the typed KlayAPI service.
+type APIBackendProvider interface {
+	KlayAPIBackend() Backend
+}
+
 // grpcReadWriteNopCloser wraps an io.Reader and io.Writer with a NOP Close method.
 type grpcReadWriteNopCloser struct {
 	io.Reader
@@ -250,6 +257,12 @@ func (gs *Listener) SetRPCServer(handler *rpc.Server) {
 	gs.handler = handler
 }
 
+// SetAPIBackend sets the backend of the typed KlayAPI service.
+// KlayAPI is not served if no backend is set.
+func (gs *Listener) SetAPIBackend(backend Backend) {
+	gs.backend = backend
+}
+
 func (gs *Listener) Start() {
 	lis, err := net.Listen("tcp", gs.Addr)
 	if err != nil {
@@ -259,6 +272,9 @@ func (gs *Listener) Start() {
 	gs.grpcServer = grpc.NewServer()
 
 	RegisterKlaytnNodeServer(gs.grpcServer, &klaytnServer{handler: gs.handler})
+	if gs.backend != nil {
+		RegisterKlayAPIServer(gs.grpcServer, newKlayServer(gs.backend))
+	}
 
 	// Register reflection service on gRPC server.
 	reflection.Register(gs.grpcServer)
@@ -10,6 +10,8 @@ import (
 
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -21,7 +23,73 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// BlockTag designates a block relative to the chain head.
+type BlockTag int32
+
+const (
+	BlockTag_LATEST   BlockTag = 0
+	BlockTag_PENDING  BlockTag = 1
+	BlockTag_EARLIEST BlockTag = 2
+)
+
+var BlockTag_name = map[int32]string{
+	0: "LATEST",
+	1: "PENDING",
+	2: "EARLIEST",
+}
+
+var BlockTag_value = map[string]int32{
+	"LATEST":   0,
+	"PENDING":  1,
+	"EARLIEST": 2,
+}
+
+func (x BlockTag) String() string {
+	return proto.EnumName(BlockTag_name, int32(x))
+}
+
+func (BlockTag) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{0}
+}
+
+type AccountKeyType int32
+
+const (
+	AccountKeyType_ACCOUNT_KEY_NIL                AccountKeyType = 0
+	AccountKeyType_ACCOUNT_KEY_LEGACY             AccountKeyType = 1
+	AccountKeyType_ACCOUNT_KEY_PUBLIC             AccountKeyType = 2
+	AccountKeyType_ACCOUNT_KEY_FAIL               AccountKeyType = 3
+	AccountKeyType_ACCOUNT_KEY_WEIGHTED_MULTI_SIG AccountKeyType = 4
+	AccountKeyType_ACCOUNT_KEY_ROLE_BASED         AccountKeyType = 5
+)
+
+var AccountKeyType_name = map[int32]string{
+	0: "ACCOUNT_KEY_NIL",
+	1: "ACCOUNT_KEY_LEGACY",
+	2: "ACCOUNT_KEY_PUBLIC",
+	3: "ACCOUNT_KEY_FAIL",
+	4: "ACCOUNT_KEY_WEIGHTED_MULTI_SIG",
+	5: "ACCOUNT_KEY_ROLE_BASED",
+}
+
+var AccountKeyType_value = map[string]int32{
+	"ACCOUNT_KEY_NIL":                0,
+	"ACCOUNT_KEY_LEGACY":             1,
+	"ACCOUNT_KEY_PUBLIC":             2,
+	"ACCOUNT_KEY_FAIL":               3,
+	"ACCOUNT_KEY_WEIGHTED_MULTI_SIG": 4,
+	"ACCOUNT_KEY_ROLE_BASED":         5,
+}
+
+func (x AccountKeyType) String() string {
+	return proto.EnumName(AccountKeyType_name, int32(x))
+}
+
+func (AccountKeyType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{1}
+}
 
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -148,227 +216,2289 @@ func (m *RPCResponse) GetPayload() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "grpc.Empty")
-	proto.RegisterType((*RPCRequest)(nil), "grpc.RPCRequest")
-	proto.RegisterType((*RPCResponse)(nil), "grpc.RPCResponse")
+// BlockSelector designates a block by tag, number or hash.
+// An unset selector designates the latest block.
+type BlockSelector struct {
+	// Types that are valid to be assigned to Selector:
+	//	*BlockSelector_Tag
+	//	*BlockSelector_Number
+	//	*BlockSelector_Hash
+	Selector             isBlockSelector_Selector `protobuf_oneof:"selector"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
-func init() { proto.RegisterFile("klaytn.proto", fileDescriptor_c6d8429895d2d55b) }
+func (m *BlockSelector) Reset()         { *m = BlockSelector{} }
+func (m *BlockSelector) String() string { return proto.CompactTextString(m) }
+func (*BlockSelector) ProtoMessage()    {}
+func (*BlockSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{3}
+}
 
-var fileDescriptor_c6d8429895d2d55b = []byte{
-	// 244 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x41, 0x4b, 0xc3, 0x30,
-	0x18, 0x86, 0xfd, 0x66, 0xed, 0xd8, 0x67, 0x61, 0x9a, 0x83, 0x14, 0x4f, 0xa3, 0x17, 0x0b, 0x62,
-	0x19, 0xce, 0x5f, 0xd0, 0xe1, 0x49, 0x90, 0x12, 0xc1, 0x7b, 0xda, 0x7e, 0x68, 0xb1, 0x59, 0x62,
-	0x92, 0x09, 0xfd, 0x3b, 0x1e, 0xfc, 0x9d, 0x92, 0xd8, 0x51, 0x8f, 0x7a, 0x7c, 0xdf, 0x24, 0x4f,
-	0x1e, 0x5e, 0x4c, 0xde, 0x7a, 0x31, 0xb8, 0x5d, 0xa1, 0x8d, 0x72, 0x8a, 0x45, 0x2f, 0x46, 0x37,
-	0xd9, 0x1c, 0x4f, 0xee, 0xa5, 0x76, 0x43, 0xf6, 0x8c, 0xc8, 0xab, 0x2d, 0xa7, 0xf7, 0x3d, 0x59,
-	0xc7, 0x52, 0x9c, 0x5b, 0x32, 0x1f, 0x5d, 0x43, 0x29, 0xac, 0x20, 0x5f, 0xf0, 0x43, 0x64, 0x17,
-	0x18, 0x4b, 0x72, 0xaf, 0xaa, 0x4d, 0x67, 0xe1, 0x60, 0x4c, 0xbe, 0xd7, 0xc2, 0x08, 0x69, 0xd3,
-	0xe3, 0x15, 0xe4, 0x09, 0x1f, 0x53, 0x76, 0x85, 0xa7, 0x81, 0x6b, 0xb5, 0xda, 0x59, 0xf2, 0x60,
-	0x2d, 0x86, 0x5e, 0x89, 0x36, 0x80, 0x13, 0x7e, 0x88, 0xb7, 0x5f, 0x80, 0xf8, 0x10, 0x04, 0x1f,
-	0x55, 0x4b, 0xec, 0x06, 0xa3, 0xad, 0xe8, 0x7b, 0x76, 0x56, 0x78, 0xcf, 0x62, 0x72, 0xbb, 0x3c,
-	0xff, 0xd5, 0xfc, 0x50, 0xb3, 0x23, 0x76, 0x87, 0x8b, 0xa7, 0x7d, 0x6d, 0x1b, 0xd3, 0xd5, 0xf4,
-	0xc7, 0x37, 0x6b, 0x60, 0x1b, 0x8c, 0xcb, 0xee, 0x1f, 0xdf, 0xe4, 0xb0, 0x86, 0xf2, 0x1a, 0x97,
-	0x8d, 0x92, 0xc5, 0x38, 0xa6, 0xbf, 0x54, 0x2e, 0x27, 0xf1, 0xca, 0x8f, 0x5b, 0xc1, 0xe7, 0x2c,
-	0xf2, 0x5d, 0x1d, 0x87, 0xb1, 0x37, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x75, 0x07, 0x2f, 0xb1,
-	0x7c, 0x01, 0x00, 0x00,
+func (m *BlockSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSelector.Unmarshal(m, b)
+}
+func (m *BlockSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSelector.Marshal(b, m, deterministic)
+}
+func (m *BlockSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSelector.Merge(m, src)
+}
+func (m *BlockSelector) XXX_Size() int {
+	return xxx_messageInfo_BlockSelector.Size(m)
+}
+func (m *BlockSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSelector.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_BlockSelector proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type isBlockSelector_Selector interface {
+	isBlockSelector_Selector()
+}
 
-// KlaytnNodeClient is the client API for KlaytnNode service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type KlaytnNodeClient interface {
-	Call(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (*RPCResponse, error)
-	Subscribe(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (KlaytnNode_SubscribeClient, error)
-	BiCall(ctx context.Context, opts ...grpc.CallOption) (KlaytnNode_BiCallClient, error)
+type BlockSelector_Tag struct {
+	Tag BlockTag `protobuf:"varint,1,opt,name=tag,proto3,enum=grpc.BlockTag,oneof"`
 }
 
-type klaytnNodeClient struct {
-	cc *grpc.ClientConn
+type BlockSelector_Number struct {
+	Number uint64 `protobuf:"varint,2,opt,name=number,proto3,oneof"`
 }
 
-func NewKlaytnNodeClient(cc *grpc.ClientConn) KlaytnNodeClient {
-	return &klaytnNodeClient{cc}
+type BlockSelector_Hash struct {
+	Hash []byte `protobuf:"bytes,3,opt,name=hash,proto3,oneof"`
 }
 
-func (c *klaytnNodeClient) Call(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (*RPCResponse, error) {
-	out := new(RPCResponse)
-	err := c.cc.Invoke(ctx, "/grpc.KlaytnNode/Call", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (*BlockSelector_Tag) isBlockSelector_Selector() {}
+
+func (*BlockSelector_Number) isBlockSelector_Selector() {}
+
+func (*BlockSelector_Hash) isBlockSelector_Selector() {}
+
+func (m *BlockSelector) GetSelector() isBlockSelector_Selector {
+	if m != nil {
+		return m.Selector
 	}
-	return out, nil
+	return nil
 }
 
-func (c *klaytnNodeClient) Subscribe(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (KlaytnNode_SubscribeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_KlaytnNode_serviceDesc.Streams[0], "/grpc.KlaytnNode/Subscribe", opts...)
-	if err != nil {
-		return nil, err
+func (m *BlockSelector) GetTag() BlockTag {
+	if x, ok := m.GetSelector().(*BlockSelector_Tag); ok {
+		return x.Tag
 	}
-	x := &klaytnNodeSubscribeClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return BlockTag_LATEST
+}
+
+func (m *BlockSelector) GetNumber() uint64 {
+	if x, ok := m.GetSelector().(*BlockSelector_Number); ok {
+		return x.Number
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *BlockSelector) GetHash() []byte {
+	if x, ok := m.GetSelector().(*BlockSelector_Hash); ok {
+		return x.Hash
 	}
-	return x, nil
+	return nil
 }
 
-type KlaytnNode_SubscribeClient interface {
-	Recv() (*RPCResponse, error)
-	grpc.ClientStream
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*BlockSelector) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*BlockSelector_Tag)(nil),
+		(*BlockSelector_Number)(nil),
+		(*BlockSelector_Hash)(nil),
+	}
 }
 
-type klaytnNodeSubscribeClient struct {
-	grpc.ClientStream
+type BlockNumberResponse struct {
+	Number               uint64   `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *klaytnNodeSubscribeClient) Recv() (*RPCResponse, error) {
-	m := new(RPCResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *BlockNumberResponse) Reset()         { *m = BlockNumberResponse{} }
+func (m *BlockNumberResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockNumberResponse) ProtoMessage()    {}
+func (*BlockNumberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{4}
+}
+
+func (m *BlockNumberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockNumberResponse.Unmarshal(m, b)
+}
+func (m *BlockNumberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockNumberResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockNumberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockNumberResponse.Merge(m, src)
+}
+func (m *BlockNumberResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockNumberResponse.Size(m)
+}
+func (m *BlockNumberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockNumberResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockNumberResponse proto.InternalMessageInfo
+
+func (m *BlockNumberResponse) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
 	}
-	return m, nil
+	return 0
 }
 
-func (c *klaytnNodeClient) BiCall(ctx context.Context, opts ...grpc.CallOption) (KlaytnNode_BiCallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_KlaytnNode_serviceDesc.Streams[1], "/grpc.KlaytnNode/BiCall", opts...)
-	if err != nil {
-		return nil, err
+type GetBlockRequest struct {
+	Block *BlockSelector `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// If set, Block.transactions is filled instead of Block.transaction_hashes.
+	FullTransactions     bool     `protobuf:"varint,2,opt,name=full_transactions,json=fullTransactions,proto3" json:"full_transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
+func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockRequest) ProtoMessage()    {}
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{5}
+}
+
+func (m *GetBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockRequest.Unmarshal(m, b)
+}
+func (m *GetBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockRequest.Merge(m, src)
+}
+func (m *GetBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockRequest.Size(m)
+}
+func (m *GetBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockRequest proto.InternalMessageInfo
+
+func (m *GetBlockRequest) GetBlock() *BlockSelector {
+	if m != nil {
+		return m.Block
 	}
-	x := &klaytnNodeBiCallClient{stream}
-	return x, nil
+	return nil
 }
 
-type KlaytnNode_BiCallClient interface {
-	Send(*RPCRequest) error
-	Recv() (*RPCResponse, error)
-	grpc.ClientStream
+func (m *GetBlockRequest) GetFullTransactions() bool {
+	if m != nil {
+		return m.FullTransactions
+	}
+	return false
 }
 
-type klaytnNodeBiCallClient struct {
-	grpc.ClientStream
+type Header struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Number               uint64   `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	ParentHash           []byte   `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Rewardbase           []byte   `protobuf:"bytes,4,opt,name=rewardbase,proto3" json:"rewardbase,omitempty"`
+	StateRoot            []byte   `protobuf:"bytes,5,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	TransactionsRoot     []byte   `protobuf:"bytes,6,opt,name=transactions_root,json=transactionsRoot,proto3" json:"transactions_root,omitempty"`
+	ReceiptsRoot         []byte   `protobuf:"bytes,7,opt,name=receipts_root,json=receiptsRoot,proto3" json:"receipts_root,omitempty"`
+	LogsBloom            []byte   `protobuf:"bytes,8,opt,name=logs_bloom,json=logsBloom,proto3" json:"logs_bloom,omitempty"`
+	BlockScore           []byte   `protobuf:"bytes,9,opt,name=block_score,json=blockScore,proto3" json:"block_score,omitempty"`
+	GasUsed              uint64   `protobuf:"varint,10,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Timestamp            uint64   `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TimestampFos         uint32   `protobuf:"varint,12,opt,name=timestamp_fos,json=timestampFos,proto3" json:"timestamp_fos,omitempty"`
+	ExtraData            []byte   `protobuf:"bytes,13,opt,name=extra_data,json=extraData,proto3" json:"extra_data,omitempty"`
+	GovernanceData       []byte   `protobuf:"bytes,14,opt,name=governance_data,json=governanceData,proto3" json:"governance_data,omitempty"`
+	VoteData             []byte   `protobuf:"bytes,15,opt,name=vote_data,json=voteData,proto3" json:"vote_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *klaytnNodeBiCallClient) Send(m *RPCRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *Header) Reset()         { *m = Header{} }
+func (m *Header) String() string { return proto.CompactTextString(m) }
+func (*Header) ProtoMessage()    {}
+func (*Header) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{6}
 }
 
-func (x *klaytnNodeBiCallClient) Recv() (*RPCResponse, error) {
-	m := new(RPCResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *Header) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Header.Unmarshal(m, b)
+}
+func (m *Header) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Header.Marshal(b, m, deterministic)
+}
+func (m *Header) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Header.Merge(m, src)
+}
+func (m *Header) XXX_Size() int {
+	return xxx_messageInfo_Header.Size(m)
+}
+func (m *Header) XXX_DiscardUnknown() {
+	xxx_messageInfo_Header.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Header proto.InternalMessageInfo
+
+func (m *Header) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
-	return m, nil
+	return nil
 }
 
-// KlaytnNodeServer is the server API for KlaytnNode service.
-type KlaytnNodeServer interface {
-	Call(context.Context, *RPCRequest) (*RPCResponse, error)
-	Subscribe(*RPCRequest, KlaytnNode_SubscribeServer) error
-	BiCall(KlaytnNode_BiCallServer) error
+func (m *Header) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
 }
 
-func RegisterKlaytnNodeServer(s *grpc.Server, srv KlaytnNodeServer) {
-	s.RegisterService(&_KlaytnNode_serviceDesc, srv)
+func (m *Header) GetParentHash() []byte {
+	if m != nil {
+		return m.ParentHash
+	}
+	return nil
 }
 
-func _KlaytnNode_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RPCRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Header) GetRewardbase() []byte {
+	if m != nil {
+		return m.Rewardbase
 	}
-	if interceptor == nil {
-		return srv.(KlaytnNodeServer).Call(ctx, in)
+	return nil
+}
+
+func (m *Header) GetStateRoot() []byte {
+	if m != nil {
+		return m.StateRoot
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/grpc.KlaytnNode/Call",
+	return nil
+}
+
+func (m *Header) GetTransactionsRoot() []byte {
+	if m != nil {
+		return m.TransactionsRoot
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KlaytnNodeServer).Call(ctx, req.(*RPCRequest))
+	return nil
+}
+
+func (m *Header) GetReceiptsRoot() []byte {
+	if m != nil {
+		return m.ReceiptsRoot
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _KlaytnNode_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RPCRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *Header) GetLogsBloom() []byte {
+	if m != nil {
+		return m.LogsBloom
 	}
-	return srv.(KlaytnNodeServer).Subscribe(m, &klaytnNodeSubscribeServer{stream})
+	return nil
 }
 
-type KlaytnNode_SubscribeServer interface {
-	Send(*RPCResponse) error
-	grpc.ServerStream
+func (m *Header) GetBlockScore() []byte {
+	if m != nil {
+		return m.BlockScore
+	}
+	return nil
 }
 
-type klaytnNodeSubscribeServer struct {
-	grpc.ServerStream
+func (m *Header) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
 }
 
-func (x *klaytnNodeSubscribeServer) Send(m *RPCResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *Header) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-func _KlaytnNode_BiCall_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(KlaytnNodeServer).BiCall(&klaytnNodeBiCallServer{stream})
+func (m *Header) GetTimestampFos() uint32 {
+	if m != nil {
+		return m.TimestampFos
+	}
+	return 0
 }
 
-type KlaytnNode_BiCallServer interface {
-	Send(*RPCResponse) error
-	Recv() (*RPCRequest, error)
-	grpc.ServerStream
+func (m *Header) GetExtraData() []byte {
+	if m != nil {
+		return m.ExtraData
+	}
+	return nil
 }
 
-type klaytnNodeBiCallServer struct {
-	grpc.ServerStream
+func (m *Header) GetGovernanceData() []byte {
+	if m != nil {
+		return m.GovernanceData
+	}
+	return nil
 }
 
-func (x *klaytnNodeBiCallServer) Send(m *RPCResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *Header) GetVoteData() []byte {
+	if m != nil {
+		return m.VoteData
+	}
+	return nil
 }
 
-func (x *klaytnNodeBiCallServer) Recv() (*RPCRequest, error) {
-	m := new(RPCRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+type Block struct {
+	Header               *Header        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	TotalBlockScore      []byte         `protobuf:"bytes,2,opt,name=total_block_score,json=totalBlockScore,proto3" json:"total_block_score,omitempty"`
+	Size                 uint64         `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	TransactionHashes    [][]byte       `protobuf:"bytes,4,rep,name=transaction_hashes,json=transactionHashes,proto3" json:"transaction_hashes,omitempty"`
+	Transactions         []*Transaction `protobuf:"bytes,5,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Block) Reset()         { *m = Block{} }
+func (m *Block) String() string { return proto.CompactTextString(m) }
+func (*Block) ProtoMessage()    {}
+func (*Block) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{7}
+}
+
+func (m *Block) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Block.Unmarshal(m, b)
+}
+func (m *Block) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Block.Marshal(b, m, deterministic)
+}
+func (m *Block) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Block.Merge(m, src)
+}
+func (m *Block) XXX_Size() int {
+	return xxx_messageInfo_Block.Size(m)
+}
+func (m *Block) XXX_DiscardUnknown() {
+	xxx_messageInfo_Block.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Block proto.InternalMessageInfo
+
+func (m *Block) GetHeader() *Header {
+	if m != nil {
+		return m.Header
 	}
-	return m, nil
+	return nil
 }
 
-var _KlaytnNode_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "grpc.KlaytnNode",
-	HandlerType: (*KlaytnNodeServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Call",
+func (m *Block) GetTotalBlockScore() []byte {
+	if m != nil {
+		return m.TotalBlockScore
+	}
+	return nil
+}
+
+func (m *Block) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Block) GetTransactionHashes() [][]byte {
+	if m != nil {
+		return m.TransactionHashes
+	}
+	return nil
+}
+
+func (m *Block) GetTransactions() []*Transaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type Signature struct {
+	V                    []byte   `protobuf:"bytes,1,opt,name=v,proto3" json:"v,omitempty"`
+	R                    []byte   `protobuf:"bytes,2,opt,name=r,proto3" json:"r,omitempty"`
+	S                    []byte   `protobuf:"bytes,3,opt,name=s,proto3" json:"s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Signature) Reset()         { *m = Signature{} }
+func (m *Signature) String() string { return proto.CompactTextString(m) }
+func (*Signature) ProtoMessage()    {}
+func (*Signature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{8}
+}
+
+func (m *Signature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signature.Unmarshal(m, b)
+}
+func (m *Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signature.Marshal(b, m, deterministic)
+}
+func (m *Signature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signature.Merge(m, src)
+}
+func (m *Signature) XXX_Size() int {
+	return xxx_messageInfo_Signature.Size(m)
+}
+func (m *Signature) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Signature proto.InternalMessageInfo
+
+func (m *Signature) GetV() []byte {
+	if m != nil {
+		return m.V
+	}
+	return nil
+}
+
+func (m *Signature) GetR() []byte {
+	if m != nil {
+		return m.R
+	}
+	return nil
+}
+
+func (m *Signature) GetS() []byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
+type Transaction struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// type is the numeric transaction type and type_name its name, e.g. "TxTypeValueTransfer".
+	Type               uint32       `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	TypeName           string       `protobuf:"bytes,3,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	From               []byte       `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To                 []byte       `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Nonce              uint64       `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Gas                uint64       `protobuf:"varint,7,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasPrice           []byte       `protobuf:"bytes,8,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	Value              []byte       `protobuf:"bytes,9,opt,name=value,proto3" json:"value,omitempty"`
+	Input              []byte       `protobuf:"bytes,10,opt,name=input,proto3" json:"input,omitempty"`
+	FeePayer           []byte       `protobuf:"bytes,11,opt,name=fee_payer,json=feePayer,proto3" json:"fee_payer,omitempty"`
+	FeeRatio           uint32       `protobuf:"varint,12,opt,name=fee_ratio,json=feeRatio,proto3" json:"fee_ratio,omitempty"`
+	Signatures         []*Signature `protobuf:"bytes,13,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	FeePayerSignatures []*Signature `protobuf:"bytes,14,rep,name=fee_payer_signatures,json=feePayerSignatures,proto3" json:"fee_payer_signatures,omitempty"`
+	// raw is the RLP encoding of the transaction carrying the type-specific fields.
+	Raw []byte `protobuf:"bytes,15,opt,name=raw,proto3" json:"raw,omitempty"`
+	// Block fields are empty for pending transactions.
+	BlockHash            []byte   `protobuf:"bytes,16,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,17,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TransactionIndex     uint64   `protobuf:"varint,18,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{9}
+}
+
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transaction.Unmarshal(m, b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+}
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return xxx_messageInfo_Transaction.Size(m)
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Transaction) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Transaction) GetTypeName() string {
+	if m != nil {
+		return m.TypeName
+	}
+	return ""
+}
+
+func (m *Transaction) GetFrom() []byte {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *Transaction) GetTo() []byte {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *Transaction) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *Transaction) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *Transaction) GetGasPrice() []byte {
+	if m != nil {
+		return m.GasPrice
+	}
+	return nil
+}
+
+func (m *Transaction) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Transaction) GetInput() []byte {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *Transaction) GetFeePayer() []byte {
+	if m != nil {
+		return m.FeePayer
+	}
+	return nil
+}
+
+func (m *Transaction) GetFeeRatio() uint32 {
+	if m != nil {
+		return m.FeeRatio
+	}
+	return 0
+}
+
+func (m *Transaction) GetSignatures() []*Signature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+func (m *Transaction) GetFeePayerSignatures() []*Signature {
+	if m != nil {
+		return m.FeePayerSignatures
+	}
+	return nil
+}
+
+func (m *Transaction) GetRaw() []byte {
+	if m != nil {
+		return m.Raw
+	}
+	return nil
+}
+
+func (m *Transaction) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Transaction) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *Transaction) GetTransactionIndex() uint64 {
+	if m != nil {
+		return m.TransactionIndex
+	}
+	return 0
+}
+
+type TransactionHash struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionHash) Reset()         { *m = TransactionHash{} }
+func (m *TransactionHash) String() string { return proto.CompactTextString(m) }
+func (*TransactionHash) ProtoMessage()    {}
+func (*TransactionHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{10}
+}
+
+func (m *TransactionHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionHash.Unmarshal(m, b)
+}
+func (m *TransactionHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionHash.Marshal(b, m, deterministic)
+}
+func (m *TransactionHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionHash.Merge(m, src)
+}
+func (m *TransactionHash) XXX_Size() int {
+	return xxx_messageInfo_TransactionHash.Size(m)
+}
+func (m *TransactionHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionHash proto.InternalMessageInfo
+
+func (m *TransactionHash) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+type SendRawTransactionRequest struct {
+	Raw                  []byte   `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendRawTransactionRequest) Reset()         { *m = SendRawTransactionRequest{} }
+func (m *SendRawTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRawTransactionRequest) ProtoMessage()    {}
+func (*SendRawTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{11}
+}
+
+func (m *SendRawTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendRawTransactionRequest.Unmarshal(m, b)
+}
+func (m *SendRawTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendRawTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *SendRawTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendRawTransactionRequest.Merge(m, src)
+}
+func (m *SendRawTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SendRawTransactionRequest.Size(m)
+}
+func (m *SendRawTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendRawTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendRawTransactionRequest proto.InternalMessageInfo
+
+func (m *SendRawTransactionRequest) GetRaw() []byte {
+	if m != nil {
+		return m.Raw
+	}
+	return nil
+}
+
+type Log struct {
+	Address          []byte   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics           [][]byte `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data             []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	BlockNumber      uint64   `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TransactionHash  []byte   `protobuf:"bytes,5,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TransactionIndex uint64   `protobuf:"varint,6,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	BlockHash        []byte   `protobuf:"bytes,7,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	LogIndex         uint64   `protobuf:"varint,8,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	// removed is set when the log was reverted due to a chain reorganisation.
+	Removed              bool     `protobuf:"varint,9,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Log) Reset()         { *m = Log{} }
+func (m *Log) String() string { return proto.CompactTextString(m) }
+func (*Log) ProtoMessage()    {}
+func (*Log) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{12}
+}
+
+func (m *Log) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Log.Unmarshal(m, b)
+}
+func (m *Log) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Log.Marshal(b, m, deterministic)
+}
+func (m *Log) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Log.Merge(m, src)
+}
+func (m *Log) XXX_Size() int {
+	return xxx_messageInfo_Log.Size(m)
+}
+func (m *Log) XXX_DiscardUnknown() {
+	xxx_messageInfo_Log.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Log proto.InternalMessageInfo
+
+func (m *Log) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *Log) GetTopics() [][]byte {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *Log) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Log) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *Log) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *Log) GetTransactionIndex() uint64 {
+	if m != nil {
+		return m.TransactionIndex
+	}
+	return 0
+}
+
+func (m *Log) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Log) GetLogIndex() uint64 {
+	if m != nil {
+		return m.LogIndex
+	}
+	return 0
+}
+
+func (m *Log) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+type Logs struct {
+	Logs                 []*Log   `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Logs) Reset()         { *m = Logs{} }
+func (m *Logs) String() string { return proto.CompactTextString(m) }
+func (*Logs) ProtoMessage()    {}
+func (*Logs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{13}
+}
+
+func (m *Logs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Logs.Unmarshal(m, b)
+}
+func (m *Logs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Logs.Marshal(b, m, deterministic)
+}
+func (m *Logs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Logs.Merge(m, src)
+}
+func (m *Logs) XXX_Size() int {
+	return xxx_messageInfo_Logs.Size(m)
+}
+func (m *Logs) XXX_DiscardUnknown() {
+	xxx_messageInfo_Logs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Logs proto.InternalMessageInfo
+
+func (m *Logs) GetLogs() []*Log {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type Receipt struct {
+	TransactionHash  []byte `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TransactionIndex uint64 `protobuf:"varint,2,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	BlockHash        []byte `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockNumber      uint64 `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	From             []byte `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To               []byte `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	ContractAddress  []byte `protobuf:"bytes,7,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	GasUsed          uint64 `protobuf:"varint,8,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	// status is 1 on success and 0 on failure, in which case tx_error holds the error code.
+	Status               uint32   `protobuf:"varint,9,opt,name=status,proto3" json:"status,omitempty"`
+	TxError              uint32   `protobuf:"varint,10,opt,name=tx_error,json=txError,proto3" json:"tx_error,omitempty"`
+	LogsBloom            []byte   `protobuf:"bytes,11,opt,name=logs_bloom,json=logsBloom,proto3" json:"logs_bloom,omitempty"`
+	Logs                 []*Log   `protobuf:"bytes,12,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Receipt) Reset()         { *m = Receipt{} }
+func (m *Receipt) String() string { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()    {}
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{14}
+}
+
+func (m *Receipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Receipt.Unmarshal(m, b)
+}
+func (m *Receipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Receipt.Marshal(b, m, deterministic)
+}
+func (m *Receipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Receipt.Merge(m, src)
+}
+func (m *Receipt) XXX_Size() int {
+	return xxx_messageInfo_Receipt.Size(m)
+}
+func (m *Receipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_Receipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Receipt proto.InternalMessageInfo
+
+func (m *Receipt) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *Receipt) GetTransactionIndex() uint64 {
+	if m != nil {
+		return m.TransactionIndex
+	}
+	return 0
+}
+
+func (m *Receipt) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *Receipt) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *Receipt) GetFrom() []byte {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *Receipt) GetTo() []byte {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *Receipt) GetContractAddress() []byte {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return nil
+}
+
+func (m *Receipt) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *Receipt) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *Receipt) GetTxError() uint32 {
+	if m != nil {
+		return m.TxError
+	}
+	return 0
+}
+
+func (m *Receipt) GetLogsBloom() []byte {
+	if m != nil {
+		return m.LogsBloom
+	}
+	return nil
+}
+
+func (m *Receipt) GetLogs() []*Log {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+// Topics lists the alternatives accepted at one topic position.
+// An empty list matches any topic.
+type Topics struct {
+	Hashes               [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Topics) Reset()         { *m = Topics{} }
+func (m *Topics) String() string { return proto.CompactTextString(m) }
+func (*Topics) ProtoMessage()    {}
+func (*Topics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{15}
+}
+
+func (m *Topics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Topics.Unmarshal(m, b)
+}
+func (m *Topics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Topics.Marshal(b, m, deterministic)
+}
+func (m *Topics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Topics.Merge(m, src)
+}
+func (m *Topics) XXX_Size() int {
+	return xxx_messageInfo_Topics.Size(m)
+}
+func (m *Topics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Topics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Topics proto.InternalMessageInfo
+
+func (m *Topics) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+// LogFilter selects logs either in a block range or in the block of block_hash.
+// For subscriptions only addresses and topics are used.
+type LogFilter struct {
+	FromBlock            *BlockSelector `protobuf:"bytes,1,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	ToBlock              *BlockSelector `protobuf:"bytes,2,opt,name=to_block,json=toBlock,proto3" json:"to_block,omitempty"`
+	BlockHash            []byte         `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Addresses            [][]byte       `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Topics               []*Topics      `protobuf:"bytes,5,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *LogFilter) Reset()         { *m = LogFilter{} }
+func (m *LogFilter) String() string { return proto.CompactTextString(m) }
+func (*LogFilter) ProtoMessage()    {}
+func (*LogFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{16}
+}
+
+func (m *LogFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogFilter.Unmarshal(m, b)
+}
+func (m *LogFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogFilter.Marshal(b, m, deterministic)
+}
+func (m *LogFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogFilter.Merge(m, src)
+}
+func (m *LogFilter) XXX_Size() int {
+	return xxx_messageInfo_LogFilter.Size(m)
+}
+func (m *LogFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogFilter proto.InternalMessageInfo
+
+func (m *LogFilter) GetFromBlock() *BlockSelector {
+	if m != nil {
+		return m.FromBlock
+	}
+	return nil
+}
+
+func (m *LogFilter) GetToBlock() *BlockSelector {
+	if m != nil {
+		return m.ToBlock
+	}
+	return nil
+}
+
+func (m *LogFilter) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *LogFilter) GetAddresses() [][]byte {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *LogFilter) GetTopics() []*Topics {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+type AccountKeyRequest struct {
+	Address              []byte         `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Block                *BlockSelector `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AccountKeyRequest) Reset()         { *m = AccountKeyRequest{} }
+func (m *AccountKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountKeyRequest) ProtoMessage()    {}
+func (*AccountKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{17}
+}
+
+func (m *AccountKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountKeyRequest.Unmarshal(m, b)
+}
+func (m *AccountKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountKeyRequest.Merge(m, src)
+}
+func (m *AccountKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountKeyRequest.Size(m)
+}
+func (m *AccountKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountKeyRequest proto.InternalMessageInfo
+
+func (m *AccountKeyRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *AccountKeyRequest) GetBlock() *BlockSelector {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type WeightedPublicKey struct {
+	Weight uint32 `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	// public_key is a compressed secp256k1 public key.
+	PublicKey            []byte   `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WeightedPublicKey) Reset()         { *m = WeightedPublicKey{} }
+func (m *WeightedPublicKey) String() string { return proto.CompactTextString(m) }
+func (*WeightedPublicKey) ProtoMessage()    {}
+func (*WeightedPublicKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{18}
+}
+
+func (m *WeightedPublicKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WeightedPublicKey.Unmarshal(m, b)
+}
+func (m *WeightedPublicKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WeightedPublicKey.Marshal(b, m, deterministic)
+}
+func (m *WeightedPublicKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WeightedPublicKey.Merge(m, src)
+}
+func (m *WeightedPublicKey) XXX_Size() int {
+	return xxx_messageInfo_WeightedPublicKey.Size(m)
+}
+func (m *WeightedPublicKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_WeightedPublicKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WeightedPublicKey proto.InternalMessageInfo
+
+func (m *WeightedPublicKey) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *WeightedPublicKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type AccountKey struct {
+	Type AccountKeyType `protobuf:"varint,1,opt,name=type,proto3,enum=grpc.AccountKeyType" json:"type,omitempty"`
+	// public_key is set for ACCOUNT_KEY_PUBLIC.
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// threshold and weighted_keys are set for ACCOUNT_KEY_WEIGHTED_MULTI_SIG.
+	Threshold    uint32               `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	WeightedKeys []*WeightedPublicKey `protobuf:"bytes,4,rep,name=weighted_keys,json=weightedKeys,proto3" json:"weighted_keys,omitempty"`
+	// role_keys is set for ACCOUNT_KEY_ROLE_BASED, indexed by role.
+	RoleKeys             []*AccountKey `protobuf:"bytes,5,rep,name=role_keys,json=roleKeys,proto3" json:"role_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *AccountKey) Reset()         { *m = AccountKey{} }
+func (m *AccountKey) String() string { return proto.CompactTextString(m) }
+func (*AccountKey) ProtoMessage()    {}
+func (*AccountKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{19}
+}
+
+func (m *AccountKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountKey.Unmarshal(m, b)
+}
+func (m *AccountKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountKey.Marshal(b, m, deterministic)
+}
+func (m *AccountKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountKey.Merge(m, src)
+}
+func (m *AccountKey) XXX_Size() int {
+	return xxx_messageInfo_AccountKey.Size(m)
+}
+func (m *AccountKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountKey proto.InternalMessageInfo
+
+func (m *AccountKey) GetType() AccountKeyType {
+	if m != nil {
+		return m.Type
+	}
+	return AccountKeyType_ACCOUNT_KEY_NIL
+}
+
+func (m *AccountKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *AccountKey) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *AccountKey) GetWeightedKeys() []*WeightedPublicKey {
+	if m != nil {
+		return m.WeightedKeys
+	}
+	return nil
+}
+
+func (m *AccountKey) GetRoleKeys() []*AccountKey {
+	if m != nil {
+		return m.RoleKeys
+	}
+	return nil
+}
+
+type CallRequest struct {
+	From                 []byte         `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   []byte         `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Gas                  uint64         `protobuf:"varint,3,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasPrice             []byte         `protobuf:"bytes,4,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	Value                []byte         `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Data                 []byte         `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	Block                *BlockSelector `protobuf:"bytes,7,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CallRequest) Reset()         { *m = CallRequest{} }
+func (m *CallRequest) String() string { return proto.CompactTextString(m) }
+func (*CallRequest) ProtoMessage()    {}
+func (*CallRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{20}
+}
+
+func (m *CallRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallRequest.Unmarshal(m, b)
+}
+func (m *CallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallRequest.Marshal(b, m, deterministic)
+}
+func (m *CallRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallRequest.Merge(m, src)
+}
+func (m *CallRequest) XXX_Size() int {
+	return xxx_messageInfo_CallRequest.Size(m)
+}
+func (m *CallRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallRequest proto.InternalMessageInfo
+
+func (m *CallRequest) GetFrom() []byte {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *CallRequest) GetTo() []byte {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *CallRequest) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *CallRequest) GetGasPrice() []byte {
+	if m != nil {
+		return m.GasPrice
+	}
+	return nil
+}
+
+func (m *CallRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *CallRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CallRequest) GetBlock() *BlockSelector {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type CallResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallResponse) Reset()         { *m = CallResponse{} }
+func (m *CallResponse) String() string { return proto.CompactTextString(m) }
+func (*CallResponse) ProtoMessage()    {}
+func (*CallResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{21}
+}
+
+func (m *CallResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallResponse.Unmarshal(m, b)
+}
+func (m *CallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallResponse.Marshal(b, m, deterministic)
+}
+func (m *CallResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallResponse.Merge(m, src)
+}
+func (m *CallResponse) XXX_Size() int {
+	return xxx_messageInfo_CallResponse.Size(m)
+}
+func (m *CallResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallResponse proto.InternalMessageInfo
+
+func (m *CallResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type EstimateGasResponse struct {
+	Gas                  uint64   `protobuf:"varint,1,opt,name=gas,proto3" json:"gas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimateGasResponse) Reset()         { *m = EstimateGasResponse{} }
+func (m *EstimateGasResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateGasResponse) ProtoMessage()    {}
+func (*EstimateGasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d8429895d2d55b, []int{22}
+}
+
+func (m *EstimateGasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateGasResponse.Unmarshal(m, b)
+}
+func (m *EstimateGasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateGasResponse.Marshal(b, m, deterministic)
+}
+func (m *EstimateGasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateGasResponse.Merge(m, src)
+}
+func (m *EstimateGasResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimateGasResponse.Size(m)
+}
+func (m *EstimateGasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateGasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateGasResponse proto.InternalMessageInfo
+
+func (m *EstimateGasResponse) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("grpc.BlockTag", BlockTag_name, BlockTag_value)
+	proto.RegisterEnum("grpc.AccountKeyType", AccountKeyType_name, AccountKeyType_value)
+	proto.RegisterType((*Empty)(nil), "grpc.Empty")
+	proto.RegisterType((*RPCRequest)(nil), "grpc.RPCRequest")
+	proto.RegisterType((*RPCResponse)(nil), "grpc.RPCResponse")
+	proto.RegisterType((*BlockSelector)(nil), "grpc.BlockSelector")
+	proto.RegisterType((*BlockNumberResponse)(nil), "grpc.BlockNumberResponse")
+	proto.RegisterType((*GetBlockRequest)(nil), "grpc.GetBlockRequest")
+	proto.RegisterType((*Header)(nil), "grpc.Header")
+	proto.RegisterType((*Block)(nil), "grpc.Block")
+	proto.RegisterType((*Signature)(nil), "grpc.Signature")
+	proto.RegisterType((*Transaction)(nil), "grpc.Transaction")
+	proto.RegisterType((*TransactionHash)(nil), "grpc.TransactionHash")
+	proto.RegisterType((*SendRawTransactionRequest)(nil), "grpc.SendRawTransactionRequest")
+	proto.RegisterType((*Log)(nil), "grpc.Log")
+	proto.RegisterType((*Logs)(nil), "grpc.Logs")
+	proto.RegisterType((*Receipt)(nil), "grpc.Receipt")
+	proto.RegisterType((*Topics)(nil), "grpc.Topics")
+	proto.RegisterType((*LogFilter)(nil), "grpc.LogFilter")
+	proto.RegisterType((*AccountKeyRequest)(nil), "grpc.AccountKeyRequest")
+	proto.RegisterType((*WeightedPublicKey)(nil), "grpc.WeightedPublicKey")
+	proto.RegisterType((*AccountKey)(nil), "grpc.AccountKey")
+	proto.RegisterType((*CallRequest)(nil), "grpc.CallRequest")
+	proto.RegisterType((*CallResponse)(nil), "grpc.CallResponse")
+	proto.RegisterType((*EstimateGasResponse)(nil), "grpc.EstimateGasResponse")
+}
+
+func init() { proto.RegisterFile("klaytn.proto", fileDescriptor_c6d8429895d2d55b) }
+
+var fileDescriptor_c6d8429895d2d55b = []byte{
+	// 1833 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0x4b, 0x73, 0x22, 0xc9,
+	0x11, 0xa6, 0xa1, 0x79, 0x25, 0x20, 0x50, 0xcd, 0x8c, 0xb6, 0x47, 0xfb, 0x92, 0xdb, 0xde, 0x18,
+	0xcd, 0x6c, 0x8c, 0x66, 0xac, 0xf5, 0x86, 0x2f, 0xeb, 0x70, 0x80, 0x86, 0x41, 0x58, 0x98, 0x25,
+	0x0a, 0xc6, 0xeb, 0x3d, 0x75, 0x14, 0x50, 0x42, 0xc4, 0x00, 0xd5, 0xae, 0x2a, 0xa4, 0xc1, 0x27,
+	0xff, 0x0a, 0x9f, 0x7c, 0xf2, 0xc1, 0x17, 0x9f, 0xfd, 0x47, 0xec, 0x3f, 0xe0, 0xab, 0xff, 0x84,
+	0xc3, 0x51, 0x8f, 0x6e, 0x9a, 0x87, 0xac, 0xdd, 0x13, 0x9d, 0x59, 0x99, 0x59, 0xf9, 0xf8, 0x2a,
+	0xb3, 0x0a, 0x28, 0xbf, 0x9f, 0x91, 0x95, 0x5c, 0x9c, 0x85, 0x9c, 0x49, 0x86, 0xdc, 0x09, 0x0f,
+	0x47, 0x7e, 0x1e, 0xb2, 0xcd, 0x79, 0x28, 0x57, 0xfe, 0xef, 0x00, 0x70, 0xef, 0x02, 0xd3, 0x3f,
+	0x2c, 0xa9, 0x90, 0xc8, 0x83, 0xbc, 0xa0, 0xfc, 0x76, 0x3a, 0xa2, 0x9e, 0x73, 0xe2, 0x9c, 0x16,
+	0x71, 0x44, 0xa2, 0x23, 0xc8, 0xcd, 0xa9, 0xbc, 0x61, 0x63, 0x2f, 0xad, 0x17, 0x2c, 0xa5, 0xf8,
+	0x21, 0xe1, 0x64, 0x2e, 0xbc, 0xcc, 0x89, 0x73, 0x5a, 0xc6, 0x96, 0xf2, 0x9f, 0x41, 0x49, 0xdb,
+	0x15, 0x21, 0x5b, 0x08, 0xaa, 0x0c, 0x87, 0x64, 0x35, 0x63, 0x64, 0xac, 0x0d, 0x97, 0x71, 0x44,
+	0xfa, 0x0c, 0x2a, 0x8d, 0x19, 0x1b, 0xbd, 0xef, 0xd3, 0x19, 0x1d, 0x49, 0xc6, 0x91, 0x0f, 0x19,
+	0x49, 0x26, 0x5a, 0xec, 0xe0, 0xfc, 0xe0, 0x4c, 0xb9, 0x7b, 0xa6, 0x25, 0x06, 0x64, 0x72, 0x99,
+	0xc2, 0x6a, 0x11, 0x79, 0x90, 0x5b, 0x2c, 0xe7, 0x43, 0xca, 0xb5, 0x37, 0xee, 0x65, 0x0a, 0x5b,
+	0x1a, 0x3d, 0x06, 0xf7, 0x86, 0x88, 0x1b, 0xe3, 0xcd, 0x65, 0x0a, 0x6b, 0xaa, 0x01, 0x50, 0x10,
+	0xd6, 0xbe, 0xff, 0x12, 0x1e, 0x69, 0x73, 0x5d, 0xad, 0x10, 0x7b, 0x78, 0x14, 0x9b, 0x54, 0x3b,
+	0xbb, 0x91, 0x41, 0x7f, 0x0a, 0xd5, 0x16, 0x95, 0x5a, 0x23, 0xca, 0xd2, 0x73, 0xc8, 0x0e, 0x15,
+	0xad, 0x25, 0x4b, 0xe7, 0x8f, 0x12, 0x3e, 0x46, 0x51, 0x60, 0x23, 0x81, 0xbe, 0x84, 0xc3, 0xeb,
+	0xe5, 0x6c, 0x16, 0x48, 0x4e, 0x16, 0x82, 0x8c, 0xe4, 0x94, 0x2d, 0x84, 0xf6, 0xb9, 0x80, 0x6b,
+	0x6a, 0x61, 0x90, 0xe0, 0xfb, 0xff, 0xc9, 0x40, 0xee, 0x92, 0x92, 0x31, 0xe5, 0x08, 0xd9, 0x30,
+	0x4c, 0xb2, 0xf4, 0x77, 0xc2, 0xc3, 0x74, 0xd2, 0x43, 0xf4, 0x39, 0x94, 0x42, 0xc2, 0xe9, 0x42,
+	0x06, 0xeb, 0xc8, 0x31, 0x18, 0xd6, 0xa5, 0x52, 0xfc, 0x0c, 0x80, 0xd3, 0x3b, 0xc2, 0xc7, 0x43,
+	0x22, 0xa8, 0xe7, 0x9a, 0xf5, 0x35, 0x07, 0x7d, 0x0a, 0x20, 0x24, 0x91, 0x34, 0xe0, 0x8c, 0x49,
+	0x2f, 0xab, 0xd7, 0x8b, 0x9a, 0x83, 0x19, 0x93, 0x2a, 0x86, 0xa4, 0xfb, 0x46, 0x2a, 0xa7, 0xa5,
+	0x6a, 0xc9, 0x05, 0x2d, 0xfc, 0x53, 0xa8, 0x70, 0x3a, 0xa2, 0xd3, 0x50, 0x5a, 0xc1, 0xbc, 0x16,
+	0x2c, 0x47, 0x4c, 0x2d, 0xf4, 0x29, 0xc0, 0x8c, 0x4d, 0x44, 0x30, 0x9c, 0x31, 0x36, 0xf7, 0x0a,
+	0x66, 0x43, 0xc5, 0x69, 0x28, 0x86, 0x0a, 0x48, 0x67, 0x2f, 0x10, 0x23, 0xc6, 0xa9, 0x57, 0x34,
+	0x0e, 0x6b, 0x56, 0x5f, 0x71, 0xd0, 0x53, 0x28, 0x4c, 0x88, 0x08, 0x96, 0x82, 0x8e, 0x3d, 0xd0,
+	0xb9, 0xc8, 0x4f, 0x88, 0x78, 0x27, 0xe8, 0x18, 0x7d, 0x02, 0x45, 0x39, 0x9d, 0x53, 0x21, 0xc9,
+	0x3c, 0xf4, 0x4a, 0x7a, 0x6d, 0xcd, 0x50, 0xde, 0xc5, 0x44, 0x70, 0xcd, 0x84, 0x57, 0x3e, 0x71,
+	0x4e, 0x2b, 0xb8, 0x1c, 0x33, 0xdf, 0x32, 0xa1, 0xbc, 0xa3, 0x1f, 0x24, 0x27, 0xc1, 0x98, 0x48,
+	0xe2, 0x55, 0x8c, 0x77, 0x9a, 0xf3, 0x86, 0x48, 0x82, 0x9e, 0x41, 0x75, 0xc2, 0x6e, 0x29, 0x5f,
+	0x90, 0xc5, 0x88, 0x1a, 0x99, 0x03, 0x2d, 0x73, 0xb0, 0x66, 0x6b, 0xc1, 0x8f, 0xa1, 0x78, 0xcb,
+	0xa4, 0x15, 0xa9, 0x6a, 0x91, 0x82, 0x62, 0xa8, 0x45, 0xff, 0x5f, 0x0e, 0x64, 0x35, 0x62, 0xd0,
+	0xcf, 0x20, 0x77, 0xa3, 0x8b, 0x6e, 0xe1, 0x54, 0x36, 0x70, 0x32, 0x40, 0xc0, 0x76, 0x0d, 0xbd,
+	0x80, 0x43, 0xc9, 0x24, 0x99, 0x05, 0xc9, 0xcc, 0xa4, 0xb5, 0xd1, 0xaa, 0x5e, 0x68, 0xac, 0xd3,
+	0x83, 0xc0, 0x15, 0xd3, 0x3f, 0x52, 0x8d, 0x04, 0x17, 0xeb, 0x6f, 0xf4, 0x12, 0x50, 0xa2, 0x56,
+	0x1a, 0x29, 0x54, 0x78, 0xee, 0x49, 0xe6, 0xb4, 0x8c, 0x93, 0xe5, 0xbd, 0xd4, 0x0b, 0xe8, 0x6b,
+	0x28, 0x6f, 0x40, 0x36, 0x7b, 0x92, 0x39, 0x2d, 0x9d, 0x1f, 0x1a, 0xd7, 0x12, 0xa0, 0xc5, 0x1b,
+	0x62, 0xfe, 0xd7, 0x50, 0xec, 0x4f, 0x27, 0x0b, 0x22, 0x97, 0x9c, 0xa2, 0x32, 0x38, 0xb7, 0x16,
+	0xc0, 0xce, 0xad, 0xa2, 0xb8, 0x75, 0xd8, 0xe1, 0x8a, 0x8a, 0x3a, 0x86, 0x23, 0xfc, 0x3f, 0xbb,
+	0x50, 0x4a, 0x18, 0xdd, 0x8b, 0x7e, 0x04, 0xae, 0x5c, 0x85, 0x26, 0xe6, 0x0a, 0xd6, 0xdf, 0x2a,
+	0xc3, 0xea, 0x37, 0x58, 0x90, 0xb9, 0x89, 0xb6, 0x88, 0x0b, 0x8a, 0xd1, 0x25, 0x73, 0x9d, 0x85,
+	0x6b, 0xce, 0xe6, 0x16, 0xef, 0xfa, 0x1b, 0x1d, 0x40, 0x5a, 0x32, 0x8b, 0xf0, 0xb4, 0x64, 0xe8,
+	0x31, 0x64, 0x17, 0x6c, 0x31, 0xa2, 0x1a, 0xce, 0x2e, 0x36, 0x04, 0xaa, 0x41, 0x66, 0x42, 0x84,
+	0x46, 0xae, 0x8b, 0xd5, 0xa7, 0xda, 0x48, 0x01, 0x2e, 0xe4, 0xaa, 0x33, 0x1a, 0xbc, 0x2a, 0x04,
+	0xf6, 0x14, 0xad, 0x8c, 0xdc, 0x92, 0xd9, 0x32, 0x02, 0xaa, 0x21, 0x14, 0x77, 0xba, 0x08, 0x97,
+	0x52, 0x03, 0xb4, 0x8c, 0x0d, 0xa1, 0x0c, 0x5d, 0x53, 0x1a, 0x84, 0x64, 0x45, 0xb9, 0x86, 0x67,
+	0x19, 0x17, 0xae, 0x29, 0xed, 0x29, 0x3a, 0x5a, 0xe4, 0x44, 0x4e, 0x99, 0x45, 0xa6, 0x5a, 0xc4,
+	0x8a, 0x46, 0xaf, 0x00, 0x44, 0x94, 0x5a, 0xe1, 0x55, 0x74, 0x3d, 0xaa, 0xa6, 0x1e, 0x71, 0xca,
+	0x71, 0x42, 0x04, 0xd5, 0xe1, 0x71, 0xbc, 0x55, 0x90, 0x50, 0x3d, 0xd8, 0xaf, 0x8a, 0x22, 0x37,
+	0xfa, 0x6b, 0x13, 0x35, 0xc8, 0x70, 0x72, 0x67, 0xb1, 0xab, 0x3e, 0xd5, 0xd9, 0x30, 0x00, 0xd4,
+	0xf5, 0xa9, 0x99, 0xb3, 0xa1, 0x39, 0xba, 0xd3, 0xfc, 0x04, 0xca, 0x66, 0xd9, 0x36, 0xaa, 0x43,
+	0x9d, 0xc2, 0xd2, 0x70, 0xdd, 0x6f, 0xb7, 0xba, 0x49, 0x30, 0x5d, 0x8c, 0xe9, 0x07, 0x0f, 0x69,
+	0xb9, 0x64, 0x37, 0x69, 0x2b, 0xbe, 0xff, 0x05, 0x54, 0x07, 0x9b, 0xd8, 0xdc, 0x87, 0x0d, 0xff,
+	0x25, 0x3c, 0xed, 0xd3, 0xc5, 0x18, 0x93, 0xbb, 0x24, 0x34, 0x6d, 0xb7, 0xb6, 0x41, 0x38, 0x71,
+	0x10, 0xfe, 0x5f, 0xd2, 0x90, 0xe9, 0x30, 0x35, 0x45, 0xf2, 0x64, 0x3c, 0xe6, 0x54, 0x88, 0x68,
+	0x28, 0x59, 0x52, 0xb5, 0x5a, 0xc9, 0xc2, 0xe9, 0x48, 0xf5, 0x6a, 0x75, 0x42, 0x2c, 0xa5, 0x36,
+	0xd7, 0xa7, 0xd9, 0x20, 0x57, 0x7f, 0xef, 0xc4, 0xec, 0xee, 0xc6, 0xfc, 0x1c, 0x6a, 0xdb, 0x87,
+	0xcf, 0x82, 0xb0, 0xba, 0x75, 0xf4, 0xf6, 0xa7, 0x27, 0xb7, 0x3f, 0x3d, 0x5b, 0xd5, 0xc8, 0x6f,
+	0x57, 0xe3, 0x63, 0x50, 0x4d, 0xd5, 0xda, 0x28, 0x68, 0x1b, 0x85, 0x19, 0x9b, 0x18, 0x5d, 0x0f,
+	0xf2, 0x9c, 0xce, 0xd9, 0x2d, 0x1d, 0x6b, 0xdc, 0x16, 0x70, 0x44, 0xfa, 0x5f, 0x80, 0xdb, 0x61,
+	0x13, 0xd5, 0x07, 0x5d, 0xd5, 0x93, 0x3d, 0x47, 0x03, 0xa6, 0x68, 0x00, 0xd3, 0x61, 0x13, 0xac,
+	0xd9, 0xfe, 0x7f, 0xd3, 0x90, 0xc7, 0xa6, 0xab, 0xef, 0x0d, 0xd0, 0xf9, 0x11, 0x01, 0xa6, 0x7f,
+	0x50, 0x80, 0x99, 0x87, 0xe0, 0xb6, 0x27, 0xf5, 0x51, 0x17, 0xc8, 0xee, 0x74, 0x81, 0x5c, 0xdc,
+	0x05, 0x9e, 0x43, 0x6d, 0xc4, 0x16, 0x92, 0x93, 0x91, 0x0c, 0x22, 0x40, 0x98, 0x64, 0x56, 0x23,
+	0x7e, 0xdd, 0xb0, 0x37, 0x26, 0x4f, 0x61, 0x73, 0xf2, 0x1c, 0x41, 0x4e, 0xcd, 0xcc, 0xa5, 0xd0,
+	0xf9, 0xac, 0x60, 0x4b, 0x29, 0x15, 0xf9, 0x21, 0xa0, 0x9c, 0x33, 0xae, 0x7b, 0x41, 0x05, 0xe7,
+	0xe5, 0x87, 0xa6, 0x22, 0xb7, 0xe6, 0x60, 0x69, 0x7b, 0x0e, 0x46, 0x05, 0x28, 0xef, 0x2f, 0xc0,
+	0x09, 0xe4, 0x06, 0x06, 0x96, 0x47, 0x90, 0xb3, 0x0d, 0xdd, 0x31, 0x70, 0x35, 0x94, 0xff, 0x4f,
+	0x07, 0x8a, 0x1d, 0x36, 0x79, 0x3b, 0x9d, 0x49, 0xca, 0xd1, 0x39, 0x80, 0x0a, 0x3f, 0x78, 0xf0,
+	0xee, 0x52, 0x54, 0x62, 0x9a, 0x85, 0xce, 0xa0, 0x20, 0x99, 0xd5, 0x48, 0xdf, 0xaf, 0x91, 0x97,
+	0xcc, 0xc8, 0x3f, 0x50, 0xb0, 0x4f, 0xa0, 0x68, 0x13, 0x1c, 0x0f, 0x9f, 0x35, 0x43, 0x4d, 0x42,
+	0x7b, 0xea, 0xcc, 0xb8, 0xb1, 0x93, 0xd0, 0x04, 0x19, 0x9d, 0x41, 0xff, 0xf7, 0x70, 0x58, 0x1f,
+	0x8d, 0xd8, 0x72, 0x21, 0xaf, 0xe8, 0x2a, 0x71, 0x71, 0xbd, 0xe7, 0x28, 0xc7, 0x97, 0xb5, 0xf4,
+	0x43, 0x97, 0x35, 0xff, 0x37, 0x70, 0xf8, 0x1d, 0x9d, 0x4e, 0x6e, 0x24, 0x1d, 0xf7, 0x96, 0xc3,
+	0xd9, 0x74, 0x74, 0x45, 0x57, 0x2a, 0xb7, 0x77, 0x9a, 0xa9, 0x0d, 0x57, 0xb0, 0xa5, 0x54, 0xa4,
+	0xa1, 0x16, 0x0a, 0xde, 0xd3, 0x95, 0x1d, 0x6c, 0xc5, 0x30, 0x52, 0xf3, 0xff, 0xed, 0x00, 0xac,
+	0xdd, 0x44, 0xa7, 0x76, 0x7a, 0x99, 0x5b, 0xed, 0x63, 0xe3, 0xc4, 0x7a, 0x7d, 0xb0, 0x0a, 0xa9,
+	0x9d, 0x69, 0xff, 0xdf, 0xae, 0xbe, 0xdf, 0xdc, 0x70, 0x2a, 0x6e, 0xd8, 0x6c, 0xac, 0xf3, 0x5b,
+	0xc1, 0x6b, 0x06, 0xfa, 0x06, 0x2a, 0x77, 0x36, 0x02, 0xa5, 0x6e, 0x72, 0x5c, 0x3a, 0xff, 0xc8,
+	0xec, 0xb7, 0x13, 0x1c, 0x2e, 0x47, 0xd2, 0x57, 0x74, 0x25, 0xd0, 0x4b, 0x28, 0x72, 0x36, 0xa3,
+	0x46, 0xd3, 0x94, 0xa0, 0xb6, 0xed, 0x29, 0x2e, 0x28, 0x11, 0x25, 0xee, 0xff, 0xc3, 0x81, 0xd2,
+	0x05, 0x99, 0xcd, 0xa2, 0x1a, 0x44, 0x47, 0xcd, 0xd9, 0x39, 0x6a, 0xe9, 0xf8, 0xa8, 0xd9, 0xd1,
+	0x9a, 0xb9, 0x67, 0xb4, 0xba, 0xf7, 0x8d, 0xd6, 0x6c, 0x72, 0xb4, 0x46, 0x5d, 0x38, 0x97, 0xe8,
+	0xc2, 0x71, 0x99, 0xf3, 0x0f, 0x96, 0xd9, 0x87, 0xb2, 0x71, 0xdb, 0xde, 0xfc, 0x23, 0x73, 0xce,
+	0xda, 0x9c, 0xff, 0x0c, 0x1e, 0x35, 0x85, 0x9c, 0xce, 0x89, 0xa4, 0x2d, 0x22, 0x62, 0x51, 0xeb,
+	0xbe, 0x13, 0xbb, 0xff, 0xe2, 0xe7, 0x50, 0x88, 0x1e, 0x27, 0x08, 0x20, 0xd7, 0xa9, 0x0f, 0x9a,
+	0xfd, 0x41, 0x2d, 0x85, 0x4a, 0x90, 0xef, 0x35, 0xbb, 0x6f, 0xda, 0xdd, 0x56, 0xcd, 0x41, 0x65,
+	0x28, 0x34, 0xeb, 0xb8, 0xd3, 0x56, 0x4b, 0xe9, 0x17, 0x7f, 0x77, 0xe0, 0x60, 0xb3, 0xf4, 0xe8,
+	0x11, 0x54, 0xeb, 0x17, 0x17, 0xdf, 0xbe, 0xeb, 0x0e, 0x82, 0xab, 0xe6, 0xf7, 0x41, 0xb7, 0xdd,
+	0xa9, 0xa5, 0xd0, 0x11, 0xa0, 0x24, 0xb3, 0xd3, 0x6c, 0xd5, 0x2f, 0xbe, 0xaf, 0x39, 0xdb, 0xfc,
+	0xde, 0xbb, 0x46, 0xa7, 0x7d, 0x51, 0x4b, 0xa3, 0xc7, 0x50, 0x4b, 0xf2, 0xdf, 0xd6, 0xdb, 0x9d,
+	0x5a, 0x06, 0xf9, 0xf0, 0x59, 0x92, 0xfb, 0x5d, 0xb3, 0xdd, 0xba, 0x1c, 0x34, 0xdf, 0x04, 0xbf,
+	0x7d, 0xd7, 0x19, 0xb4, 0x83, 0x7e, 0xbb, 0x55, 0x73, 0xd1, 0x31, 0x1c, 0x25, 0x65, 0xf0, 0xb7,
+	0x9d, 0x66, 0xd0, 0xa8, 0xf7, 0x9b, 0x6f, 0x6a, 0xd9, 0xf3, 0xbf, 0x39, 0x00, 0x57, 0xfa, 0x01,
+	0xd9, 0x65, 0x63, 0x75, 0x8f, 0x74, 0x55, 0xf2, 0x90, 0x05, 0xc6, 0xfa, 0xed, 0x78, 0x7c, 0x98,
+	0xe0, 0x98, 0x74, 0xf9, 0x29, 0xf4, 0x0b, 0x28, 0xf6, 0x97, 0x43, 0x31, 0xe2, 0xd3, 0x21, 0xfd,
+	0x81, 0x3a, 0xaf, 0x1d, 0xf4, 0x15, 0xe4, 0x1a, 0xd3, 0x1f, 0xb1, 0xcd, 0xa9, 0xf3, 0xda, 0x39,
+	0xff, 0x53, 0x16, 0xf2, 0xca, 0xd1, 0x7a, 0xaf, 0x8d, 0x7e, 0x09, 0xa5, 0xc4, 0x1b, 0x0f, 0x95,
+	0x8c, 0x8e, 0x7e, 0xf1, 0x1e, 0x3f, 0x4d, 0x40, 0x63, 0xf3, 0x0d, 0xe8, 0xa7, 0xd0, 0x6b, 0x28,
+	0x44, 0xaf, 0x3d, 0xf4, 0xc4, 0x08, 0x6e, 0xbd, 0xfe, 0x8e, 0x4b, 0x09, 0x7d, 0x3f, 0x85, 0xbe,
+	0x81, 0x83, 0x16, 0x95, 0xc9, 0xdb, 0xeb, 0x93, 0x9d, 0x5b, 0xb2, 0xea, 0x7d, 0xc7, 0xbb, 0x97,
+	0x67, 0x3f, 0x85, 0x7e, 0x0d, 0x4f, 0x36, 0xb5, 0xa3, 0x89, 0x7a, 0x8f, 0x91, 0x8a, 0x8d, 0xde,
+	0x48, 0xf9, 0x29, 0xd4, 0x05, 0xb4, 0x7b, 0xf5, 0x41, 0x9f, 0xdb, 0xdb, 0xdd, 0x7d, 0x97, 0xa2,
+	0xe3, 0xfd, 0xe6, 0xfd, 0x14, 0x3a, 0x85, 0x7c, 0x8b, 0x4a, 0x3d, 0xff, 0xab, 0xf1, 0xc0, 0x31,
+	0x03, 0xe4, 0x18, 0x62, 0x86, 0xd0, 0x81, 0x57, 0x5a, 0x54, 0x26, 0x7a, 0xdc, 0x47, 0x3b, 0xbd,
+	0xc2, 0x6e, 0xb6, 0xd3, 0x44, 0xfc, 0x14, 0x7a, 0x65, 0x71, 0x64, 0xb3, 0x92, 0xe8, 0x23, 0xc7,
+	0x28, 0xc9, 0x8a, 0x2b, 0xf3, 0x2b, 0x28, 0x25, 0x4e, 0xe4, 0x3e, 0x3d, 0x5b, 0xd8, 0x3d, 0xe7,
+	0xd6, 0x4f, 0xa1, 0x73, 0x38, 0x8c, 0x81, 0xd8, 0xa5, 0x77, 0xea, 0x75, 0x25, 0x36, 0x71, 0xb1,
+	0xf1, 0xee, 0xd2, 0x30, 0x7c, 0x05, 0x95, 0x58, 0x67, 0x7f, 0x46, 0xd6, 0x33, 0x59, 0x29, 0x34,
+	0xbe, 0x84, 0xea, 0x88, 0xcd, 0xcf, 0xec, 0xff, 0x2d, 0x6a, 0xa9, 0x51, 0x5d, 0x9f, 0x9d, 0x1e,
+	0x67, 0x92, 0xf5, 0x9c, 0xbf, 0xa6, 0x5d, 0xc5, 0x1b, 0xe6, 0xf4, 0xff, 0x31, 0x5f, 0xfd, 0x6f,
+	0x00, 0x81, 0x11, 0x7b, 0x34, 0x9f, 0x11, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// KlaytnNodeClient is the client API for KlaytnNode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type KlaytnNodeClient interface {
+	Call(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (*RPCResponse, error)
+	Subscribe(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (KlaytnNode_SubscribeClient, error)
+	BiCall(ctx context.Context, opts ...grpc.CallOption) (KlaytnNode_BiCallClient, error)
+}
+
+type klaytnNodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewKlaytnNodeClient(cc *grpc.ClientConn) KlaytnNodeClient {
+	return &klaytnNodeClient{cc}
+}
+
+func (c *klaytnNodeClient) Call(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (*RPCResponse, error) {
+	out := new(RPCResponse)
+	err := c.cc.Invoke(ctx, "/grpc.KlaytnNode/Call", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klaytnNodeClient) Subscribe(ctx context.Context, in *RPCRequest, opts ...grpc.CallOption) (KlaytnNode_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_KlaytnNode_serviceDesc.Streams[0], "/grpc.KlaytnNode/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &klaytnNodeSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type KlaytnNode_SubscribeClient interface {
+	Recv() (*RPCResponse, error)
+	grpc.ClientStream
+}
+
+type klaytnNodeSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *klaytnNodeSubscribeClient) Recv() (*RPCResponse, error) {
+	m := new(RPCResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *klaytnNodeClient) BiCall(ctx context.Context, opts ...grpc.CallOption) (KlaytnNode_BiCallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_KlaytnNode_serviceDesc.Streams[1], "/grpc.KlaytnNode/BiCall", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &klaytnNodeBiCallClient{stream}
+	return x, nil
+}
+
+type KlaytnNode_BiCallClient interface {
+	Send(*RPCRequest) error
+	Recv() (*RPCResponse, error)
+	grpc.ClientStream
+}
+
+type klaytnNodeBiCallClient struct {
+	grpc.ClientStream
+}
+
+func (x *klaytnNodeBiCallClient) Send(m *RPCRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *klaytnNodeBiCallClient) Recv() (*RPCResponse, error) {
+	m := new(RPCResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// KlaytnNodeServer is the server API for KlaytnNode service.
+type KlaytnNodeServer interface {
+	Call(context.Context, *RPCRequest) (*RPCResponse, error)
+	Subscribe(*RPCRequest, KlaytnNode_SubscribeServer) error
+	BiCall(KlaytnNode_BiCallServer) error
+}
+
+// UnimplementedKlaytnNodeServer can be embedded to have forward compatible implementations.
+type UnimplementedKlaytnNodeServer struct {
+}
+
+func (*UnimplementedKlaytnNodeServer) Call(ctx context.Context, req *RPCRequest) (*RPCResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+func (*UnimplementedKlaytnNodeServer) Subscribe(req *RPCRequest, srv KlaytnNode_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedKlaytnNodeServer) BiCall(srv KlaytnNode_BiCallServer) error {
+	return status.Errorf(codes.Unimplemented, "method BiCall not implemented")
+}
+
+func RegisterKlaytnNodeServer(s *grpc.Server, srv KlaytnNodeServer) {
+	s.RegisterService(&_KlaytnNode_serviceDesc, srv)
+}
+
+func _KlaytnNode_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RPCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlaytnNodeServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlaytnNode/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlaytnNodeServer).Call(ctx, req.(*RPCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlaytnNode_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RPCRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KlaytnNodeServer).Subscribe(m, &klaytnNodeSubscribeServer{stream})
+}
+
+type KlaytnNode_SubscribeServer interface {
+	Send(*RPCResponse) error
+	grpc.ServerStream
+}
+
+type klaytnNodeSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *klaytnNodeSubscribeServer) Send(m *RPCResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _KlaytnNode_BiCall_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KlaytnNodeServer).BiCall(&klaytnNodeBiCallServer{stream})
+}
+
+type KlaytnNode_BiCallServer interface {
+	Send(*RPCResponse) error
+	Recv() (*RPCRequest, error)
+	grpc.ServerStream
+}
+
+type klaytnNodeBiCallServer struct {
+	grpc.ServerStream
+}
+
+func (x *klaytnNodeBiCallServer) Send(m *RPCResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *klaytnNodeBiCallServer) Recv() (*RPCRequest, error) {
+	m := new(RPCRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _KlaytnNode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc.KlaytnNode",
+	HandlerType: (*KlaytnNodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Call",
 			Handler:    _KlaytnNode_Call_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "Subscribe",
-			Handler:       _KlaytnNode_Subscribe_Handler,
+			StreamName:    "Subscribe",
+			Handler:       _KlaytnNode_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BiCall",
+			Handler:       _KlaytnNode_BiCall_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "klaytn.proto",
+}
+
+// KlayAPIClient is the client API for KlayAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type KlayAPIClient interface {
+	BlockNumber(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BlockNumberResponse, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	GetTransaction(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Transaction, error)
+	GetTransactionReceipt(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Receipt, error)
+	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*TransactionHash, error)
+	GetLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (*Logs, error)
+	GetAccountKey(ctx context.Context, in *AccountKeyRequest, opts ...grpc.CallOption) (*AccountKey, error)
+	Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
+	EstimateGas(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error)
+	// SubscribeNewHeads streams the header of every new chain head.
+	SubscribeNewHeads(ctx context.Context, in *Empty, opts ...grpc.CallOption) (KlayAPI_SubscribeNewHeadsClient, error)
+	// SubscribeLogs streams the logs of new blocks matching the filter.
+	SubscribeLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (KlayAPI_SubscribeLogsClient, error)
+}
+
+type klayAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewKlayAPIClient(cc *grpc.ClientConn) KlayAPIClient {
+	return &klayAPIClient{cc}
+}
+
+func (c *klayAPIClient) BlockNumber(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BlockNumberResponse, error) {
+	out := new(BlockNumberResponse)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/BlockNumber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) GetTransaction(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) GetTransactionReceipt(ctx context.Context, in *TransactionHash, opts ...grpc.CallOption) (*Receipt, error) {
+	out := new(Receipt)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/GetTransactionReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*TransactionHash, error) {
+	out := new(TransactionHash)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/SendRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) GetLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (*Logs, error) {
+	out := new(Logs)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/GetLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) GetAccountKey(ctx context.Context, in *AccountKeyRequest, opts ...grpc.CallOption) (*AccountKey, error) {
+	out := new(AccountKey)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/GetAccountKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/Call", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) EstimateGas(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error) {
+	out := new(EstimateGasResponse)
+	err := c.cc.Invoke(ctx, "/grpc.KlayAPI/EstimateGas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *klayAPIClient) SubscribeNewHeads(ctx context.Context, in *Empty, opts ...grpc.CallOption) (KlayAPI_SubscribeNewHeadsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_KlayAPI_serviceDesc.Streams[0], "/grpc.KlayAPI/SubscribeNewHeads", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &klayAPISubscribeNewHeadsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type KlayAPI_SubscribeNewHeadsClient interface {
+	Recv() (*Header, error)
+	grpc.ClientStream
+}
+
+type klayAPISubscribeNewHeadsClient struct {
+	grpc.ClientStream
+}
+
+func (x *klayAPISubscribeNewHeadsClient) Recv() (*Header, error) {
+	m := new(Header)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *klayAPIClient) SubscribeLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (KlayAPI_SubscribeLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_KlayAPI_serviceDesc.Streams[1], "/grpc.KlayAPI/SubscribeLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &klayAPISubscribeLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type KlayAPI_SubscribeLogsClient interface {
+	Recv() (*Log, error)
+	grpc.ClientStream
+}
+
+type klayAPISubscribeLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *klayAPISubscribeLogsClient) Recv() (*Log, error) {
+	m := new(Log)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// KlayAPIServer is the server API for KlayAPI service.
+type KlayAPIServer interface {
+	BlockNumber(context.Context, *Empty) (*BlockNumberResponse, error)
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	GetTransaction(context.Context, *TransactionHash) (*Transaction, error)
+	GetTransactionReceipt(context.Context, *TransactionHash) (*Receipt, error)
+	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*TransactionHash, error)
+	GetLogs(context.Context, *LogFilter) (*Logs, error)
+	GetAccountKey(context.Context, *AccountKeyRequest) (*AccountKey, error)
+	Call(context.Context, *CallRequest) (*CallResponse, error)
+	EstimateGas(context.Context, *CallRequest) (*EstimateGasResponse, error)
+	// SubscribeNewHeads streams the header of every new chain head.
+	SubscribeNewHeads(*Empty, KlayAPI_SubscribeNewHeadsServer) error
+	// SubscribeLogs streams the logs of new blocks matching the filter.
+	SubscribeLogs(*LogFilter, KlayAPI_SubscribeLogsServer) error
+}
+
+// UnimplementedKlayAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedKlayAPIServer struct {
+}
+
+func (*UnimplementedKlayAPIServer) BlockNumber(ctx context.Context, req *Empty) (*BlockNumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockNumber not implemented")
+}
+func (*UnimplementedKlayAPIServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedKlayAPIServer) GetTransaction(ctx context.Context, req *TransactionHash) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedKlayAPIServer) GetTransactionReceipt(ctx context.Context, req *TransactionHash) (*Receipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionReceipt not implemented")
+}
+func (*UnimplementedKlayAPIServer) SendRawTransaction(ctx context.Context, req *SendRawTransactionRequest) (*TransactionHash, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendRawTransaction not implemented")
+}
+func (*UnimplementedKlayAPIServer) GetLogs(ctx context.Context, req *LogFilter) (*Logs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (*UnimplementedKlayAPIServer) GetAccountKey(ctx context.Context, req *AccountKeyRequest) (*AccountKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountKey not implemented")
+}
+func (*UnimplementedKlayAPIServer) Call(ctx context.Context, req *CallRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+func (*UnimplementedKlayAPIServer) EstimateGas(ctx context.Context, req *CallRequest) (*EstimateGasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateGas not implemented")
+}
+func (*UnimplementedKlayAPIServer) SubscribeNewHeads(req *Empty, srv KlayAPI_SubscribeNewHeadsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewHeads not implemented")
+}
+func (*UnimplementedKlayAPIServer) SubscribeLogs(req *LogFilter, srv KlayAPI_SubscribeLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeLogs not implemented")
+}
+
+func RegisterKlayAPIServer(s *grpc.Server, srv KlayAPIServer) {
+	s.RegisterService(&_KlayAPI_serviceDesc, srv)
+}
+
+func _KlayAPI_BlockNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).BlockNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/BlockNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).BlockNumber(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).GetTransaction(ctx, req.(*TransactionHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_GetTransactionReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).GetTransactionReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/GetTransactionReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).GetTransactionReceipt(ctx, req.(*TransactionHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/SendRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).SendRawTransaction(ctx, req.(*SendRawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/GetLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).GetLogs(ctx, req.(*LogFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_GetAccountKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).GetAccountKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/GetAccountKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).GetAccountKey(ctx, req.(*AccountKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/Call",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).Call(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_EstimateGas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KlayAPIServer).EstimateGas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.KlayAPI/EstimateGas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KlayAPIServer).EstimateGas(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KlayAPI_SubscribeNewHeads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KlayAPIServer).SubscribeNewHeads(m, &klayAPISubscribeNewHeadsServer{stream})
+}
+
+type KlayAPI_SubscribeNewHeadsServer interface {
+	Send(*Header) error
+	grpc.ServerStream
+}
+
+type klayAPISubscribeNewHeadsServer struct {
+	grpc.ServerStream
+}
+
+func (x *klayAPISubscribeNewHeadsServer) Send(m *Header) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _KlayAPI_SubscribeLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogFilter)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KlayAPIServer).SubscribeLogs(m, &klayAPISubscribeLogsServer{stream})
+}
+
+type KlayAPI_SubscribeLogsServer interface {
+	Send(*Log) error
+	grpc.ServerStream
+}
+
+type klayAPISubscribeLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *klayAPISubscribeLogsServer) Send(m *Log) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _KlayAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc.KlayAPI",
+	HandlerType: (*KlayAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BlockNumber",
+			Handler:    _KlayAPI_BlockNumber_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _KlayAPI_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _KlayAPI_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetTransactionReceipt",
+			Handler:    _KlayAPI_GetTransactionReceipt_Handler,
+		},
+		{
+			MethodName: "SendRawTransaction",
+			Handler:    _KlayAPI_SendRawTransaction_Handler,
+		},
+		{
+			MethodName: "GetLogs",
+			Handler:    _KlayAPI_GetLogs_Handler,
+		},
+		{
+			MethodName: "GetAccountKey",
+			Handler:    _KlayAPI_GetAccountKey_Handler,
+		},
+		{
+			MethodName: "Call",
+			Handler:    _KlayAPI_Call_Handler,
+		},
+		{
+			MethodName: "EstimateGas",
+			Handler:    _KlayAPI_EstimateGas_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNewHeads",
+			Handler:       _KlayAPI_SubscribeNewHeads_Handler,
 			ServerStreams: true,
 		},
 		{
-			StreamName:    "BiCall",
-			Handler:       _KlaytnNode_BiCall_Handler,
+			StreamName:    "SubscribeLogs",
+			Handler:       _KlayAPI_SubscribeLogs_Handler,
 			ServerStreams: true,
-			ClientStreams: true,
 		},
 	},
 	Metadata: "klaytn.proto",
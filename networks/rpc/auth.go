@@ -0,0 +1,206 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// jwtIssuedAtWindow is the allowed difference between the issued-at time of a token
+// without an expiry and the current time.
+const jwtIssuedAtWindow = 60 * time.Second
+
+var (
+	errMissingToken     = errors.New("missing bearer token")
+	errMalformedToken   = errors.New("malformed token")
+	errUnsupportedToken = errors.New("unsupported token algorithm")
+	errInvalidSignature = errors.New("invalid token signature")
+	errTokenExpired     = errors.New("token is expired")
+	errStaleToken       = errors.New("token is issued too far from the current time")
+)
+
+// namespacesKey is the context key of the namespaces a caller is allowed to call.
+type namespacesKey struct{}
+
+// jwtClaims is the set of the claims of the tokens accepted by the authenticated endpoint.
+// Namespaces is the allow-list of the API namespaces the token holder can call, and "*"
+// allows all the namespaces of the endpoint.
+type jwtClaims struct {
+	IssuedAt   *int64   `json:"iat"`
+	Expiry     *int64   `json:"exp"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// parseJWT verifies the HS256 signature and the time claims of the token and returns its claims.
+// A token with an expiry is valid until it expires. A token without an expiry is valid only
+// if it has been issued within jwtIssuedAtWindow from now.
+func parseJWT(secret []byte, token string, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, errMalformedToken
+	}
+	if header.Alg != "HS256" {
+		return nil, errUnsupportedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidSignature
+	}
+
+	claims := new(jwtClaims)
+	if err := decodeJWTSegment(parts[1], claims); err != nil {
+		return nil, errMalformedToken
+	}
+	switch {
+	case claims.Expiry != nil:
+		if now.Unix() >= *claims.Expiry {
+			return nil, errTokenExpired
+		}
+	case claims.IssuedAt != nil:
+		issuedAt := time.Unix(*claims.IssuedAt, 0)
+		if issuedAt.Before(now.Add(-jwtIssuedAtWindow)) || issuedAt.After(now.Add(jwtIssuedAtWindow)) {
+			return nil, errStaleToken
+		}
+	default:
+		return nil, errStaleToken
+	}
+	return claims, nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// newJWTHandler returns a handler which passes only the requests with a valid bearer token
+// to the next handler. The namespaces allowed by the token are attached to the request context.
+func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") {
+			http.Error(w, errMissingToken.Error(), http.StatusUnauthorized)
+			return
+		}
+		claims, err := parseJWT(secret, strings.TrimPrefix(auth, "Bearer "), time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		namespaces := make(map[string]bool, len(claims.Namespaces))
+		for _, namespace := range claims.Namespaces {
+			// for ethereum compatibility. convert ethereum namespace to klay namespace.
+			if namespace == "eth" {
+				namespace = "klay"
+			}
+			namespaces[namespace] = true
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), namespacesKey{}, namespaces)))
+	})
+}
+
+// authorizeRequests marks the requests to the namespaces not allowed for the caller as failed.
+// The requests are not restricted if the context has no allow-list.
+func (s *Server) authorizeRequests(ctx context.Context, reqs []*serverRequest) {
+	namespaces, ok := ctx.Value(namespacesKey{}).(map[string]bool)
+	if !ok || namespaces["*"] {
+		return
+	}
+	for _, req := range reqs {
+		if req.err != nil || req.callb == nil {
+			continue
+		}
+		if !namespaces[req.svcname] {
+			req.err = &unauthorizedError{req.svcname, req.method}
+		}
+	}
+}
+
+// authContext returns the context for the connection of the given request,
+// keeping the namespaces allowed by the authenticated endpoint.
+func authContext(r *http.Request) context.Context {
+	ctx := context.Background()
+	if namespaces := r.Context().Value(namespacesKey{}); namespaces != nil {
+		ctx = context.WithValue(ctx, namespacesKey{}, namespaces)
+	}
+	return ctx
+}
+
+// StartAuthEndpoint starts the authenticated RPC endpoint serving HTTP and websocket requests.
+// Every request should have a bearer token signed with the secret, and can only call the
+// namespaces allowed by the token among the given modules.
+func StartAuthEndpoint(endpoint string, apis []API, modules []string, vhosts []string, timeouts HTTPTimeouts, secret []byte) (net.Listener, *Server, error) {
+	// Generate the whitelist based on the allowed modules
+	whitelist := make(map[string]bool)
+	for _, module := range modules {
+		whitelist[module] = true
+	}
+	// Register all the APIs exposed by the services
+	handler := NewServer()
+	for _, api := range apis {
+		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
+			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+				return nil, nil, err
+			}
+			logger.Debug("Authenticated endpoint registered", "namespace", api.Namespace)
+		}
+	}
+	// All APIs registered, start the HTTP listener
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	wsHandler := handler.WebsocketHandler([]string{"*"})
+	rpcHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+	timeouts = sanitizeTimeouts(timeouts)
+	server := &http.Server{
+		Handler:      newVHostHandler(vhosts, newJWTHandler(secret, rpcHandler)),
+		ReadTimeout:  timeouts.ReadTimeout,
+		WriteTimeout: timeouts.WriteTimeout,
+		IdleTimeout:  timeouts.IdleTimeout,
+	}
+	go server.Serve(listener)
+	return listener, handler, nil
+}
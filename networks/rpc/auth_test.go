@@ -0,0 +1,101 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testJWTSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func makeTestJWT(secret []byte, alg string, claims interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseJWT(t *testing.T) {
+	now := time.Now()
+	future, past := now.Add(time.Hour).Unix(), now.Add(-time.Hour).Unix()
+	issued := now.Unix()
+
+	tests := []struct {
+		token string
+		err   error
+	}{
+		{makeTestJWT(testJWTSecret, "HS256", jwtClaims{Expiry: &future}), nil},
+		{makeTestJWT(testJWTSecret, "HS256", jwtClaims{IssuedAt: &issued}), nil},
+		{makeTestJWT(testJWTSecret, "HS256", jwtClaims{Expiry: &past}), errTokenExpired},
+		{makeTestJWT(testJWTSecret, "HS256", jwtClaims{IssuedAt: &past}), errStaleToken},
+		{makeTestJWT(testJWTSecret, "HS256", jwtClaims{}), errStaleToken},
+		{makeTestJWT(testJWTSecret, "none", jwtClaims{Expiry: &future}), errUnsupportedToken},
+		{makeTestJWT([]byte("wrong secret"), "HS256", jwtClaims{Expiry: &future}), errInvalidSignature},
+		{"not a token", errMalformedToken},
+	}
+	for i, tt := range tests {
+		_, err := parseJWT(testJWTSecret, tt.token, now)
+		assert.Equal(t, tt.err, err, "test %d", i)
+	}
+}
+
+func TestJWTHandler(t *testing.T) {
+	server := NewServer()
+	if err := server.RegisterName("test", new(Service)); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.RegisterName("admin", new(Service)); err != nil {
+		t.Fatal(err)
+	}
+	handler := newJWTHandler(testJWTSecret, server)
+	expiry := time.Now().Add(time.Hour).Unix()
+
+	call := func(token, method string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`))
+		request.Header.Set("content-type", contentType)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Requests without a valid token are rejected.
+	assert.Equal(t, http.StatusUnauthorized, call("", "test_rets").Code)
+	assert.Equal(t, http.StatusUnauthorized, call(makeTestJWT([]byte("wrong secret"), "HS256", jwtClaims{Expiry: &expiry}), "test_rets").Code)
+
+	// A token can call only the namespaces in its claim.
+	token := makeTestJWT(testJWTSecret, "HS256", jwtClaims{Expiry: &expiry, Namespaces: []string{"test"}})
+	assert.NotContains(t, call(token, "test_rets").Body.String(), "-32001")
+	assert.Contains(t, call(token, "admin_rets").Body.String(), "-32001")
+
+	token = makeTestJWT(testJWTSecret, "HS256", jwtClaims{Expiry: &expiry, Namespaces: []string{"*"}})
+	assert.NotContains(t, call(token, "admin_rets").Body.String(), "-32001")
+}
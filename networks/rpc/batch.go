@@ -0,0 +1,138 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+// BatchConfig is the configuration of the batch requests of the RPC servers.
+type BatchConfig struct {
+	// MaxItems is the maximum number of requests in a batch. 0 means no limit.
+	MaxItems int
+	// MaxResponseSize is the maximum number of bytes of the responses of a batch. 0 means no limit.
+	// The requests executed after the limit is reached are answered with an error.
+	MaxResponseSize int
+	// Parallelism is the number of requests of a batch executed at once. 1 or less means sequential execution.
+	Parallelism int
+}
+
+// BatchLimit is the batch configuration of newly created RPC servers.
+// It can be overwritten by rpc.batch flags.
+var BatchLimit = BatchConfig{
+	MaxItems:        1000,
+	MaxResponseSize: 25 * 1024 * 1024,
+	Parallelism:     1,
+}
+
+var (
+	rpcBatchTooLargeCounter    = metrics.NewRegisteredCounter("rpc/counts/batch/toolarge", nil)
+	rpcBatchResponseSizeMeter  = metrics.NewRegisteredMeter("rpc/batch/responsesize", nil)
+	rpcResponseTooLargeCounter = metrics.NewRegisteredCounter("rpc/counts/batch/responsetoolarge", nil)
+)
+
+// checkBatchSize returns an error if the number of the requests exceeds the limit.
+func (s *Server) checkBatchSize(reqs []*serverRequest) Error {
+	if s.batch.MaxItems > 0 && len(reqs) > s.batch.MaxItems {
+		rpcBatchTooLargeCounter.Inc(1)
+		return &invalidRequestError{fmt.Sprintf("batch too large: %d requests (max %d)", len(reqs), s.batch.MaxItems)}
+	}
+	return nil
+}
+
+// execBatch executes the given requests and writes the result back using the codec.
+// It will only write the response back when the last request is processed.
+// The requests are executed in parallel up to the configured parallelism, and the
+// requests executed after the responses exceed the size limit get an error instead.
+func (s *Server) execBatch(ctx context.Context, codec ServerCodec, requests []*serverRequest, subCnt *int32) {
+	var (
+		responses = make([]interface{}, len(requests))
+		callbacks = make([]func(), len(requests))
+		size      int64
+	)
+
+	execOne := func(i int) {
+		req := requests[i]
+		if s.batch.MaxResponseSize > 0 && atomic.LoadInt64(&size) > int64(s.batch.MaxResponseSize) {
+			rpcResponseTooLargeCounter.Inc(1)
+			rpcErrorResponsesCounter.Inc(1)
+			responses[i] = codec.CreateErrorResponse(&req.id, &responseTooLargeError{s.batch.MaxResponseSize})
+			return
+		}
+		if req.err != nil {
+			rpcErrorResponsesCounter.Inc(1)
+			responses[i] = codec.CreateErrorResponse(&req.id, req.err)
+		} else {
+			responses[i], callbacks[i] = s.handle(ctx, codec, req, subCnt)
+		}
+		// The response is encoded here to measure its size, and written as is afterwards.
+		if s.batch.MaxResponseSize > 0 {
+			encoded, err := json.Marshal(responses[i])
+			if err != nil {
+				rpcErrorResponsesCounter.Inc(1)
+				responses[i] = codec.CreateErrorResponse(&req.id, &callbackError{err.Error()})
+				return
+			}
+			responses[i] = json.RawMessage(encoded)
+			atomic.AddInt64(&size, int64(len(encoded)))
+		}
+	}
+
+	if s.batch.Parallelism <= 1 || len(requests) == 1 {
+		for i := range requests {
+			execOne(i)
+		}
+	} else {
+		var (
+			wg   sync.WaitGroup
+			next = int64(-1)
+		)
+		workers := s.batch.Parallelism
+		if workers > len(requests) {
+			workers = len(requests)
+		}
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+				for i := int(atomic.AddInt64(&next, 1)); i < len(requests); i = int(atomic.AddInt64(&next, 1)) {
+					execOne(i)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	rpcBatchResponseSizeMeter.Mark(size)
+
+	if err := codec.Write(responses); err != nil {
+		logger.Error(fmt.Sprintf("%v\n", err))
+		codec.Close()
+	}
+
+	// when request holds one of more subscribe requests this allows these subscriptions to be activated
+	for _, c := range callbacks {
+		if c != nil {
+			c()
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callBatch sends a batch of n test_echo requests to the server and returns the responses.
+func callBatch(t *testing.T, server *Server, n int) []jsonErrResponse {
+	reqs := make([]string, n)
+	for i := range reqs {
+		reqs[i] = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"test_echo","params":["%s",%d,{"S":"x"}]}`, i, strings.Repeat("a", 100), i)
+	}
+	request := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader("["+strings.Join(reqs, ",")+"]"))
+	request.Header.Set("content-type", contentType)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+
+	var resps []jsonErrResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &resps); err != nil {
+		var resp jsonErrResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+		resps = append(resps, resp)
+	}
+	return resps
+}
+
+func newBatchTestServer(t *testing.T, config BatchConfig) *Server {
+	server := NewServer()
+	server.batch = config
+	require.NoError(t, server.RegisterName("test", new(Service)))
+	return server
+}
+
+func TestServerBatchMaxItems(t *testing.T) {
+	server := newBatchTestServer(t, BatchConfig{MaxItems: 3})
+
+	resps := callBatch(t, server, 3)
+	assert.Len(t, resps, 3)
+	for _, resp := range resps {
+		assert.Equal(t, 0, resp.Error.Code)
+	}
+
+	// A batch exceeding the limit is rejected with a single error.
+	resps = callBatch(t, server, 4)
+	require.Len(t, resps, 1)
+	assert.Equal(t, -32600, resps[0].Error.Code)
+	assert.Contains(t, resps[0].Error.Message, "batch too large")
+}
+
+func TestServerBatchMaxResponseSize(t *testing.T) {
+	// Each response is about 150 bytes, so the limit is exceeded by the 7th response.
+	server := newBatchTestServer(t, BatchConfig{MaxResponseSize: 1000})
+
+	resps := callBatch(t, server, 10)
+	require.Len(t, resps, 10)
+	var succeeded int
+	for i, resp := range resps {
+		assert.Equal(t, float64(i), resp.Id)
+		if resp.Error.Code == 0 {
+			assert.Equal(t, i, succeeded, "the requests after the limit must fail")
+			succeeded++
+		} else {
+			assert.Equal(t, -32003, resp.Error.Code)
+		}
+	}
+	assert.True(t, succeeded > 0 && succeeded < 10, "succeeded: %d", succeeded)
+}
+
+func TestServerBatchParallel(t *testing.T) {
+	server := newBatchTestServer(t, BatchConfig{Parallelism: 4})
+
+	// The responses keep the order of the requests.
+	resps := callBatch(t, server, 50)
+	require.Len(t, resps, 50)
+	for i, resp := range resps {
+		assert.Equal(t, float64(i), resp.Id)
+		assert.Equal(t, 0, resp.Error.Code)
+	}
+}
@@ -64,3 +64,36 @@ type shutdownError struct{}
 func (e *shutdownError) ErrorCode() int { return -32000 }
 
 func (e *shutdownError) Error() string { return "server is shutting down" }
+
+// issued when a client sends requests more than its rate limit.
+type rateLimitedError struct {
+	service string
+	method  string
+}
+
+func (e *rateLimitedError) ErrorCode() int { return -32005 }
+
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s%s%s", e.service, serviceMethodSeparator, e.method)
+}
+
+// issued when a client calls a namespace which is not allowed by its credentials.
+type unauthorizedError struct {
+	service string
+	method  string
+}
+
+func (e *unauthorizedError) ErrorCode() int { return -32001 }
+
+func (e *unauthorizedError) Error() string {
+	return fmt.Sprintf("not authorized to call %s%s%s", e.service, serviceMethodSeparator, e.method)
+}
+
+// issued when the responses of a batch exceed the size limit.
+type responseTooLargeError struct{ limit int }
+
+func (e *responseTooLargeError) ErrorCode() int { return -32003 }
+
+func (e *responseTooLargeError) Error() string {
+	return fmt.Sprintf("batch response too large: exceeds %d bytes", e.limit)
+}
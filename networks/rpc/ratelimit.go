@@ -0,0 +1,215 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	// heavyMethodClass is the class of the rate limit shared by the methods in RateLimitConfig.HeavyMethods.
+	heavyMethodClass = "heavy"
+
+	// rateLimitCleanupInterval is the interval to remove the token buckets of idle clients.
+	rateLimitCleanupInterval = time.Minute
+
+	// apiKeyHeader is the HTTP header used to identify a client with an allowed API key instead of its IP address.
+	apiKeyHeader = "X-Api-Key"
+)
+
+// RateLimitConfig is the configuration of the per-client rate limit of the RPC servers.
+// A client is identified by its API key given in the X-Api-Key header if the key is in APIKeys,
+// or by its IP address otherwise.
+// Each client has a token bucket for each API namespace, and the methods in HeavyMethods
+// share a separate token bucket which is limited by HeavyRate and HeavyBurst.
+type RateLimitConfig struct {
+	// Rate is the number of requests per second allowed for each namespace. 0 means no limit.
+	Rate float64
+	// Burst is the maximum number of requests allowed at once for each namespace.
+	Burst int
+
+	// HeavyRate is the number of requests per second allowed for the heavy methods. 0 means no limit.
+	HeavyRate float64
+	// HeavyBurst is the maximum number of requests allowed at once for the heavy methods.
+	HeavyBurst int
+	// HeavyMethods is the list of the heavy methods. A name ending with '*' matches the methods with the prefix.
+	HeavyMethods []string
+
+	// APIKeys is the list of the API keys identifying clients. Other keys are ignored,
+	// since a client could get a new bucket with each new key.
+	APIKeys []string
+}
+
+// RateLimit is the rate limit configuration of newly created RPC servers.
+// It can be overwritten by rpc.ratelimit flags.
+var RateLimit = RateLimitConfig{
+	HeavyMethods: []string{"debug_trace*", "klay_getLogs"},
+}
+
+var rpcRateLimitedCounter = metrics.NewRegisteredCounter("rpc/counts/ratelimited", nil)
+
+// tokenBucket is a token bucket refilled at a constant rate up to its burst size.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket for the elapsed time and takes a token if there is one.
+func (b *tokenBucket) take(now time.Time, rate float64, burst int) bool {
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter keeps the token buckets of the clients of an RPC server.
+type rateLimiter struct {
+	config RateLimitConfig
+
+	apiKeys map[string]bool
+
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	if config.Rate > 0 && config.Burst <= 0 {
+		config.Burst = burstOf(config.Rate)
+	}
+	if config.HeavyRate > 0 && config.HeavyBurst <= 0 {
+		config.HeavyBurst = burstOf(config.HeavyRate)
+	}
+	apiKeys := make(map[string]bool, len(config.APIKeys))
+	for _, key := range config.APIKeys {
+		if key != "" {
+			apiKeys[key] = true
+		}
+	}
+	return &rateLimiter{
+		config:      config,
+		apiKeys:     apiKeys,
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// burstOf returns the default burst size for the given rate.
+func burstOf(rate float64) int {
+	if rate < 1 {
+		return 1
+	}
+	return int(rate)
+}
+
+// enabled returns true if any limit is configured.
+func (l *rateLimiter) enabled() bool {
+	return l != nil && (l.config.Rate > 0 || l.config.HeavyRate > 0)
+}
+
+// classOf returns the class of the rate limit of the given method and its limit.
+func (l *rateLimiter) classOf(service, method string) (string, float64, int) {
+	name := service + serviceMethodSeparator + method
+	for _, heavy := range l.config.HeavyMethods {
+		if name == heavy || (strings.HasSuffix(heavy, "*") && strings.HasPrefix(name, strings.TrimSuffix(heavy, "*"))) {
+			return heavyMethodClass, l.config.HeavyRate, l.config.HeavyBurst
+		}
+	}
+	return service, l.config.Rate, l.config.Burst
+}
+
+// allow returns true if the client is allowed to call the method now.
+func (l *rateLimiter) allow(client, service, method string) bool {
+	class, rate, burst := l.classOf(service, method)
+	if rate <= 0 {
+		return true
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastCleanup) > rateLimitCleanupInterval {
+		for key, bucket := range l.buckets {
+			if now.Sub(bucket.last) > rateLimitCleanupInterval {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	key := client + "/" + class
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(burst), last: now}
+		l.buckets[key] = bucket
+	}
+	if bucket.take(now, rate, burst) {
+		return true
+	}
+	rpcRateLimitedCounter.Inc(1)
+	metrics.GetOrRegisterCounter("rpc/counts/ratelimited/"+class, nil).Inc(1)
+	return false
+}
+
+// clientOf returns the identifier of the client sending the request, which is its API key
+// if the key is allowed, or its IP address otherwise.
+// An empty string is returned if the client cannot be identified (e.g. IPC).
+func (l *rateLimiter) clientOf(ctx context.Context) string {
+	if apiKey, ok := ctx.Value("apikey").(string); ok && l.apiKeys[apiKey] {
+		return "key:" + apiKey
+	}
+	remote, ok := ctx.Value("remote").(string)
+	if !ok || remote == "" {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
+
+// limitRequests marks the requests exceeding the rate limit of the client as failed.
+func (s *Server) limitRequests(ctx context.Context, reqs []*serverRequest) {
+	if !s.limiter.enabled() {
+		return
+	}
+	client := s.limiter.clientOf(ctx)
+	if client == "" {
+		return
+	}
+	for _, req := range reqs {
+		if req.err != nil || req.callb == nil {
+			continue
+		}
+		if !s.limiter.allow(client, req.svcname, req.method) {
+			req.err = &rateLimitedError{req.svcname, req.method}
+		}
+	}
+}
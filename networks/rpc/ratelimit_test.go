@@ -0,0 +1,98 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := &tokenBucket{tokens: 2, last: now}
+
+	assert.True(t, bucket.take(now, 1, 2))
+	assert.True(t, bucket.take(now, 1, 2))
+	assert.False(t, bucket.take(now, 1, 2))
+
+	// A token is refilled after a second, and the bucket never exceeds its burst size.
+	assert.True(t, bucket.take(now.Add(time.Second), 1, 2))
+	assert.False(t, bucket.take(now.Add(time.Second), 1, 2))
+	assert.True(t, bucket.take(now.Add(time.Hour), 1, 2))
+	assert.True(t, bucket.take(now.Add(time.Hour), 1, 2))
+	assert.False(t, bucket.take(now.Add(time.Hour), 1, 2))
+}
+
+func TestRateLimiter_Classes(t *testing.T) {
+	limiter := newRateLimiter(RateLimitConfig{Rate: 1, Burst: 2, HeavyRate: 1, HeavyMethods: []string{"debug_trace*", "klay_getLogs"}})
+
+	class, _, burst := limiter.classOf("debug", "traceTransaction")
+	assert.Equal(t, heavyMethodClass, class)
+	assert.Equal(t, 1, burst)
+	class, _, _ = limiter.classOf("klay", "getLogs")
+	assert.Equal(t, heavyMethodClass, class)
+	class, _, burst = limiter.classOf("klay", "getLogsByHash")
+	assert.Equal(t, "klay", class)
+	assert.Equal(t, 2, burst)
+
+	// The heavy methods and the namespaces have separate buckets.
+	assert.True(t, limiter.allow("client", "debug", "traceBlock"))
+	assert.False(t, limiter.allow("client", "klay", "getLogs"))
+	assert.True(t, limiter.allow("client", "klay", "blockNumber"))
+	assert.True(t, limiter.allow("client", "klay", "blockNumber"))
+	assert.False(t, limiter.allow("client", "klay", "blockNumber"))
+	assert.True(t, limiter.allow("client", "net", "version"))
+
+	// Clients have their own buckets.
+	assert.True(t, limiter.allow("other", "klay", "blockNumber"))
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server := NewServer()
+	server.limiter = newRateLimiter(RateLimitConfig{Rate: 1, Burst: 1, APIKeys: []string{"key"}})
+	if err := server.RegisterName("test", new(Service)); err != nil {
+		t.Fatal(err)
+	}
+
+	call := func(remote, apiKey string) string {
+		request := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"test_rets"}`))
+		request.Header.Set("content-type", contentType)
+		request.Header.Set(apiKeyHeader, apiKey)
+		request.RemoteAddr = remote
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		return recorder.Body.String()
+	}
+
+	assert.NotContains(t, call("10.0.0.1:1000", ""), "-32005")
+	// The same IP address is limited regardless of its port.
+	assert.Contains(t, call("10.0.0.1:2000", ""), "-32005")
+	assert.NotContains(t, call("10.0.0.2:1000", ""), "-32005")
+
+	// A client with an allowed API key is identified by the key.
+	assert.NotContains(t, call("10.0.0.1:1000", "key"), "-32005")
+	assert.Contains(t, call("10.0.0.3:1000", "key"), "-32005")
+
+	// A client with an unknown API key is identified by its IP address.
+	assert.NotContains(t, call("10.0.0.4:1000", "random1"), "-32005")
+	assert.Contains(t, call("10.0.0.4:1000", "random2"), "-32005")
+}
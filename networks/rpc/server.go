@@ -77,6 +77,8 @@ func NewServer() *Server {
 		codecs:      set.New(),
 		run:         1,
 		wsConnCount: 0,
+		limiter:     newRateLimiter(RateLimit),
+		batch:       BatchLimit,
 	}
 
 	// register a default service which will provide meta information about the RPC service such as the services and
@@ -249,6 +251,21 @@ func (s *Server) serveRequest(ctx context.Context, codec ServerCodec, singleShot
 		//	logger.Error("## request", "method", reqs[0].callb.method.Name,"#call",atomic.LoadInt64(&exeCount))
 		//}
 
+		if batch {
+			if err := s.checkBatchSize(reqs); err != nil {
+				rpcErrorResponsesCounter.Inc(int64(len(reqs)))
+				logger.Debug(fmt.Sprintf("request error %v\n", err))
+				codec.Write(codec.CreateErrorResponse(nil, err))
+				if singleShot {
+					return nil
+				}
+				continue
+			}
+		}
+
+		s.authorizeRequests(ctx, reqs)
+		s.limitRequests(ctx, reqs)
+
 		// If a single shot request is executing, run and return immediately
 		if singleShot {
 			if batch {
@@ -451,34 +468,6 @@ func (s *Server) exec(ctx context.Context, codec ServerCodec, req *serverRequest
 	}
 }
 
-// execBatch executes the given requests and writes the result back using the codec.
-// It will only write the response back when the last request is processed.
-func (s *Server) execBatch(ctx context.Context, codec ServerCodec, requests []*serverRequest, subCnt *int32) {
-	responses := make([]interface{}, len(requests))
-	var callbacks []func()
-	for i, req := range requests {
-		if req.err != nil {
-			rpcErrorResponsesCounter.Inc(1)
-			responses[i] = codec.CreateErrorResponse(&req.id, req.err)
-		} else {
-			var callback func()
-			if responses[i], callback = s.handle(ctx, codec, req, subCnt); callback != nil {
-				callbacks = append(callbacks, callback)
-			}
-		}
-	}
-
-	if err := codec.Write(responses); err != nil {
-		logger.Error(fmt.Sprintf("%v\n", err))
-		codec.Close()
-	}
-
-	// when request holds one of more subscribe requests this allows these subscriptions to be activated
-	for _, c := range callbacks {
-		c()
-	}
-}
-
 // readRequest requests the next (batch) request from the codec. It will return the collection
 // of requests, an indication if the request was a batch, the invalid request identifier and an
 // error when the request could not be read/parsed.
@@ -524,7 +513,7 @@ func (s *Server) readRequest(codec ServerCodec) ([]*serverRequest, bool, Error)
 
 		if r.isPubSub { // eth_subscribe, r.method contains the subscription method name
 			if callb, ok := svc.subscriptions[r.method]; ok {
-				requests[i] = &serverRequest{id: r.id, svcname: svc.name, callb: callb}
+				requests[i] = &serverRequest{id: r.id, svcname: svc.name, method: r.method, callb: callb}
 				if r.params != nil && len(callb.argTypes) > 0 {
 					argTypes := []reflect.Type{reflect.TypeOf("")}
 					argTypes = append(argTypes, callb.argTypes...)
@@ -541,7 +530,7 @@ func (s *Server) readRequest(codec ServerCodec) ([]*serverRequest, bool, Error)
 		}
 
 		if callb, ok := svc.callbacks[r.method]; ok { // lookup RPC method
-			requests[i] = &serverRequest{id: r.id, svcname: svc.name, callb: callb}
+			requests[i] = &serverRequest{id: r.id, svcname: svc.name, method: r.method, callb: callb}
 			if r.params != nil && len(callb.argTypes) > 0 {
 				if args, err := codec.ParseRequestArguments(callb.argTypes, r.params); err == nil {
 					requests[i].args = args
@@ -44,6 +44,37 @@ import (
 	"github.com/klaytn/klaytn/work"
 )
 
+// blockByNumberOrHash returns the block specified by the given block number or hash.
+func blockByNumberOrHash(cn *CN, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block := cn.blockchain.GetBlockByHash(hash)
+		if block == nil {
+			return nil, fmt.Errorf("block %#x not found", hash)
+		}
+		if blockNrOrHash.RequireCanonical && cn.ChainDB().ReadCanonicalHash(block.NumberU64()) != hash {
+			return nil, fmt.Errorf("hash %#x is not currently canonical", hash)
+		}
+		return block, nil
+	}
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	var block *types.Block
+	switch number {
+	case rpc.PendingBlockNumber:
+		return nil, kerrors.ErrPendingBlockNotSupported
+	case rpc.LatestBlockNumber:
+		block = cn.blockchain.CurrentBlock()
+	default:
+		block = cn.blockchain.GetBlockByNumber(uint64(number))
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	return block, nil
+}
+
 // PublicKlayAPI provides an API to access Klaytn CN-related
 // information.
 type PublicKlayAPI struct {
@@ -202,6 +233,24 @@ func (api *PrivateAdminAPI) SaveTrieNodeCacheToDisk() error {
 	return api.cn.BlockChain().SaveTrieNodeCacheToDisk()
 }
 
+// DropTransaction removes the transaction of the given hash from the txpool.
+// The subsequent transactions of the sender are moved back to the queue.
+func (api *PrivateAdminAPI) DropTransaction(hash common.Hash) (bool, error) {
+	if !api.cn.TxPool().DropTx(hash) {
+		return false, fmt.Errorf("transaction %s not found in the txpool", hash.String())
+	}
+	logger.Info("Dropped a transaction from the txpool", "hash", hash)
+	return true, nil
+}
+
+// DropTransactionsFrom removes all the transactions sent from the given account
+// from the txpool, and returns the number of the removed transactions.
+func (api *PrivateAdminAPI) DropTransactionsFrom(addr common.Address) int {
+	dropped := api.cn.TxPool().DropTxsFrom(addr)
+	logger.Info("Dropped transactions from the txpool", "from", addr, "count", dropped)
+	return dropped
+}
+
 // PublicDebugAPI is the collection of Klaytn full node APIs exposed
 // over the public debugging endpoint.
 type PublicDebugAPI struct {
@@ -260,7 +309,7 @@ func (api *PublicDebugAPI) DumpStateTrie(blockNr uint64) (DumpStateTrieResult, e
 	}
 
 	db := state.NewDatabaseWithExistingCache(api.cn.chainDB, api.cn.blockchain.StateCache().TrieDB().TrieNodeCache())
-	stateDB, err := state.New(block.Root(), db)
+	stateDB, err := state.New(block.Root(), db, nil)
 	if err != nil {
 		return DumpStateTrieResult{}, err
 	}
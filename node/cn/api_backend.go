@@ -213,10 +213,18 @@ func (b *CNAPIBackend) TxPoolContent() (map[common.Address]types.Transactions, m
 	return b.cn.TxPool().Content()
 }
 
+func (b *CNAPIBackend) TxPoolContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	return b.cn.TxPool().ContentFrom(addr)
+}
+
 func (b *CNAPIBackend) SubscribeNewTxsEvent(ch chan<- blockchain.NewTxsEvent) event.Subscription {
 	return b.cn.TxPool().SubscribeNewTxsEvent(ch)
 }
 
+func (b *CNAPIBackend) SubscribeTxPoolEvent(ch chan<- blockchain.TxPoolEvent) event.Subscription {
+	return b.cn.TxPool().SubscribeTxPoolEvent(ch)
+}
+
 func (b *CNAPIBackend) Progress() klaytn.SyncProgress {
 	return b.cn.Progress()
 }
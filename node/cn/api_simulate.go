@@ -0,0 +1,301 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	klaytnapi "github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/misc"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/reward"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks simulated by a single klay_simulateBlocks request.
+	maxSimulateBlocks = 256
+	// simulateTimeout is the maximum execution time of a single klay_simulateBlocks request.
+	simulateTimeout = 5 * time.Second
+)
+
+var (
+	errSimulateNoBlocks        = errors.New("no blocks to simulate")
+	errSimulateTooManyBlocks   = fmt.Errorf("too many blocks to simulate (max %d)", maxSimulateBlocks)
+	errSimulateNumberNotAsc    = errors.New("block numbers must be in ascending order")
+	errSimulateTimestampNotAsc = errors.New("block timestamps must be in ascending order")
+)
+
+// SimBlockOverrides is the set of header fields which can be overridden for a simulated block.
+// A field which is not given is derived from the previous block.
+type SimBlockOverrides struct {
+	Number     *hexutil.Big    `json:"number"`
+	Time       *hexutil.Big    `json:"timestamp"`
+	Rewardbase *common.Address `json:"rewardbase"`
+}
+
+// SimBlock is a block to be simulated by klay_simulateBlocks.
+// The state overrides are applied before the calls are executed in order.
+type SimBlock struct {
+	BlockOverrides *SimBlockOverrides       `json:"blockOverrides"`
+	StateOverrides *klaytnapi.StateOverride `json:"stateOverrides"`
+	Calls          []klaytnapi.CallArgs     `json:"calls"`
+}
+
+// SimCallResult is the outcome of a single simulated call.
+type SimCallResult struct {
+	ReturnData hexutil.Bytes       `json:"returnData"`
+	Receipt    *types.Receipt      `json:"receipt"`
+	Error      string              `json:"error,omitempty"`
+	Trace      *vm.InternalTxTrace `json:"trace,omitempty"`
+}
+
+// SimBlockResult is the outcome of a simulated block.
+type SimBlockResult struct {
+	Number     *hexutil.Big     `json:"number"`
+	Hash       common.Hash      `json:"hash"`
+	Timestamp  *hexutil.Big     `json:"timestamp"`
+	Rewardbase common.Address   `json:"rewardbase"`
+	StateRoot  common.Hash      `json:"stateRoot"`
+	GasUsed    hexutil.Uint64   `json:"gasUsed"`
+	Calls      []*SimCallResult `json:"calls"`
+}
+
+// SimulateBlocks executes the calls of the given blocks sequentially on top of the state of
+// the given block (the latest block if not given). The effects of a call are visible to the
+// following calls and blocks, and the block rewards are paid at the end of each block.
+// The senders of simulated calls pay the transaction fees, which are paid out with the block rewards.
+func (api *PublicKlayAPI) SimulateBlocks(ctx context.Context, blocks []SimBlock, blockNrOrHash *rpc.BlockNumberOrHash) ([]*SimBlockResult, error) {
+	if len(blocks) == 0 {
+		return nil, errSimulateNoBlocks
+	}
+	if len(blocks) > maxSimulateBlocks {
+		return nil, errSimulateTooManyBlocks
+	}
+	base := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		base = *blockNrOrHash
+	}
+	parent, err := blockByNumberOrHash(api.cn, base)
+	if err != nil {
+		return nil, err
+	}
+	statedb, err := api.cn.blockchain.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, simulateTimeout)
+	defer cancel()
+
+	results := make([]*SimBlockResult, 0, len(blocks))
+	parentHeader := parent.Header()
+	for i, block := range blocks {
+		header, err := makeSimHeader(parentHeader, block.BlockOverrides)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %v", i, err)
+		}
+		// set the base fee calculated from the parent after the magma fork as the Istanbul engine does
+		if api.cn.chainConfig.IsMagma(header.Number) {
+			kip71, err := api.cn.governance.KIP71ConfigAt(header.Number.Uint64())
+			if err != nil {
+				return nil, fmt.Errorf("block %d: %v", i, err)
+			}
+			header.BaseFee = misc.NextMagmaBlockBaseFee(parentHeader, kip71)
+		}
+		if err := block.StateOverrides.Apply(statedb); err != nil {
+			return nil, fmt.Errorf("block %d: %v", i, err)
+		}
+		result, err := api.simulateBlock(ctx, statedb, header, block.Calls)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %v", i, err)
+		}
+		results = append(results, result)
+		parentHeader = header
+	}
+	return results, nil
+}
+
+// makeSimHeader derives the header of a simulated block from its parent and the given overrides.
+func makeSimHeader(parent *types.Header, overrides *SimBlockOverrides) (*types.Header, error) {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Rewardbase: parent.Rewardbase,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       new(big.Int).Add(parent.Time, common.Big1),
+		BlockScore: common.Big1,
+	}
+	if overrides == nil {
+		return header, nil
+	}
+	if overrides.Number != nil {
+		if overrides.Number.ToInt().Cmp(parent.Number) <= 0 {
+			return nil, errSimulateNumberNotAsc
+		}
+		header.Number = new(big.Int).Set(overrides.Number.ToInt())
+	}
+	if overrides.Time != nil {
+		if overrides.Time.ToInt().Cmp(parent.Time) <= 0 {
+			return nil, errSimulateTimestampNotAsc
+		}
+		header.Time = new(big.Int).Set(overrides.Time.ToInt())
+	}
+	if overrides.Rewardbase != nil {
+		header.Rewardbase = *overrides.Rewardbase
+	}
+	return header, nil
+}
+
+// simulateBlock executes the calls on the given state, distributes the block reward and
+// fills the state root and gas used of the header.
+func (api *PublicKlayAPI) simulateBlock(ctx context.Context, statedb *state.StateDB, header *types.Header, calls []klaytnapi.CallArgs) (*SimBlockResult, error) {
+	results := make([]*SimCallResult, 0, len(calls))
+	receipts := make(types.Receipts, 0, len(calls))
+	for i, args := range calls {
+		result, err := api.simulateCall(ctx, statedb, header, args, i)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %v", i, err)
+		}
+		header.GasUsed += result.Receipt.GasUsed
+		results = append(results, result)
+		receipts = append(receipts, result.Receipt)
+	}
+
+	if api.cn.chainConfig.Istanbul != nil {
+		if err := api.distributeSimReward(statedb, header); err != nil {
+			return nil, err
+		}
+	}
+	header.Root = statedb.IntermediateRoot(true)
+	header.ReceiptHash = types.DeriveSha(receipts)
+	header.Bloom = types.CreateBloom(receipts)
+
+	hash := header.Hash()
+	for i, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			log.BlockHash = hash
+			log.BlockNumber = header.Number.Uint64()
+			log.TxIndex = uint(i)
+		}
+	}
+	return &SimBlockResult{
+		Number:     (*hexutil.Big)(header.Number),
+		Hash:       hash,
+		Timestamp:  (*hexutil.Big)(header.Time),
+		Rewardbase: header.Rewardbase,
+		StateRoot:  header.Root,
+		GasUsed:    hexutil.Uint64(header.GasUsed),
+		Calls:      results,
+	}, nil
+}
+
+// simulateCall executes a single call as the index-th transaction of the simulated block.
+func (api *PublicKlayAPI) simulateCall(ctx context.Context, statedb *state.StateDB, header *types.Header, args klaytnapi.CallArgs, index int) (*SimCallResult, error) {
+	msg, err := args.ToMessage(api.cn.config.RPCGasCap)
+	if err != nil {
+		return nil, err
+	}
+	// The sender pays the fee, so the gas of a call without a gas limit is capped by the balance of the sender.
+	if args.Gas == 0 {
+		allowance := new(big.Int).Div(statedb.GetBalance(msg.ValidatedSender()), msg.GasPrice())
+		if allowance.IsUint64() && allowance.Uint64() < msg.Gas() {
+			args.Gas = hexutil.Uint64(allowance.Uint64())
+			if msg, err = args.ToMessage(api.cn.config.RPCGasCap); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Simulated calls are not signed, so a hash is derived from the position of the call.
+	txHash := crypto.Keccak256Hash(header.Number.Bytes(), new(big.Int).SetInt64(int64(index)).Bytes())
+	statedb.Prepare(txHash, common.Hash{}, index)
+
+	// A plain value transfer does not run the EVM, so there is nothing to trace for it.
+	var (
+		vmConfig = &vm.Config{}
+		tracer   *vm.InternalTxTracer
+	)
+	if msg.To() == nil || statedb.GetCodeSize(*msg.To()) > 0 {
+		tracer = vm.NewInternalTxTracer()
+		vmConfig.Debug, vmConfig.Tracer = true, tracer
+	}
+	from, nonce := msg.ValidatedSender(), statedb.GetNonce(msg.ValidatedSender())
+
+	rewardbase := header.Rewardbase
+	evm := vm.NewEVM(blockchain.NewEVMContext(msg, header, api.cn.blockchain, &rewardbase), statedb, api.cn.chainConfig, vmConfig)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			evm.Cancel(vm.CancelByCtxDone)
+		case <-done:
+		}
+	}()
+
+	ret, gas, kerr := blockchain.ApplyMessage(evm, msg)
+	if kerr.ErrTxInvalid != nil {
+		return nil, kerr.ErrTxInvalid
+	}
+	if evm.Cancelled() {
+		return nil, fmt.Errorf("execution aborted (timeout = %v)", simulateTimeout)
+	}
+	statedb.Finalise(true, false)
+
+	receipt := types.NewReceipt(kerr.Status, txHash, gas)
+	if msg.To() == nil {
+		receipt.ContractAddress = crypto.CreateAddress(from, nonce)
+	}
+	receipt.Logs = statedb.GetLogs(txHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+	result := &SimCallResult{ReturnData: ret, Receipt: receipt}
+	if err := blockchain.GetVMerrFromReceiptStatus(kerr.Status); err != nil {
+		result.Error = err.Error()
+	}
+	if tracer != nil {
+		if result.Trace, err = tracer.GetResult(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// distributeSimReward pays the block reward of a simulated block in the same way as the
+// Istanbul engine does when finalizing a block.
+func (api *PublicKlayAPI) distributeSimReward(statedb *state.StateDB, header *types.Header) error {
+	rewardDistributor := reward.NewRewardDistributor(api.cn.governance)
+	if api.cn.governance.ProposerPolicy() != uint64(istanbul.WeightedRandom) {
+		return rewardDistributor.MintKLAY(statedb, header)
+	}
+	pocAddr, kirAddr := common.Address{}, common.Address{}
+	if stakingInfo := reward.GetStakingInfo(header.Number.Uint64()); stakingInfo != nil {
+		kirAddr = stakingInfo.KIRAddr
+		pocAddr = stakingInfo.PoCAddr
+	}
+	return rewardDistributor.DistributeBlockReward(statedb, header, pocAddr, kirAddr)
+}
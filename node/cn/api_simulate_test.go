@@ -0,0 +1,91 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/kerrors"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeSimHeader(t *testing.T) {
+	parent := &types.Header{Number: big.NewInt(10), Time: big.NewInt(100), Rewardbase: common.Address{0x1}}
+
+	// Without overrides, the header follows the parent.
+	header, err := makeSimHeader(parent, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, parent.Hash(), header.ParentHash)
+	assert.Equal(t, big.NewInt(11), header.Number)
+	assert.Equal(t, big.NewInt(101), header.Time)
+	assert.Equal(t, parent.Rewardbase, header.Rewardbase)
+
+	// Overridden fields are used as given.
+	rewardbase := common.Address{0x2}
+	header, err = makeSimHeader(parent, &SimBlockOverrides{
+		Number:     (*hexutil.Big)(big.NewInt(20)),
+		Time:       (*hexutil.Big)(big.NewInt(200)),
+		Rewardbase: &rewardbase,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(20), header.Number)
+	assert.Equal(t, big.NewInt(200), header.Time)
+	assert.Equal(t, rewardbase, header.Rewardbase)
+
+	// Numbers and timestamps must increase.
+	_, err = makeSimHeader(parent, &SimBlockOverrides{Number: (*hexutil.Big)(big.NewInt(10))})
+	assert.Equal(t, errSimulateNumberNotAsc, err)
+	_, err = makeSimHeader(parent, &SimBlockOverrides{Time: (*hexutil.Big)(big.NewInt(99))})
+	assert.Equal(t, errSimulateTimestampNotAsc, err)
+}
+
+func TestPublicKlayAPI_SimulateBlocks(t *testing.T) {
+	{
+		api := NewPublicKlayAPI(&CN{})
+		results, err := api.SimulateBlocks(context.Background(), nil, nil)
+		assert.Nil(t, results)
+		assert.Equal(t, errSimulateNoBlocks, err)
+	}
+	{
+		api := NewPublicKlayAPI(&CN{})
+		results, err := api.SimulateBlocks(context.Background(), make([]SimBlock, maxSimulateBlocks+1), nil)
+		assert.Nil(t, results)
+		assert.Equal(t, errSimulateTooManyBlocks, err)
+	}
+	{
+		api := NewPublicKlayAPI(&CN{})
+		pending := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+		results, err := api.SimulateBlocks(context.Background(), make([]SimBlock, 1), &pending)
+		assert.Nil(t, results)
+		assert.Equal(t, kerrors.ErrPendingBlockNotSupported, err)
+	}
+	{
+		mockCtrl, _, mockBlockChain, _ := newMocks(t)
+		mockBlockChain.EXPECT().CurrentBlock().Return(nil).Times(1)
+		api := NewPublicKlayAPI(&CN{blockchain: mockBlockChain})
+		results, err := api.SimulateBlocks(context.Background(), make([]SimBlock, 1), nil)
+		assert.Nil(t, results)
+		assert.Error(t, err)
+		mockCtrl.Finish()
+	}
+}
@@ -24,9 +24,11 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"reflect"
 	"runtime"
@@ -61,6 +63,9 @@ const (
 	// fastCallTracer is the go-version callTracer which is lighter and faster than
 	// Javascript version.
 	fastCallTracer = "fastCallTracer"
+
+	// nativeCallTracer is the name of fastCallTracer among the native tracers.
+	nativeCallTracer = "nativeCallTracer"
 )
 
 // TraceConfig holds extra parameters to trace functions.
@@ -69,6 +74,9 @@ type TraceConfig struct {
 	Tracer  *string
 	Timeout *string
 	Reexec  *uint64
+
+	// TracerConfig is the config of the native tracer selected by Tracer.
+	TracerConfig json.RawMessage
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -165,7 +173,7 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 			return nil, fmt.Errorf("parent block #%d not found", number-1)
 		}
 	}
-	statedb, err := state.New(start.Root(), database)
+	statedb, err := state.New(start.Root(), database, nil)
 	if err != nil {
 		// If the starting state is missing, allow some number of blocks to be reexecuted
 		reexec := defaultTraceReexec
@@ -178,7 +186,7 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 			if start == nil {
 				break
 			}
-			if statedb, err = state.New(start.Root(), database); err == nil {
+			if statedb, err = state.New(start.Root(), database, nil); err == nil {
 				break
 			}
 		}
@@ -677,7 +685,7 @@ func (api *PrivateDebugAPI) computeStateDB(block *types.Block, reexec uint64) (*
 	var err error
 
 	for i := uint64(0); i < reexec; i++ {
-		if statedb, err = state.New(block.Root(), database); err == nil {
+		if statedb, err = state.New(block.Root(), database, nil); err == nil {
 			break
 		}
 		blockNumber := block.NumberU64()
@@ -753,6 +761,37 @@ func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Ha
 	return api.traceTx(ctx, msg, vmctx, statedb, config)
 }
 
+// TraceCall lets you trace a given klay_call. It collects the structured logs created
+// during the execution of EVM if the given transaction was added on top of the
+// provided block and returns them as a JSON object.
+func (api *PrivateDebugAPI) TraceCall(ctx context.Context, args klaytnapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
+	// Try to retrieve the specified block
+	block, err := blockByNumberOrHash(api.cn, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, release, err := api.stateAt(block, reexec)
+	defer release()
+	if err != nil {
+		return nil, fmt.Errorf("can not get the state of block %#x: %v", block.Root(), err)
+	}
+
+	// Execute the trace
+	msg, err := args.ToMessage(api.cn.config.RPCGasCap)
+	if err != nil {
+		return nil, err
+	}
+	// Add gas fee to sender for calling a function by insufficient balance sender, as klay_call does.
+	statedb.AddBalance(msg.ValidatedSender(), new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), msg.GasPrice()))
+
+	vmctx := blockchain.NewEVMContext(msg, block.Header(), api.cn.blockchain, nil)
+	return api.traceTx(ctx, msg, vmctx, statedb, config)
+}
+
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
@@ -772,9 +811,15 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message blockchain.Mess
 			}
 		}
 
-		if *config.Tracer == fastCallTracer {
+		switch {
+		case *config.Tracer == fastCallTracer || *config.Tracer == nativeCallTracer:
 			tracer = vm.NewInternalTxTracer()
-		} else {
+		case tracers.IsNativeTracer(*config.Tracer):
+			ctx := &tracers.Context{Message: message, StateDB: statedb}
+			if tracer, err = tracers.NewNativeTracer(*config.Tracer, ctx, config.TracerConfig); err != nil {
+				return nil, err
+			}
+		default:
 			// Constuct the JavaScript tracer to execute with
 			if tracer, err = tracers.New(*config.Tracer); err != nil {
 				return nil, err
@@ -789,6 +834,8 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message blockchain.Mess
 				t.Stop(errors.New("execution timeout"))
 			case *vm.InternalTxTracer:
 				t.Stop(errors.New("execution timeout"))
+			case tracers.NativeTracer:
+				t.Stop(errors.New("execution timeout"))
 			default:
 				logger.Warn("unknown tracer type", "type", reflect.TypeOf(t).String())
 			}
@@ -822,6 +869,8 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message blockchain.Mess
 		return tracer.GetResult()
 	case *vm.InternalTxTracer:
 		return tracer.GetResult()
+	case tracers.NativeTracer:
+		return tracer.GetResult()
 
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
@@ -19,18 +19,27 @@ package cn
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	klaytnapi "github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/gxhash"
 	mocks2 "github.com/klaytn/klaytn/consensus/mocks"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/kerrors"
 	"github.com/klaytn/klaytn/networks/rpc"
 	mocks3 "github.com/klaytn/klaytn/node/cn/mocks"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
 	"github.com/klaytn/klaytn/work/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func createCNMocks(t *testing.T) (*gomock.Controller, *PrivateDebugAPI, *mocks2.MockEngine, *mocks.MockBlockChain, *mocks3.MockMiner) {
@@ -129,6 +138,118 @@ func TestPrivateDebugAPI_TraceBlockByHash(t *testing.T) {
 	mockCtrl.Finish()
 }
 
+func TestPrivateDebugAPI_TraceCall(t *testing.T) {
+	{
+		mockCtrl, api, _, _, _ := createCNMocks(t)
+		sub, err := api.TraceCall(context.Background(), klaytnapi.CallArgs{}, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber), nil)
+		assert.Nil(t, sub)
+		assert.Equal(t, kerrors.ErrPendingBlockNotSupported, err)
+		mockCtrl.Finish()
+	}
+	{
+		mockCtrl, api, _, mockBlockChain, _ := createCNMocks(t)
+		mockBlockChain.EXPECT().CurrentBlock().Return(nil).Times(1)
+		sub, err := api.TraceCall(context.Background(), klaytnapi.CallArgs{}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil)
+		assert.Nil(t, sub)
+		assert.Error(t, err)
+		mockCtrl.Finish()
+	}
+	{
+		mockCtrl, api, _, mockBlockChain, _ := createCNMocks(t)
+		mockBlockChain.EXPECT().GetBlockByHash(hashes[0]).Return(nil).Times(1)
+		sub, err := api.TraceCall(context.Background(), klaytnapi.CallArgs{}, rpc.BlockNumberOrHashWithHash(hashes[0], false), nil)
+		assert.Nil(t, sub)
+		assert.Error(t, err)
+		mockCtrl.Finish()
+	}
+}
+
+// newTraceCallTestChain returns a debug API on a chain where each block sends
+// 1000 peb to the recipient, with a contract which returns the balance of the
+// recipient.
+func newTraceCallTestChain(t *testing.T, numBlocks int) (*PrivateDebugAPI, *blockchain.BlockChain, common.Address) {
+	var (
+		config    = params.TestChainConfig
+		db        = database.NewMemoryDBManager()
+		key, _    = crypto.GenerateKey()
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0xb0b")
+		contract  = common.HexToAddress("0xc0de")
+		signer    = types.NewEIP155Signer(config.ChainID)
+	)
+	// PUSH20 recipient BALANCE PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	code := append(append([]byte{byte(vm.PUSH20)}, recipient.Bytes()...),
+		byte(vm.BALANCE), byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN))
+	gspec := &blockchain.Genesis{
+		Config: config,
+		Alloc: blockchain.GenesisAlloc{
+			sender:   {Balance: big.NewInt(params.KLAY)},
+			contract: {Balance: common.Big0, Code: code},
+		},
+	}
+	genesis := gspec.MustCommit(db)
+	blocks, _ := blockchain.GenerateChain(config, genesis, gxhash.NewFaker(), db, numBlocks, func(i int, gen *blockchain.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(sender), recipient, big.NewInt(1000), params.TxGas, common.Big1, nil), signer, key)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+
+	bc, err := blockchain.NewBlockChain(db, nil, config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	_, err = bc.InsertChain(blocks)
+	require.NoError(t, err)
+
+	cn := &CN{config: &Config{RPCGasCap: big.NewInt(10000000)}, chainConfig: config, blockchain: bc, chainDB: db}
+	return NewPrivateDebugAPI(config, cn), bc, contract
+}
+
+func TestPrivateDebugAPI_TraceCallHistoricalState(t *testing.T) {
+	api, bc, contract := newTraceCallTestChain(t, 3)
+	defer bc.Stop()
+
+	args := klaytnapi.CallArgs{To: &contract, Gas: hexutil.Uint64(100000)}
+	for num := uint64(0); num <= 3; num++ {
+		block := bc.GetBlockByNumber(num)
+		require.NotNil(t, block)
+
+		byNumber, err := api.TraceCall(context.Background(), args, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(num)), nil)
+		require.NoError(t, err)
+		byHash, err := api.TraceCall(context.Background(), args, rpc.BlockNumberOrHashWithHash(block.Hash(), true), nil)
+		require.NoError(t, err)
+		assert.Equal(t, byNumber, byHash)
+
+		// The call sees the balance of the recipient at the given block
+		res, ok := byNumber.(*klaytnapi.ExecutionResult)
+		require.True(t, ok)
+		assert.False(t, res.Failed)
+		assert.Equal(t, fmt.Sprintf("%x", common.BigToHash(big.NewInt(int64(1000*num)))), res.ReturnValue)
+		assert.NotEmpty(t, res.StructLogs)
+		assert.Equal(t, "RETURN", res.StructLogs[len(res.StructLogs)-1].Op)
+	}
+}
+
+func TestPrivateDebugAPI_TraceCallNativeCallTracer(t *testing.T) {
+	api, bc, contract := newTraceCallTestChain(t, 1)
+	defer bc.Stop()
+
+	args := klaytnapi.CallArgs{To: &contract, Gas: hexutil.Uint64(100000)}
+	trace := func(name string) interface{} {
+		res, err := api.TraceCall(context.Background(), args, rpc.BlockNumberOrHashWithNumber(1), &TraceConfig{Tracer: &name})
+		require.NoError(t, err)
+		return res
+	}
+	// The native call tracer is the fast call tracer
+	res, ok := trace(nativeCallTracer).(*vm.InternalTxTrace)
+	require.True(t, ok)
+	assert.Equal(t, "CALL", res.Type)
+	assert.Equal(t, contract, *res.To)
+	assert.Equal(t, fmt.Sprintf("0x%x", common.BigToHash(big.NewInt(1000))), res.Output)
+
+	expected := trace(fastCallTracer).(*vm.InternalTxTrace)
+	res.Time, expected.Time = 0, 0
+	assert.Equal(t, expected, res)
+}
+
 func TestPrivateDebugAPI_TraceBlock(t *testing.T) {
 	mockCtrl, api, _, _, _ := createCNMocks(t)
 	sub, err := api.TraceBlock(context.Background(), hexutil.Bytes{}, nil)
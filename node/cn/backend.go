@@ -45,6 +45,7 @@ import (
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/networks/grpc"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/node"
@@ -103,6 +104,7 @@ type CN struct {
 
 	// Handlers
 	txPool          work.TxPool
+	txPoolMirror    *txPoolMirror
 	blockchain      work.BlockChain
 	protocolManager BackendProtocolManager
 	lesServer       LesServer
@@ -251,7 +253,9 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		vmConfig    = config.getVMConfig()
 		cacheConfig = &blockchain.CacheConfig{ArchiveMode: config.NoPruning, CacheSize: config.TrieCacheSize,
 			BlockInterval: config.TrieBlockInterval, TriesInMemory: config.TriesInMemory,
-			TrieNodeCacheConfig: &config.TrieNodeCacheConfig, SenderTxHashIndexing: config.SenderTxHashIndexing}
+			TrieNodeCacheConfig: &config.TrieNodeCacheConfig, SenderTxHashIndexing: config.SenderTxHashIndexing,
+			SnapshotCacheSize: config.SnapshotCacheSize, SnapshotAsyncGen: config.SnapshotAsyncGen,
+			LivePruning: config.LivePruning, LivePruningRetention: config.LivePruningRetention}
 	)
 
 	bc, err := blockchain.NewBlockChain(chainDB, cacheConfig, cn.chainConfig, cn.engine, vmConfig)
@@ -289,7 +293,14 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	}
 	// TODO-Klaytn-ServiceChain: add account creation prevention in the txPool if TxTypeAccountCreation is supported.
 	config.TxPool.NoAccountCreation = config.NoAccountCreation
-	cn.txPool = blockchain.NewTxPool(config.TxPool, cn.chainConfig, bc)
+	txPool := blockchain.NewTxPool(config.TxPool, cn.chainConfig, bc)
+	if config.TxPool.Persist {
+		txPool.EnablePersistence(chainDB)
+	}
+	cn.txPool = txPool
+	if config.TxPool.MirrorSource != "" {
+		cn.txPoolMirror = newTxPoolMirror(config.TxPool.MirrorSource, cn.txPool)
+	}
 	governance.SetTxPool(cn.txPool)
 	// Synchronize unitprice
 	cn.txPool.SetGasPrice(big.NewInt(0).SetUint64(governance.UnitPrice()))
@@ -449,7 +460,8 @@ func makeExtraData(extra []byte) []byte {
 func CreateDB(ctx *node.ServiceContext, config *Config, name string) database.DBManager {
 	dbc := &database.DBConfig{Dir: name, DBType: config.DBType, ParallelDBWrite: config.ParallelDBWrite, SingleDB: config.SingleDB, NumStateTrieShards: config.NumStateTrieShards,
 		LevelDBCacheSize: config.LevelDBCacheSize, OpenFilesLimit: database.GetOpenFilesLimit(), LevelDBCompression: config.LevelDBCompression,
-		LevelDBBufferPool: config.LevelDBBufferPool, EnableDBPerfMetrics: config.EnableDBPerfMetrics, DynamoDBConfig: &config.DynamoDBConfig}
+		LevelDBBufferPool: config.LevelDBBufferPool, EnableDBPerfMetrics: config.EnableDBPerfMetrics, DynamoDBConfig: &config.DynamoDBConfig,
+		AncientThreshold: config.AncientThreshold}
 	return ctx.OpenDatabase(dbc)
 }
 
@@ -519,6 +531,11 @@ func (s *CN) APIs() []rpc.API {
 	}...)
 }
 
+// KlayAPIBackend returns the backend serving the typed KlayAPI gRPC service.
+func (s *CN) KlayAPIBackend() grpc.Backend {
+	return s.APIBackend
+}
+
 func (s *CN) ResetWithGenesisBlock(gb *types.Block) {
 	s.blockchain.ResetWithGenesisBlock(gb)
 }
@@ -633,6 +650,9 @@ func (s *CN) Start(srvr p2p.Server) error {
 
 	reward.StakingManagerSubscribe()
 
+	if s.txPoolMirror != nil {
+		s.txPoolMirror.start()
+	}
 	return nil
 }
 
@@ -648,6 +668,9 @@ func (s *CN) Stop() error {
 	// Then stop everything else.
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	if s.txPoolMirror != nil {
+		s.txPoolMirror.stop()
+	}
 	s.txPool.Stop()
 	s.miner.Stop()
 	reward.StakingManagerUnsubscribe()
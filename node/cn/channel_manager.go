@@ -66,6 +66,10 @@ func NewChannelManager(channelSize int) *ChannelManager {
 	channelMgr.RegisterMsgCode(MiscChannel, StatusMsg)
 	channelMgr.RegisterMsgCode(MiscChannel, NodeDataRequestMsg)
 	channelMgr.RegisterMsgCode(MiscChannel, NodeDataMsg)
+	channelMgr.RegisterMsgCode(MiscChannel, AccountRangeRequestMsg)
+	channelMgr.RegisterMsgCode(MiscChannel, AccountRangeMsg)
+	channelMgr.RegisterMsgCode(MiscChannel, StorageRangesRequestMsg)
+	channelMgr.RegisterMsgCode(MiscChannel, StorageRangesMsg)
 
 	return channelMgr
 }
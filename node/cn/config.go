@@ -113,6 +113,7 @@ type Config struct {
 	LevelDBBufferPool    bool
 	LevelDBCacheSize     int
 	DynamoDBConfig       database.DynamoDBConfig
+	AncientThreshold     uint64
 	TrieCacheSize        int
 	TrieTimeout          time.Duration
 	TrieBlockInterval    uint
@@ -120,6 +121,10 @@ type Config struct {
 	SenderTxHashIndexing bool
 	ParallelDBWrite      bool
 	TrieNodeCacheConfig  statedb.TrieNodeCacheConfig
+	SnapshotCacheSize    int
+	SnapshotAsyncGen     bool
+	LivePruning          bool
+	LivePruningRetention uint64
 
 	// Mining-related options
 	ServiceChainSigner common.Address `toml:",omitempty"`
@@ -42,9 +42,11 @@ import (
 var (
 	deadline = 5 * time.Minute // consider a filter inactive if it has not been polled for within deadline
 
-	getLogsCxtKeyMaxItems = "maxItems"       // the value of the context key should have the type of GetLogsMaxItems
-	GetLogsDeadline       = 10 * time.Second // execution deadlines for getLogs and getFilterLogs APIs
-	GetLogsMaxItems       = int(10000)       // maximum allowed number of return items for getLogs and getFilterLogs APIs
+	getLogsCxtKeyMaxItems     = "maxItems"       // the value of the context key should have the type of GetLogsMaxItems
+	getLogsCxtKeyMaxBlockSpan = "maxBlockSpan"   // the value of the context key should have the type of GetLogsMaxBlockSpan
+	GetLogsDeadline           = 10 * time.Second // execution deadlines for getLogs and getFilterLogs APIs
+	GetLogsMaxItems           = int(10000)       // maximum allowed number of return items for getLogs and getFilterLogs APIs
+	GetLogsMaxBlockSpan       = uint64(0)        // maximum allowed number of blocks queried by getLogs and getFilterLogs APIs (0 = no limit)
 )
 
 // filter is a helper struct that holds meta information over the filter type
@@ -292,6 +294,9 @@ type FilterCriteria klaytn.FilterQuery
 //
 // In case "fromBlock" > "toBlock" an error is returned.
 func (api *PublicFilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
+	if err := checkLimit(crit.Limit); err != nil {
+		return rpc.ID(""), err
+	}
 	logs := make(chan []*types.Log)
 	logsSub, err := api.events.SubscribeLogs(klaytn.FilterQuery(crit), logs)
 	if err != nil {
@@ -324,9 +329,13 @@ func (api *PublicFilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 }
 
 // GetLogs returns logs matching the given argument that are stored within the state.
+// If limit is set, at most limit logs after the cursor are returned. The position of the
+// last returned log is used as the cursor to get the next page.
 func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
-	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
-	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	if err := checkLimit(crit.Limit); err != nil {
+		return nil, err
+	}
+	ctx, cancelFnc := WithGetLogsLimits(ctx)
 	defer cancelFnc()
 
 	// Convert the RPC block numbers into internal representations
@@ -339,6 +348,7 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([
 
 	// Create and run the filter to get all the logs
 	filter := NewRangeFilter(api.backend, crit.FromBlock.Int64(), crit.ToBlock.Int64(), crit.Addresses, crit.Topics)
+	filter.SetPage(crit.Cursor, crit.Limit)
 
 	logs, err := filter.Logs(ctx)
 	if err != nil {
@@ -365,8 +375,7 @@ func (api *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
 // GetFilterLogs returns the logs for the filter with the given id.
 // If the filter could not be found an empty array of logs is returned.
 func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*types.Log, error) {
-	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
-	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	ctx, cancelFnc := WithGetLogsLimits(ctx)
 	defer cancelFnc()
 
 	api.filtersMu.Lock()
@@ -387,6 +396,7 @@ func (api *PublicFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*ty
 	}
 	// Create and run the filter to get all the logs
 	filter := NewRangeFilter(api.backend, begin, end, f.crit.Addresses, f.crit.Topics)
+	filter.SetPage(f.crit.Cursor, f.crit.Limit)
 
 	logs, err := filter.Logs(ctx)
 	if err != nil {
@@ -427,6 +437,26 @@ func (api *PublicFilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
 	return []interface{}{}, fmt.Errorf("filter not found")
 }
 
+// WithGetLogsLimits returns a copy of the context carrying the item and block span
+// limits and the deadline of the getLogs APIs. The returned cancel function should
+// be called when the query is done.
+func WithGetLogsLimits(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
+	ctx = context.WithValue(ctx, getLogsCxtKeyMaxBlockSpan, GetLogsMaxBlockSpan)
+	return context.WithTimeout(ctx, GetLogsDeadline)
+}
+
+// checkLimit returns an error if the given page size is not allowed.
+func checkLimit(limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid limit %d", limit)
+	}
+	if limit > GetLogsMaxItems {
+		return fmt.Errorf("limit %d exceeds the maximum of %d", limit, GetLogsMaxItems)
+	}
+	return nil
+}
+
 // returnHashes is a helper that will return an empty hash array case the given hash array is nil,
 // otherwise the given hashes array is returned.
 func returnHashes(hashes []common.Hash) []common.Hash {
@@ -452,6 +482,11 @@ func (args *FilterCriteria) UnmarshalJSON(data []byte) error {
 		ToBlock   *rpc.BlockNumber `json:"toBlock"`
 		Addresses interface{}      `json:"address"`
 		Topics    []interface{}    `json:"topics"`
+		Cursor    *struct {
+			BlockNumber hexutil.Uint64 `json:"blockNumber"`
+			LogIndex    hexutil.Uint   `json:"logIndex"`
+		} `json:"cursor"`
+		Limit *hexutil.Uint `json:"limit"`
 	}
 
 	var raw input
@@ -475,6 +510,14 @@ func (args *FilterCriteria) UnmarshalJSON(data []byte) error {
 		args.ToBlock = big.NewInt(raw.ToBlock.Int64())
 	}
 
+	if raw.Cursor != nil {
+		args.Cursor = &klaytn.LogCursor{BlockNumber: uint64(raw.Cursor.BlockNumber), Index: uint(raw.Cursor.LogIndex)}
+	}
+
+	if raw.Limit != nil {
+		args.Limit = int(*raw.Limit)
+	}
+
 	args.Addresses = []common.Address{}
 
 	if raw.Addresses != nil {
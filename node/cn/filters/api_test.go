@@ -186,4 +186,17 @@ func TestUnmarshalJSONNewFilterArgs(t *testing.T) {
 	if len(test7.Topics[2]) != 0 {
 		t.Fatalf("expected 0 topics, got %d topics", len(test7.Topics[2]))
 	}
+
+	// cursor and limit
+	var test8 FilterCriteria
+	vector = `{"cursor": {"blockNumber": "0x10", "logIndex": "0x2"}, "limit": "0x64"}`
+	if err := json.Unmarshal([]byte(vector), &test8); err != nil {
+		t.Fatal(err)
+	}
+	if test8.Cursor == nil || test8.Cursor.BlockNumber != 0x10 || test8.Cursor.Index != 0x2 {
+		t.Fatalf("expected cursor {16 2}, got %v", test8.Cursor)
+	}
+	if test8.Limit != 100 {
+		t.Fatalf("expected limit 100, got %d", test8.Limit)
+	}
 }
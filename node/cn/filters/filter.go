@@ -23,10 +23,12 @@ package filters
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"strconv"
 
+	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/bloombits"
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -36,6 +38,11 @@ import (
 	"github.com/klaytn/klaytn/storage/database"
 )
 
+var (
+	errQueryTimeout = errors.New("query timeout exceeded. Narrow the block range, or set limit to page through the results")
+	errUnknownBlock = errors.New("unknown block")
+)
+
 //go:generate mockgen -destination=node/cn/filters/mock/backend_mock.go -package=cn github.com/klaytn/klaytn/node/cn/filters Backend
 type Backend interface {
 	ChainDB() database.DBManager
@@ -57,11 +64,15 @@ type Backend interface {
 type Filter struct {
 	backend Backend
 
-	begin, end int64
+	block      common.Hash // Block hash if filtering a single block
+	begin, end int64       // Range interval if filtering multiple blocks
 	addresses  []common.Address
 	topics     [][]common.Hash
 
 	matcher *bloombits.Matcher
+
+	cursor *klaytn.LogCursor // only the logs after the cursor are returned if not nil
+	limit  int               // the maximum number of returned logs if not zero
 }
 
 // NewRangeFilter creates a new filter which uses a bloom filter on blocks to
@@ -98,6 +109,15 @@ func NewRangeFilter(backend Backend, begin, end int64, addresses []common.Addres
 	return filter
 }
 
+// NewBlockFilter creates a new filter which directly inspects the contents of
+// a block to figure out whether it is interesting or not.
+func NewBlockFilter(backend Backend, block common.Hash, addresses []common.Address, topics [][]common.Hash) *Filter {
+	// Create a generic filter and convert it into a block filter
+	filter := newFilter(backend, addresses, topics)
+	filter.block = block
+	return filter
+}
+
 // newFilter creates a generic filter that can either filter based on a block hash,
 // or based on range queries. The search criteria needs to be explicitly set.
 func newFilter(backend Backend, addresses []common.Address, topics [][]common.Hash) *Filter {
@@ -108,9 +128,20 @@ func newFilter(backend Backend, addresses []common.Address, topics [][]common.Ha
 	}
 }
 
+// SetPage makes the filter return at most limit logs after the cursor.
+// A nil cursor means the beginning of the range, and zero limit means no limit.
+func (f *Filter) SetPage(cursor *klaytn.LogCursor, limit int) {
+	f.cursor = cursor
+	f.limit = limit
+}
+
 // Logs searches the blockchain for matching log entries, returning all from the
 // first block that contains matches, updating the start of the filter accordingly.
 func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
+	// If we're doing singleton block filtering, execute and return
+	if f.block != (common.Hash{}) {
+		return f.blockLogs(ctx)
+	}
 	// Figure out the limits of the filter range
 	header, _ := f.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if header == nil {
@@ -125,6 +156,13 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	if f.end == -1 {
 		end = head
 	}
+	if f.cursor != nil && int64(f.cursor.BlockNumber) > f.begin {
+		f.begin = int64(f.cursor.BlockNumber)
+	}
+	if maxBlockSpan := getMaxBlockSpan(ctx); end >= uint64(f.begin) && end-uint64(f.begin) >= maxBlockSpan {
+		return nil, fmt.Errorf("query range of %d blocks exceeds the limit of %d blocks. "+
+			"Split the query into smaller block ranges", end-uint64(f.begin)+1, maxBlockSpan)
+	}
 	// Gather all indexed logs, and finish with non indexed ones
 	var (
 		logs []*types.Log
@@ -137,15 +175,65 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		} else {
 			logs, err = f.indexedLogs(ctx, indexed-1)
 		}
-		if err != nil {
+		if err != nil || f.pageFilled(logs) {
 			return logs, err
 		}
 	}
 	rest, err := f.unindexedLogs(ctx, end)
 	logs = append(logs, rest...)
+	if f.limit > 0 && len(logs) > f.limit {
+		logs = logs[:f.limit]
+	}
 	return logs, err
 }
 
+// blockLogs returns the logs matching the filter criteria within a single block.
+func (f *Filter) blockLogs(ctx context.Context) ([]*types.Log, error) {
+	db := f.backend.ChainDB()
+	number := db.ReadHeaderNumber(f.block)
+	if number == nil {
+		return nil, errUnknownBlock
+	}
+	header := db.ReadHeader(f.block, *number)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	if !bloomFilter(header.Bloom, f.addresses, f.topics) {
+		return nil, nil
+	}
+	found, err := f.checkMatches(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	logs := f.afterCursor(found)
+	if f.pageFilled(logs) {
+		return logs[:f.limit], nil
+	}
+	if maxItems := getMaxItems(ctx); len(logs) > maxItems {
+		return nil, errTooManyLogs(maxItems)
+	}
+	return logs, nil
+}
+
+// pageFilled returns true if the logs fill up the page of the filter.
+func (f *Filter) pageFilled(logs []*types.Log) bool {
+	return f.limit > 0 && len(logs) >= f.limit
+}
+
+// afterCursor returns the logs after the cursor of the filter.
+func (f *Filter) afterCursor(logs []*types.Log) []*types.Log {
+	if f.cursor == nil {
+		return logs
+	}
+	var ret []*types.Log
+	for _, log := range logs {
+		if log.BlockNumber > f.cursor.BlockNumber || (log.BlockNumber == f.cursor.BlockNumber && log.Index > f.cursor.Index) {
+			ret = append(ret, log)
+		}
+	}
+	return ret
+}
+
 // indexedLogs returns the logs matching the filter criteria based on the bloom
 // bits indexed available locally or via the network.
 func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
@@ -187,13 +275,16 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, err
 			if err != nil {
 				return logs, err
 			}
-			logs = append(logs, found...)
+			logs = append(logs, f.afterCursor(found)...)
+			if f.pageFilled(logs) {
+				return logs[:f.limit], nil
+			}
 			if len(logs) > maxItems {
-				return logs, errors.New("query returned more than " + strconv.Itoa(maxItems) + " results")
+				return logs, errTooManyLogs(maxItems)
 			}
 		case <-ctx.Done():
 			if ctx.Err() == context.DeadlineExceeded {
-				return logs, errors.New("query timeout exceeded")
+				return logs, errQueryTimeout
 			}
 			return logs, errors.New("query is canceled. " + ctx.Err().Error())
 		}
@@ -217,15 +308,18 @@ func (f *Filter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, e
 			if err != nil {
 				return logs, err
 			}
-			logs = append(logs, found...)
+			logs = append(logs, f.afterCursor(found)...)
+			if f.pageFilled(logs) {
+				return logs[:f.limit], nil
+			}
 			if len(logs) > maxItems {
-				return logs, errors.New("query returned more than " + strconv.Itoa(maxItems) + " results")
+				return logs, errTooManyLogs(maxItems)
 			}
 		}
 		select {
 		case <-ctx.Done():
 			if ctx.Err() == context.DeadlineExceeded {
-				return logs, errors.New("query timeout exceeded")
+				return logs, errQueryTimeout
 			}
 			return logs, errors.New("query is canceled. " + ctx.Err().Error())
 		default:
@@ -272,6 +366,11 @@ func includes(addresses []common.Address, a common.Address) bool {
 	return false
 }
 
+// FilterLogs returns the logs matching the given addresses and topics.
+func FilterLogs(logs []*types.Log, addresses []common.Address, topics [][]common.Hash) []*types.Log {
+	return filterLogs(logs, nil, nil, addresses, topics)
+}
+
 // filterLogs creates a slice of logs matching the given criteria.
 func filterLogs(logs []*types.Log, fromBlock, toBlock *big.Int, addresses []common.Address, topics [][]common.Hash) []*types.Log {
 	var ret []*types.Log
@@ -337,6 +436,25 @@ func bloomFilter(bloom types.Bloom, addresses []common.Address, topics [][]commo
 	return true
 }
 
+// errTooManyLogs returns an error telling that the query returned more than the given number of logs.
+func errTooManyLogs(maxItems int) error {
+	return errors.New("query returned more than " + strconv.Itoa(maxItems) + " results. " +
+		"Set limit to page through the results, passing the position of the last log of a page as the cursor of the next page")
+}
+
+// getMaxBlockSpan returns the value of getLogsCxtKeyMaxBlockSpan set in the given context.
+// If the value is not set in the context or is zero, it will returns MaxUint64.
+func getMaxBlockSpan(ctx context.Context) uint64 {
+	maxBlockSpan := uint64(math.MaxUint64)
+	if val := ctx.Value(getLogsCxtKeyMaxBlockSpan); val != nil {
+		val, ok := val.(uint64)
+		if ok && val > 0 {
+			maxBlockSpan = val
+		}
+	}
+	return maxBlockSpan
+}
+
 // getMaxItems returns the value of getLogsCxtKeyMaxItems set in the given context.
 // If the value is not set in the context, it will returns MaxInt32-1.
 func getMaxItems(ctx context.Context) int {
@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
@@ -351,3 +352,71 @@ func TestFilters(t *testing.T) {
 		t.Error("expected 0 log, got", len(logs))
 	}
 }
+
+func TestFiltersPagination(t *testing.T) {
+	var (
+		db         = database.NewMemoryDBManager()
+		mux        = new(event.TypeMux)
+		txFeed     = new(event.Feed)
+		rmLogsFeed = new(event.Feed)
+		logsFeed   = new(event.Feed)
+		chainFeed  = new(event.Feed)
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		key1, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr       = crypto.PubkeyToAddress(key1.PublicKey)
+		topic      = common.BytesToHash([]byte("topic"))
+	)
+	defer db.Close()
+
+	// Blocks 2, 3 and 5 have two logs each.
+	genesis := blockchain.GenesisBlockForTesting(db, addr, big.NewInt(1000000))
+	chain, receipts := blockchain.GenerateChain(params.TestChainConfig, genesis, gxhash.NewFaker(), db, 10, func(i int, gen *blockchain.BlockGen) {
+		if i != 1 && i != 2 && i != 4 {
+			return
+		}
+		receipt := genReceipt(false, 0)
+		receipt.Logs = []*types.Log{
+			{Address: addr, Topics: []common.Hash{topic}, BlockNumber: uint64(i + 1), Index: 0},
+			{Address: addr, Topics: []common.Hash{topic}, BlockNumber: uint64(i + 1), Index: 1},
+		}
+		gen.AddUncheckedReceipt(receipt)
+		gen.AddUncheckedTx(types.NewTransaction(uint64(i), common.HexToAddress("0x1"), big.NewInt(1), 1, big.NewInt(1), nil))
+	})
+	for i, block := range chain {
+		db.WriteBlock(block)
+		db.WriteCanonicalHash(block.Hash(), block.NumberU64())
+		db.WriteHeadBlockHash(block.Hash())
+		db.WriteReceipts(block.Hash(), block.NumberU64(), receipts[i])
+	}
+
+	// Page through the logs three at a time, using the last log of a page as the next cursor.
+	var (
+		cursor *klaytn.LogCursor
+		pages  [][]*types.Log
+	)
+	for {
+		filter := NewRangeFilter(backend, 0, -1, []common.Address{addr}, nil)
+		filter.SetPage(cursor, 3)
+		logs, err := filter.Logs(context.Background())
+		assert.NoError(t, err)
+		if len(logs) == 0 {
+			break
+		}
+		pages = append(pages, logs)
+		last := logs[len(logs)-1]
+		cursor = &klaytn.LogCursor{BlockNumber: last.BlockNumber, Index: last.Index}
+	}
+	assert.Equal(t, 2, len(pages))
+	assert.Equal(t, 3, len(pages[0]))
+	assert.Equal(t, 3, len(pages[1]))
+	assert.Equal(t, uint64(3), pages[1][0].BlockNumber)
+	assert.Equal(t, uint(1), pages[1][0].Index)
+
+	// A query over more blocks than the limit is rejected.
+	ctx := context.WithValue(context.Background(), getLogsCxtKeyMaxBlockSpan, uint64(5))
+	_, err := NewRangeFilter(backend, 0, -1, []common.Address{addr}, nil).Logs(ctx)
+	assert.Error(t, err)
+	logs, err := NewRangeFilter(backend, 6, -1, []common.Address{addr}, nil).Logs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(logs))
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
 )
 
@@ -76,6 +77,12 @@ func NewOracle(backend api.Backend, params Config) *Oracle {
 
 // SuggestPrice returns the recommended gas price.
 func (gpo *Oracle) SuggestPrice(ctx context.Context) (*big.Int, error) {
+	// NOTE-Klaytn After the magma hard fork, the base fee is charged instead of the gas price. The price suggested
+	//         is twice the base fee of the latest block, so it still covers the base fee of the next blocks while it
+	//         rises, but it does not exceed the upper bound of the base fee.
+	if head, err := gpo.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber); err == nil && head != nil && head.BaseFee != nil {
+		return suggestPriceAboveBaseFee(head.BaseFee, gpo.backend.ChainConfig()), nil
+	}
 
 	// NOTE-Klaytn We use invariant ChainConfig.UnitPrice and this value
 	//         will not be changed until ChainConfig.UnitPrice is updated with governance.
@@ -156,6 +163,26 @@ func (gpo *Oracle) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	*/
 }
 
+// suggestPriceAboveBaseFee returns twice the given base fee, capped at the upper bound of the base fee.
+// The price is never lower than the given base fee.
+func suggestPriceAboveBaseFee(baseFee *big.Int, config *params.ChainConfig) *big.Int {
+	var kip71 *params.KIP71Config
+	if config != nil {
+		kip71 = config.Governance.KIP71Config()
+	} else {
+		kip71 = params.GetDefaultKIP71Config()
+	}
+
+	price := new(big.Int).Mul(baseFee, common.Big2)
+	if upper := new(big.Int).SetUint64(kip71.UpperBoundBaseFee); price.Cmp(upper) > 0 {
+		price = upper
+	}
+	if price.Cmp(baseFee) < 0 {
+		price = new(big.Int).Set(baseFee)
+	}
+	return price
+}
+
 // TODO-Klaytn-RemoveLater Later remove below obsolete code if we don't need them anymore.
 //type getBlockPricesResult struct {
 //	price *big.Int
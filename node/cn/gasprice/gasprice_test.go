@@ -22,6 +22,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/consensus/misc"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,6 +83,7 @@ func TestGasPrice_SuggestPrice(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(&types.Header{}, nil).AnyTimes()
 	params := Config{}
 	oracle := NewOracle(mockBackend, params)
 
@@ -93,3 +98,56 @@ func TestGasPrice_SuggestPrice(t *testing.T) {
 	assert.Equal(t, big.NewInt(123), price)
 	assert.Nil(t, err)
 }
+
+func TestGasPrice_SuggestPriceBaseFee(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	header := &types.Header{BaseFee: big.NewInt(30 * params.Ston)}
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(header, nil).Times(1)
+	mockBackend.EXPECT().ChainConfig().Return(params.TestChainConfig).Times(1)
+	oracle := NewOracle(mockBackend, Config{Default: big.NewInt(123)})
+
+	price, err := oracle.SuggestPrice(nil)
+	assert.Equal(t, big.NewInt(60*params.Ston), price)
+	assert.Nil(t, err)
+}
+
+func TestGasPrice_SuggestPriceRisingBaseFee(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	kip71 := params.GetDefaultKIP71Config()
+
+	// Every block uses the maximum gas, so the base fee rises as fast as possible
+	header := &types.Header{BaseFee: big.NewInt(30 * params.Ston), GasUsed: kip71.MaxBlockGasUsedForBaseFee}
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(header, nil).Times(1)
+	mockBackend.EXPECT().ChainConfig().Return(params.TestChainConfig).Times(1)
+	oracle := NewOracle(mockBackend, Config{Default: big.NewInt(123)})
+
+	price, err := oracle.SuggestPrice(nil)
+	assert.Nil(t, err)
+
+	// The suggested price should still be accepted after the base fee rises for a few blocks
+	for i := 0; i < 3; i++ {
+		header = &types.Header{BaseFee: misc.NextMagmaBlockBaseFee(header, kip71), GasUsed: kip71.MaxBlockGasUsedForBaseFee}
+		assert.True(t, price.Cmp(header.BaseFee) >= 0, "block %d: price %v, base fee %v", i+1, price, header.BaseFee)
+	}
+}
+
+func TestGasPrice_SuggestPriceUpperBound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	upper := new(big.Int).SetUint64(params.GetDefaultKIP71Config().UpperBoundBaseFee)
+
+	// The price does not exceed the upper bound of the base fee
+	header := &types.Header{BaseFee: new(big.Int).Sub(upper, big.NewInt(1))}
+	mockBackend.EXPECT().HeaderByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(header, nil).Times(1)
+	mockBackend.EXPECT().ChainConfig().Return(params.TestChainConfig).Times(1)
+	oracle := NewOracle(mockBackend, Config{Default: big.NewInt(123)})
+
+	price, err := oracle.SuggestPrice(nil)
+	assert.Equal(t, upper, price)
+	assert.Nil(t, err)
+}
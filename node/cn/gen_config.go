@@ -37,6 +37,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		LevelDBBufferPool       bool
 		LevelDBCacheSize        int
 		DynamoDBConfig          database.DynamoDBConfig
+		AncientThreshold        uint64
 		TrieCacheSize           int
 		TrieTimeout             time.Duration
 		TrieBlockInterval       uint
@@ -44,6 +45,10 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		SenderTxHashIndexing    bool
 		ParallelDBWrite         bool
 		TrieNodeCacheConfig     statedb.TrieNodeCacheConfig
+		SnapshotCacheSize       int
+		SnapshotAsyncGen        bool
+		LivePruning             bool
+		LivePruningRetention    uint64
 		ServiceChainSigner      common.Address `toml:",omitempty"`
 		ExtraData               hexutil.Bytes  `toml:",omitempty"`
 		GasPrice                *big.Int
@@ -81,6 +86,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.LevelDBBufferPool = c.LevelDBBufferPool
 	enc.LevelDBCacheSize = c.LevelDBCacheSize
 	enc.DynamoDBConfig = c.DynamoDBConfig
+	enc.AncientThreshold = c.AncientThreshold
 	enc.TrieCacheSize = c.TrieCacheSize
 	enc.TrieTimeout = c.TrieTimeout
 	enc.TrieBlockInterval = c.TrieBlockInterval
@@ -88,6 +94,10 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.SenderTxHashIndexing = c.SenderTxHashIndexing
 	enc.ParallelDBWrite = c.ParallelDBWrite
 	enc.TrieNodeCacheConfig = c.TrieNodeCacheConfig
+	enc.SnapshotCacheSize = c.SnapshotCacheSize
+	enc.SnapshotAsyncGen = c.SnapshotAsyncGen
+	enc.LivePruning = c.LivePruning
+	enc.LivePruningRetention = c.LivePruningRetention
 	enc.ServiceChainSigner = c.ServiceChainSigner
 	enc.ExtraData = c.ExtraData
 	enc.GasPrice = c.GasPrice
@@ -129,6 +139,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		LevelDBBufferPool       *bool
 		LevelDBCacheSize        *int
 		DynamoDBConfig          *database.DynamoDBConfig
+		AncientThreshold        *uint64
 		TrieCacheSize           *int
 		TrieTimeout             *time.Duration
 		TrieBlockInterval       *uint
@@ -136,6 +147,10 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		SenderTxHashIndexing    *bool
 		ParallelDBWrite         *bool
 		TrieNodeCacheConfig     *statedb.TrieNodeCacheConfig
+		SnapshotCacheSize       *int
+		SnapshotAsyncGen        *bool
+		LivePruning             *bool
+		LivePruningRetention    *uint64
 		ServiceChainSigner      *common.Address `toml:",omitempty"`
 		ExtraData               *hexutil.Bytes  `toml:",omitempty"`
 		GasPrice                *big.Int
@@ -208,6 +223,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DynamoDBConfig != nil {
 		c.DynamoDBConfig = *dec.DynamoDBConfig
 	}
+	if dec.AncientThreshold != nil {
+		c.AncientThreshold = *dec.AncientThreshold
+	}
 	if dec.TrieCacheSize != nil {
 		c.TrieCacheSize = *dec.TrieCacheSize
 	}
@@ -229,6 +247,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TrieNodeCacheConfig != nil {
 		c.TrieNodeCacheConfig = *dec.TrieNodeCacheConfig
 	}
+	if dec.SnapshotCacheSize != nil {
+		c.SnapshotCacheSize = *dec.SnapshotCacheSize
+	}
+	if dec.SnapshotAsyncGen != nil {
+		c.SnapshotAsyncGen = *dec.SnapshotAsyncGen
+	}
+	if dec.LivePruning != nil {
+		c.LivePruning = *dec.LivePruning
+	}
+	if dec.LivePruningRetention != nil {
+		c.LivePruningRetention = *dec.LivePruningRetention
+	}
 	if dec.ServiceChainSigner != nil {
 		c.ServiceChainSigner = *dec.ServiceChainSigner
 	}
@@ -21,6 +21,7 @@
 package cn
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -35,6 +36,7 @@ import (
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/crypto"
@@ -84,6 +86,7 @@ type ProtocolManager struct {
 	networkId uint64
 
 	fastSync  uint32 // Flag whether fast sync is enabled (gets disabled if we already have blocks)
+	snapSync  uint32 // Flag whether snap sync is enabled (gets disabled if we already have blocks)
 	acceptTxs uint32 // Flag whether we're considered synchronised (enables transaction processing)
 
 	txpool      work.TxPool
@@ -152,21 +155,24 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		handler.SetBroadcaster(manager, manager.nodetype)
 	}
 
-	// Figure out whether to allow fast sync or not
-	if mode == downloader.FastSync && blockchain.CurrentBlock().NumberU64() > 0 {
-		logger.Error("Blockchain not empty, fast sync disabled")
+	// Figure out whether to allow fast or snap sync or not
+	if (mode == downloader.FastSync || mode == downloader.SnapSync) && blockchain.CurrentBlock().NumberU64() > 0 {
+		logger.Error("Blockchain not empty, fast sync disabled", "mode", mode)
 		mode = downloader.FullSync
 	}
 	if mode == downloader.FastSync {
 		manager.fastSync = uint32(1)
 	}
+	if mode == downloader.SnapSync {
+		manager.snapSync = uint32(1)
+	}
 	// istanbul BFT
 	protocol := engine.Protocol()
 	// Initiate a sub-protocol for every implemented version we can handle
 	manager.SubProtocols = make([]p2p.Protocol, 0, len(protocol.Versions))
 	for i, version := range protocol.Versions {
 		// Skip protocol version if incompatible with the mode of operation
-		if mode == downloader.FastSync && version < klay63 {
+		if (mode == downloader.FastSync || mode == downloader.SnapSync) && version < klay63 {
 			continue
 		}
 		// Compatible; initialise the sub-protocol
@@ -262,8 +268,8 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 			return blockchain.CurrentBlock().NumberU64()
 		}
 		inserter := func(blocks types.Blocks) (int, error) {
-			// If fast sync is running, deny importing weird blocks
-			if atomic.LoadUint32(&manager.fastSync) == 1 {
+			// If fast or snap sync is running, deny importing weird blocks
+			if atomic.LoadUint32(&manager.fastSync) == 1 || atomic.LoadUint32(&manager.snapSync) == 1 {
 				logger.Warn("Discarded bad propagated block", "number", blocks[0].Number(), "hash", blocks[0].Hash())
 				return 0, nil
 			}
@@ -588,6 +594,26 @@ func (pm *ProtocolManager) handleMsg(p Peer, addr common.Address, msg p2p.Msg) e
 			return err
 		}
 
+	case p.GetVersion() >= klay65 && msg.Code == AccountRangeRequestMsg:
+		if err := handleAccountRangeRequestMsg(pm, p, msg); err != nil {
+			return err
+		}
+
+	case p.GetVersion() >= klay65 && msg.Code == AccountRangeMsg:
+		if err := handleAccountRangeMsg(pm, p, msg); err != nil {
+			return err
+		}
+
+	case p.GetVersion() >= klay65 && msg.Code == StorageRangesRequestMsg:
+		if err := handleStorageRangesRequestMsg(pm, p, msg); err != nil {
+			return err
+		}
+
+	case p.GetVersion() >= klay65 && msg.Code == StorageRangesMsg:
+		if err := handleStorageRangesMsg(pm, p, msg); err != nil {
+			return err
+		}
+
 	case msg.Code == NewBlockHashesMsg:
 		if err := handleNewBlockHashesMsg(pm, p, msg); err != nil {
 			return err
@@ -828,6 +854,196 @@ func handleNodeDataMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	return nil
 }
 
+// handleAccountRangeRequestMsg handles account range request message.
+func handleAccountRangeRequestMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	var req getAccountRangeData
+	if err := msg.Decode(&req); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	hashes, accounts, proof := serviceAccountRange(pm.blockchain.StateCache().TrieDB(), &req)
+	return p.SendAccountRange(hashes, accounts, proof)
+}
+
+// handleAccountRangeMsg handles account range response message.
+func handleAccountRangeMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	var res accountRangeData
+	if err := msg.Decode(&res); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	if err := pm.downloader.DeliverAccountRange(p.GetID(), res.Hashes, res.Accounts, res.Proof); err != nil {
+		logger.Debug("Failed to deliver account range", "err", err)
+	}
+	return nil
+}
+
+// handleStorageRangesRequestMsg handles storage ranges request message.
+func handleStorageRangesRequestMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	var req getStorageRangesData
+	if err := msg.Decode(&req); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	hashes, slots, proof := serviceStorageRanges(pm.blockchain.StateCache().TrieDB(), &req)
+	return p.SendStorageRanges(hashes, slots, proof)
+}
+
+// handleStorageRangesMsg handles storage ranges response message.
+func handleStorageRangesMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	var res storageRangesData
+	if err := msg.Decode(&res); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	if err := pm.downloader.DeliverStorageRanges(p.GetID(), res.Hashes, res.Slots, res.Proof); err != nil {
+		logger.Debug("Failed to deliver storage ranges", "err", err)
+	}
+	return nil
+}
+
+// serviceAccountRange collects the accounts of the requested range along with
+// the Merkle proof of the edges of the range. An empty response is returned if
+// the state of the requested root is not available.
+func serviceAccountRange(triedb *statedb.Database, req *getAccountRangeData) ([]common.Hash, [][]byte, [][]byte) {
+	limit := req.Bytes
+	if limit > softResponseLimit {
+		limit = softResponseLimit
+	}
+	accTrie, err := statedb.NewTrie(req.Root, triedb)
+	if err != nil {
+		return nil, nil, nil
+	}
+	var (
+		hashes   []common.Hash
+		accounts [][]byte
+		size     uint64
+		it       = statedb.NewIterator(accTrie.NodeIterator(req.Origin[:]))
+	)
+	for it.Next() {
+		hash := common.BytesToHash(it.Key)
+		hashes = append(hashes, hash)
+		accounts = append(accounts, common.CopyBytes(it.Value))
+
+		// Stop at the first account beyond the limit to prove the end of the range.
+		size += uint64(common.HashLength + len(it.Value))
+		if bytes.Compare(hash[:], req.Limit[:]) >= 0 || size >= limit {
+			break
+		}
+	}
+	if it.Err != nil {
+		return nil, nil, nil
+	}
+	keys := [][]byte{req.Origin[:]}
+	if len(hashes) > 0 {
+		keys = append(keys, hashes[len(hashes)-1][:])
+	}
+	proof, err := serviceRangeProof(accTrie, keys)
+	if err != nil {
+		return nil, nil, nil
+	}
+	return hashes, accounts, proof
+}
+
+// serviceStorageRanges collects the storage slots of the requested accounts.
+// If the storage of an account cannot be served entirely because of the size
+// limit, the partial range is attached with the Merkle proof of its edges and
+// the rest of the accounts are not served. An empty response is returned if
+// the state of the requested root is not available.
+func serviceStorageRanges(triedb *statedb.Database, req *getStorageRangesData) ([][]common.Hash, [][][]byte, [][]byte) {
+	limit := req.Bytes
+	if limit > softResponseLimit {
+		limit = softResponseLimit
+	}
+	accTrie, err := statedb.NewTrie(req.Root, triedb)
+	if err != nil {
+		return nil, nil, nil
+	}
+	var (
+		hashes [][]common.Hash
+		slots  [][][]byte
+		proof  [][]byte
+		size   uint64
+	)
+	for i, accHash := range req.Accounts {
+		if size >= limit {
+			break
+		}
+		enc, err := accTrie.TryGet(accHash[:])
+		if err != nil || enc == nil {
+			return nil, nil, nil
+		}
+		serializer := account.NewAccountSerializer()
+		if err := rlp.DecodeBytes(enc, serializer); err != nil {
+			return nil, nil, nil
+		}
+		pa := account.GetProgramAccount(serializer.GetAccount())
+		if pa == nil {
+			return nil, nil, nil
+		}
+		storageTrie, err := statedb.NewTrie(pa.GetStorageRoot(), triedb)
+		if err != nil {
+			return nil, nil, nil
+		}
+		// The origin is applied only to the first account.
+		var origin common.Hash
+		if i == 0 {
+			origin = req.Origin
+		}
+		var (
+			storageHashes []common.Hash
+			storageSlots  [][]byte
+			abort         bool
+			it            = statedb.NewIterator(storageTrie.NodeIterator(origin[:]))
+		)
+		for it.Next() {
+			hash := common.BytesToHash(it.Key)
+			storageHashes = append(storageHashes, hash)
+			storageSlots = append(storageSlots, common.CopyBytes(it.Value))
+
+			size += uint64(common.HashLength + len(it.Value))
+			if size >= limit {
+				abort = true
+				break
+			}
+		}
+		if it.Err != nil {
+			return nil, nil, nil
+		}
+		hashes = append(hashes, storageHashes)
+		slots = append(slots, storageSlots)
+
+		// Prove the edges of the range if the range is partial.
+		if abort || origin != (common.Hash{}) {
+			keys := [][]byte{origin[:]}
+			if len(storageHashes) > 0 {
+				keys = append(keys, storageHashes[len(storageHashes)-1][:])
+			}
+			if proof, err = serviceRangeProof(storageTrie, keys); err != nil {
+				return nil, nil, nil
+			}
+			break
+		}
+	}
+	return hashes, slots, proof
+}
+
+// serviceRangeProof collects the Merkle proof nodes of the given keys.
+func serviceRangeProof(trie *statedb.Trie, keys [][]byte) ([][]byte, error) {
+	proofDB := database.NewMemoryDBManager()
+	for _, key := range keys {
+		if err := trie.Prove(key, 0, proofDB); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		proof [][]byte
+		it    = proofDB.GetMemDB().NewIterator(nil, nil)
+	)
+	defer it.Release()
+
+	for it.Next() {
+		proof = append(proof, common.CopyBytes(it.Value()))
+	}
+	return proof, it.Error()
+}
+
 // handleGetReceiptsMsg handles receipt request message.
 func handleReceiptsRequestMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	// Decode the retrieval message
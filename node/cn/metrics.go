@@ -57,6 +57,10 @@ var (
 	reqReceiptInTrafficMeter             = metrics.NewRegisteredMeter("klay/req/receipts/in/traffic", nil)
 	reqReceiptOutPacketsMeter            = metrics.NewRegisteredMeter("klay/req/receipts/out/packets", nil)
 	reqReceiptOutTrafficMeter            = metrics.NewRegisteredMeter("klay/req/receipts/out/traffic", nil)
+	reqSnapInPacketsMeter                = metrics.NewRegisteredMeter("klay/req/snap/in/packets", nil)
+	reqSnapInTrafficMeter                = metrics.NewRegisteredMeter("klay/req/snap/in/traffic", nil)
+	reqSnapOutPacketsMeter               = metrics.NewRegisteredMeter("klay/req/snap/out/packets", nil)
+	reqSnapOutTrafficMeter               = metrics.NewRegisteredMeter("klay/req/snap/out/traffic", nil)
 	miscInPacketsMeter                   = metrics.NewRegisteredMeter("klay/misc/in/packets", nil)
 	miscInTrafficMeter                   = metrics.NewRegisteredMeter("klay/misc/in/traffic", nil)
 	miscOutPacketsMeter                  = metrics.NewRegisteredMeter("klay/misc/out/packets", nil)
@@ -114,6 +118,8 @@ func (rw *meteredMsgReadWriter) ReadMsg() (p2p.Msg, error) {
 		packets, traffic = reqStateInPacketsMeter, reqStateInTrafficMeter
 	case rw.version >= klay63 && msg.Code == ReceiptsMsg:
 		packets, traffic = reqReceiptInPacketsMeter, reqReceiptInTrafficMeter
+	case rw.version >= klay65 && (msg.Code == AccountRangeMsg || msg.Code == StorageRangesMsg):
+		packets, traffic = reqSnapInPacketsMeter, reqSnapInTrafficMeter
 
 	case msg.Code == NewBlockHashesMsg:
 		packets, traffic = propHashInPacketsMeter, propHashInTrafficMeter
@@ -143,6 +149,8 @@ func (rw *meteredMsgReadWriter) WriteMsg(msg p2p.Msg) error {
 		packets, traffic = reqStateOutPacketsMeter, reqStateOutTrafficMeter
 	case rw.version >= klay63 && msg.Code == ReceiptsMsg:
 		packets, traffic = reqReceiptOutPacketsMeter, reqReceiptOutTrafficMeter
+	case rw.version >= klay65 && (msg.Code == AccountRangeMsg || msg.Code == StorageRangesMsg):
+		packets, traffic = reqSnapOutPacketsMeter, reqSnapOutTrafficMeter
 
 	case msg.Code == NewBlockHashesMsg:
 		packets, traffic = propHashOutPacketsMeter, propHashOutTrafficMeter
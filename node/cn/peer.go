@@ -162,6 +162,14 @@ type Peer interface {
 	// ones requested from an already RLP encoded format.
 	SendReceiptsRLP(receipts []rlp.RawValue) error
 
+	// SendAccountRange sends a range of accounts with its Merkle proof,
+	// corresponding to the range requested.
+	SendAccountRange(hashes []common.Hash, accounts [][]byte, proof [][]byte) error
+
+	// SendStorageRanges sends storage ranges of accounts with the Merkle proof
+	// of the last range, corresponding to the ranges requested.
+	SendStorageRanges(hashes [][]common.Hash, slots [][][]byte, proof [][]byte) error
+
 	// FetchBlockHeader is a wrapper around the header query functions to fetch a
 	// single header. It is used solely by the fetcher.
 	FetchBlockHeader(hash common.Hash) error
@@ -221,6 +229,9 @@ type Peer interface {
 	// Peer encapsulates the methods required to synchronise with a remote full peer.
 	downloader.Peer
 
+	// SnapPeer encapsulates the methods required to retrieve state ranges from a remote peer.
+	downloader.SnapPeer
+
 	// RegisterConsensusMsgCode registers the channel of consensus msg.
 	RegisterConsensusMsgCode(msgCode uint64) error
 }
@@ -301,6 +312,12 @@ var ChannelOfMessage = map[uint64]int{
 	NodeDataMsg:        p2p.ConnDefault,
 	ReceiptsRequestMsg: p2p.ConnDefault,
 	ReceiptsMsg:        p2p.ConnDefault,
+
+	// Protocol messages belonging to klay/65
+	AccountRangeRequestMsg:  p2p.ConnDefault,
+	AccountRangeMsg:         p2p.ConnDefault,
+	StorageRangesRequestMsg: p2p.ConnDefault,
+	StorageRangesMsg:        p2p.ConnDefault,
 }
 
 var ConcurrentOfChannel = []int{
@@ -535,6 +552,18 @@ func (p *basePeer) SendReceiptsRLP(receipts []rlp.RawValue) error {
 	return p2p.Send(p.rw, ReceiptsMsg, receipts)
 }
 
+// SendAccountRange sends a range of accounts with its Merkle proof,
+// corresponding to the range requested.
+func (p *basePeer) SendAccountRange(hashes []common.Hash, accounts [][]byte, proof [][]byte) error {
+	return p2p.Send(p.rw, AccountRangeMsg, &accountRangeData{Hashes: hashes, Accounts: accounts, Proof: proof})
+}
+
+// SendStorageRanges sends storage ranges of accounts with the Merkle proof
+// of the last range, corresponding to the ranges requested.
+func (p *basePeer) SendStorageRanges(hashes [][]common.Hash, slots [][][]byte, proof [][]byte) error {
+	return p2p.Send(p.rw, StorageRangesMsg, &storageRangesData{Hashes: hashes, Slots: slots, Proof: proof})
+}
+
 // FetchBlockHeader is a wrapper around the header query functions to fetch a
 // single header. It is used solely by the fetcher.
 func (p *basePeer) FetchBlockHeader(hash common.Hash) error {
@@ -583,6 +612,20 @@ func (p *basePeer) RequestReceipts(hashes []common.Hash) error {
 	return p2p.Send(p.rw, ReceiptsRequestMsg, hashes)
 }
 
+// RequestAccountRange fetches a range of accounts of the given state root with
+// its Merkle proof from a remote node.
+func (p *basePeer) RequestAccountRange(root common.Hash, origin, limit common.Hash, bytes uint64) error {
+	p.Log().Debug("Fetching range of accounts", "root", root, "origin", origin, "limit", limit, "bytes", bytes)
+	return p2p.Send(p.rw, AccountRangeRequestMsg, &getAccountRangeData{Root: root, Origin: origin, Limit: limit, Bytes: bytes})
+}
+
+// RequestStorageRanges fetches storage ranges of the given accounts with the
+// Merkle proof of the last range from a remote node.
+func (p *basePeer) RequestStorageRanges(root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64) error {
+	p.Log().Debug("Fetching ranges of storage slots", "root", root, "accounts", len(accounts), "origin", origin, "limit", limit, "bytes", bytes)
+	return p2p.Send(p.rw, StorageRangesRequestMsg, &getStorageRangesData{Root: root, Accounts: accounts, Origin: origin, Limit: limit, Bytes: bytes})
+}
+
 // Handshake executes the Klaytn protocol handshake, negotiating version number,
 // network IDs, difficulties, head and genesis blocks.
 func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash) error {
@@ -870,6 +913,18 @@ func (p *multiChannelPeer) SendReceiptsRLP(receipts []rlp.RawValue) error {
 	return p.msgSender(ReceiptsMsg, receipts)
 }
 
+// SendAccountRange sends a range of accounts with its Merkle proof,
+// corresponding to the range requested.
+func (p *multiChannelPeer) SendAccountRange(hashes []common.Hash, accounts [][]byte, proof [][]byte) error {
+	return p.msgSender(AccountRangeMsg, &accountRangeData{Hashes: hashes, Accounts: accounts, Proof: proof})
+}
+
+// SendStorageRanges sends storage ranges of accounts with the Merkle proof
+// of the last range, corresponding to the ranges requested.
+func (p *multiChannelPeer) SendStorageRanges(hashes [][]common.Hash, slots [][][]byte, proof [][]byte) error {
+	return p.msgSender(StorageRangesMsg, &storageRangesData{Hashes: hashes, Slots: slots, Proof: proof})
+}
+
 // FetchBlockHeader is a wrapper around the header query functions to fetch a
 // single header. It is used solely by the fetcher.
 func (p *multiChannelPeer) FetchBlockHeader(hash common.Hash) error {
@@ -918,6 +973,20 @@ func (p *multiChannelPeer) RequestReceipts(hashes []common.Hash) error {
 	return p.msgSender(ReceiptsRequestMsg, hashes)
 }
 
+// RequestAccountRange fetches a range of accounts of the given state root with
+// its Merkle proof from a remote node.
+func (p *multiChannelPeer) RequestAccountRange(root common.Hash, origin, limit common.Hash, bytes uint64) error {
+	p.Log().Debug("Fetching range of accounts", "root", root, "origin", origin, "limit", limit, "bytes", bytes)
+	return p.msgSender(AccountRangeRequestMsg, &getAccountRangeData{Root: root, Origin: origin, Limit: limit, Bytes: bytes})
+}
+
+// RequestStorageRanges fetches storage ranges of the given accounts with the
+// Merkle proof of the last range from a remote node.
+func (p *multiChannelPeer) RequestStorageRanges(root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64) error {
+	p.Log().Debug("Fetching ranges of storage slots", "root", root, "accounts", len(accounts), "origin", origin, "limit", limit, "bytes", bytes)
+	return p.msgSender(StorageRangesRequestMsg, &getStorageRangesData{Root: root, Accounts: accounts, Origin: origin, Limit: limit, Bytes: bytes})
+}
+
 // msgSender sends data to the peer.
 func (p *multiChannelPeer) msgSender(msgcode uint64, data interface{}) error {
 	if ch, ok := ChannelOfMessage[msgcode]; ok && len(p.rws) > ch {
@@ -388,6 +388,20 @@ func (mr *MockPeerMockRecorder) RegisterConsensusMsgCode(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterConsensusMsgCode", reflect.TypeOf((*MockPeer)(nil).RegisterConsensusMsgCode), arg0)
 }
 
+// RequestAccountRange mocks base method
+func (m *MockPeer) RequestAccountRange(arg0 common.Hash, arg1 common.Hash, arg2 common.Hash, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAccountRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestAccountRange indicates an expected call of RequestAccountRange
+func (mr *MockPeerMockRecorder) RequestAccountRange(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAccountRange", reflect.TypeOf((*MockPeer)(nil).RequestAccountRange), arg0, arg1, arg2, arg3)
+}
+
 // RequestBodies mocks base method
 func (m *MockPeer) RequestBodies(arg0 []common.Hash) error {
 	m.ctrl.T.Helper()
@@ -444,6 +458,20 @@ func (mr *MockPeerMockRecorder) RequestNodeData(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestNodeData", reflect.TypeOf((*MockPeer)(nil).RequestNodeData), arg0)
 }
 
+// RequestStorageRanges mocks base method
+func (m *MockPeer) RequestStorageRanges(arg0 common.Hash, arg1 []common.Hash, arg2 common.Hash, arg3 common.Hash, arg4 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestStorageRanges", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestStorageRanges indicates an expected call of RequestStorageRanges
+func (mr *MockPeerMockRecorder) RequestStorageRanges(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestStorageRanges", reflect.TypeOf((*MockPeer)(nil).RequestStorageRanges), arg0, arg1, arg2, arg3, arg4)
+}
+
 // RequestReceipts mocks base method
 func (m *MockPeer) RequestReceipts(arg0 []common.Hash) error {
 	m.ctrl.T.Helper()
@@ -472,6 +500,20 @@ func (mr *MockPeerMockRecorder) Send(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPeer)(nil).Send), arg0, arg1)
 }
 
+// SendAccountRange mocks base method
+func (m *MockPeer) SendAccountRange(arg0 []common.Hash, arg1 [][]byte, arg2 [][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAccountRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAccountRange indicates an expected call of SendAccountRange
+func (mr *MockPeerMockRecorder) SendAccountRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAccountRange", reflect.TypeOf((*MockPeer)(nil).SendAccountRange), arg0, arg1, arg2)
+}
+
 // SendBlockBodies mocks base method
 func (m *MockPeer) SendBlockBodies(arg0 []*blockBody) error {
 	m.ctrl.T.Helper()
@@ -598,6 +640,20 @@ func (mr *MockPeerMockRecorder) SendReceiptsRLP(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendReceiptsRLP", reflect.TypeOf((*MockPeer)(nil).SendReceiptsRLP), arg0)
 }
 
+// SendStorageRanges mocks base method
+func (m *MockPeer) SendStorageRanges(arg0 [][]common.Hash, arg1 [][][]byte, arg2 [][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendStorageRanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendStorageRanges indicates an expected call of SendStorageRanges
+func (mr *MockPeerMockRecorder) SendStorageRanges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendStorageRanges", reflect.TypeOf((*MockPeer)(nil).SendStorageRanges), arg0, arg1, arg2)
+}
+
 // SendTransactions mocks base method
 func (m *MockPeer) SendTransactions(arg0 types.Transactions) error {
 	m.ctrl.T.Helper()
@@ -38,16 +38,17 @@ import (
 const (
 	klay62 = 62
 	klay63 = 63
+	klay65 = 65
 )
 
 // ProtocolName is the official short name of the protocol used during capability negotiation.
 var ProtocolName = "klay"
 
 // ProtocolVersions are the upported versions of the klay protocol (first is primary).
-var ProtocolVersions = []uint{klay63, klay62}
+var ProtocolVersions = []uint{klay65, klay63, klay62}
 
 // ProtocolLengths are the number of implemented message corresponding to different protocol versions.
-var ProtocolLengths = []uint64{17, 8}
+var ProtocolLengths = []uint64{22, 17, 8}
 
 const ProtocolMaxMsgSize = 10 * 1024 * 1024 // Maximum cap on the size of a protocol message
 
@@ -77,6 +78,15 @@ const (
 	MsgCodeEnd = 0x10
 )
 
+// Protocol messages belonging to klay/65
+// The message codes follow the consensus message code of the istanbul protocol (0x11).
+const (
+	AccountRangeRequestMsg  = 0x12
+	AccountRangeMsg         = 0x13
+	StorageRangesRequestMsg = 0x14
+	StorageRangesMsg        = 0x15
+)
+
 type errCode int
 
 const (
@@ -124,6 +134,8 @@ type ProtocolManagerDownloader interface {
 	DeliverHeaders(id string, headers []*types.Header) error
 	DeliverNodeData(id string, data [][]byte) error
 	DeliverReceipts(id string, receipts [][]*types.Receipt) error
+	DeliverAccountRange(id string, hashes []common.Hash, accounts [][]byte, proof [][]byte) error
+	DeliverStorageRanges(id string, hashes [][]common.Hash, slots [][][]byte, proof [][]byte) error
 
 	Terminate()
 	Synchronise(id string, head common.Hash, td *big.Int, mode downloader.SyncMode) error
@@ -201,6 +213,38 @@ func (hn *hashOrNumber) DecodeRLP(s *rlp.Stream) error {
 	return err
 }
 
+// getAccountRangeData represents an account range query.
+type getAccountRangeData struct {
+	Root   common.Hash // Root of the account trie to serve
+	Origin common.Hash // Hash of the first account to retrieve
+	Limit  common.Hash // Hash of the last account to retrieve
+	Bytes  uint64      // Soft limit at which to stop returning data
+}
+
+// accountRangeData is the network packet for an account range with its Merkle proof.
+type accountRangeData struct {
+	Hashes   []common.Hash // Hashes of the accounts in the range
+	Accounts [][]byte      // RLP encoded accounts in the range
+	Proof    [][]byte      // Merkle proof nodes of the edges of the range
+}
+
+// getStorageRangesData represents a storage range query of accounts.
+type getStorageRangesData struct {
+	Root     common.Hash   // Root of the account trie to serve
+	Accounts []common.Hash // Hashes of the accounts whose storage to retrieve
+	Origin   common.Hash   // Hash of the first storage slot to retrieve (only for the first account)
+	Limit    common.Hash   // Hash of the last storage slot to retrieve (only for the last account)
+	Bytes    uint64        // Soft limit at which to stop returning data
+}
+
+// storageRangesData is the network packet for storage ranges of accounts. The
+// Merkle proof is attached only for the last range if it is not complete.
+type storageRangesData struct {
+	Hashes [][]common.Hash // Hashes of the storage slots per account
+	Slots  [][][]byte      // RLP encoded storage slots per account
+	Proof  [][]byte        // Merkle proof nodes of the edges of the last range
+}
+
 // newBlockData is the network packet for the block propagation message.
 type newBlockData struct {
 	Block *types.Block
@@ -165,7 +165,10 @@ func (pm *ProtocolManager) syncer() {
 
 // getSyncMode returns SyncMode based on currentBlockNumber.
 func (pm *ProtocolManager) getSyncMode(currentBlock *types.Block) downloader.SyncMode {
-	if atomic.LoadUint32(&pm.fastSync) == 1 {
+	if atomic.LoadUint32(&pm.snapSync) == 1 {
+		// Snap sync was explicitly requested, and explicitly granted
+		return downloader.SnapSync
+	} else if atomic.LoadUint32(&pm.fastSync) == 1 {
 		// Fast sync was explicitly requested, and explicitly granted
 		return downloader.FastSync
 	} else if currentBlock.NumberU64() == 0 && pm.blockchain.CurrentFastBlock().NumberU64() > 0 {
@@ -196,7 +199,7 @@ func (pm *ProtocolManager) synchronise(peer Peer) {
 	}
 	// Otherwise try to sync with the downloader
 	mode := pm.getSyncMode(currentBlock)
-	if mode == downloader.FastSync {
+	if mode == downloader.FastSync || mode == downloader.SnapSync {
 		// Make sure the peer's total blockscore we are synchronizing is higher.
 		if pm.blockchain.GetTdByHash(pm.blockchain.CurrentFastBlock().Hash()).Cmp(pTd) >= 0 {
 			return
@@ -211,6 +214,10 @@ func (pm *ProtocolManager) synchronise(peer Peer) {
 		logger.Info("Fast sync complete, auto disabling")
 		atomic.StoreUint32(&pm.fastSync, 0)
 	}
+	if atomic.LoadUint32(&pm.snapSync) == 1 {
+		logger.Info("Snap sync complete, auto disabling")
+		atomic.StoreUint32(&pm.snapSync, 0)
+	}
 	atomic.StoreUint32(&pm.acceptTxs, 1) // Mark initial sync done
 	if head := pm.blockchain.CurrentBlock(); head.NumberU64() > 0 {
 		// We've completed a sync cycle, notify all peers of new state. This path is
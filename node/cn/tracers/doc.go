@@ -16,11 +16,14 @@
 
 /*
 Package tracers provides implementation of Tracer that evaluates a Javascript
-function for each VM execution step.
+function for each VM execution step, and native tracers written in Go.
 
 Source Files
 
-  - tracer.go  : implementation of Tracer
-  - tracers.go : provides managing functions of tracers
+  - tracer.go           : implementation of Tracer
+  - tracers.go          : provides managing functions of tracers
+  - native.go           : provides NativeTracer and the registry of native tracers
+  - native_prestate.go  : native version of prestateTracer, named nativePrestateTracer, which also supports the diff mode
+  - native_4byte.go     : native version of 4byteTracer, named native4byteTracer
 */
 package tracers
@@ -0,0 +1,72 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/vm"
+)
+
+// NativeTracer is a transaction tracer written in Go. Unlike the JavaScript
+// tracers, it does not run through an interpreter for every opcode, so it is
+// suitable for tracing large blocks.
+type NativeTracer interface {
+	vm.Tracer
+
+	// GetResult returns the JSON-encoded result of the tracing.
+	GetResult() (json.RawMessage, error)
+
+	// Stop terminates the tracing at the first opportune moment.
+	Stop(err error)
+}
+
+// Context contains the transaction to be traced and the state it will be
+// executed on. The state has not been touched by the transaction yet when
+// a native tracer is created.
+type Context struct {
+	Message blockchain.Message
+	StateDB vm.StateDB
+}
+
+// nativeTracerCtor creates a native tracer with the given tracer-specific config.
+type nativeTracerCtor func(ctx *Context, cfg json.RawMessage) (NativeTracer, error)
+
+// nativeTracers contains all the built in native tracers by name. They are named
+// apart from the JavaScript tracers, so existing users keep the JavaScript output.
+// The native call tracer is vm.InternalTxTracer, which is not registered here.
+var nativeTracers = map[string]nativeTracerCtor{
+	"nativePrestateTracer": newPrestateTracer,
+	"native4byteTracer":    newFourByteTracer,
+}
+
+// IsNativeTracer returns true if a native tracer is registered with the given name.
+func IsNativeTracer(name string) bool {
+	_, ok := nativeTracers[name]
+	return ok
+}
+
+// NewNativeTracer creates the native tracer registered with the given name.
+func NewNativeTracer(name string, ctx *Context, cfg json.RawMessage) (NativeTracer, error) {
+	ctor, ok := nativeTracers[name]
+	if !ok {
+		return nil, fmt.Errorf("native tracer %q not found", name)
+	}
+	return ctor(ctx, cfg)
+}
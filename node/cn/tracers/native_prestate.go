@@ -0,0 +1,255 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+// prestateAccount is the state of an account touched by the traced transaction.
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+type prestate map[common.Address]*prestateAccount
+
+// prestateTracerConfig is the tracer-specific config of prestateTracer.
+type prestateTracerConfig struct {
+	// DiffMode makes the tracer return the states of the modified accounts
+	// before and after the execution instead of the touched prestate.
+	DiffMode bool `json:"diffMode"`
+}
+
+// prestateDiff is the result of prestateTracer in the diff mode.
+type prestateDiff struct {
+	Pre  prestate `json:"pre"`
+	Post prestate `json:"post"`
+}
+
+// prestateTracer is the native version of prestate_tracer.js. It collects the
+// accounts and the storage slots touched by the traced transaction, which is
+// enough to re-execute the transaction on top of them.
+type prestateTracer struct {
+	config  prestateTracerConfig
+	statedb vm.StateDB
+	pre     prestate
+	created map[common.Address]bool // accounts not existing before the transaction
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+	err       error
+}
+
+func newPrestateTracer(ctx *Context, cfg json.RawMessage) (NativeTracer, error) {
+	t := &prestateTracer{
+		statedb: ctx.StateDB,
+		pre:     prestate{},
+		created: make(map[common.Address]bool),
+	}
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &t.config); err != nil {
+			return nil, err
+		}
+	}
+	// The accounts paying the value and the fee are changed before the EVM
+	// starts, so they are looked up here while the state is still untouched.
+	if msg := ctx.Message; msg != nil {
+		t.lookupAccount(msg.ValidatedSender())
+		t.lookupAccount(msg.ValidatedFeePayer())
+		if msg.To() != nil {
+			t.lookupAccount(*msg.To())
+		}
+	}
+	return t, nil
+}
+
+// lookupAccount retrieves the state of the given account if it is not retrieved yet.
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+	if !t.statedb.Exist(addr) {
+		t.created[addr] = true
+	}
+	t.pre[addr] = &prestateAccount{
+		Balance: (*hexutil.Big)(new(big.Int).Set(t.statedb.GetBalance(addr))),
+		Nonce:   t.statedb.GetNonce(addr),
+		Code:    common.CopyBytes(t.statedb.GetCode(addr)),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+// lookupStorage retrieves the given storage slot if it is not retrieved yet.
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	t.lookupAccount(addr)
+	if _, ok := t.pre[addr].Storage[key]; ok {
+		return
+	}
+	t.pre[addr].Storage[key] = t.statedb.GetState(addr, key)
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *prestateTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	// The contract created by the transaction already exists here, but it did not exist before it
+	if create {
+		t.lookupAccount(to)
+		t.created[to] = true
+	}
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.err != nil || err != nil {
+		return nil
+	}
+	// If tracing was interrupted, set the error and stop
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		t.err = t.reason
+		return nil
+	}
+	t.lookupAccount(contract.Address())
+
+	switch op {
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.EXTCODEHASH, vm.BALANCE, vm.SELFDESTRUCT:
+		t.lookupAccount(common.BigToAddress(stack.Peek()))
+	case vm.CREATE:
+		from := contract.Address()
+		t.lookupAccount(crypto.CreateAddress(from, env.StateDB.GetNonce(from)))
+	case vm.CREATE2:
+		from := contract.Address()
+		offset, size := stack.Back(1).Int64(), stack.Back(2).Int64()
+		codeHash := crypto.Keccak256(memory.Slice(offset, offset+size))
+		t.lookupAccount(crypto.CreateAddress2(from, common.BigToHash(stack.Back(3)), codeHash))
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		t.lookupAccount(common.BigToAddress(stack.Back(1)))
+	case vm.SSTORE, vm.SLOAD:
+		t.lookupStorage(contract.Address(), common.BigToHash(stack.Peek()))
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *prestateTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// GetResult returns the touched prestate, or the pre and post states of the
+// modified accounts in the diff mode.
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.config.DiffMode {
+		return json.Marshal(t.diff())
+	}
+	for addr := range t.created {
+		delete(t.pre, addr)
+	}
+	return json.Marshal(t.pre)
+}
+
+// diff compares the collected prestate with the current state, and returns the
+// changed fields of the modified accounts only. The accounts created by the
+// transaction have no prestate and their whole state is returned as the post state.
+func (t *prestateTracer) diff() *prestateDiff {
+	result := &prestateDiff{Pre: prestate{}, Post: prestate{}}
+	for addr, pre := range t.pre {
+		// Destructed accounts only have a prestate
+		if t.statedb.HasSuicided(addr) {
+			if !t.created[addr] {
+				result.Pre[addr] = pre
+			}
+			continue
+		}
+		if t.created[addr] {
+			if t.statedb.Exist(addr) {
+				result.Post[addr] = t.postAccount(addr, pre.Storage)
+			}
+			continue
+		}
+		var (
+			post     = &prestateAccount{Storage: make(map[common.Hash]common.Hash)}
+			modified bool
+		)
+		if balance := t.statedb.GetBalance(addr); balance.Cmp(pre.Balance.ToInt()) != 0 {
+			post.Balance, modified = (*hexutil.Big)(new(big.Int).Set(balance)), true
+		}
+		if nonce := t.statedb.GetNonce(addr); nonce != pre.Nonce {
+			post.Nonce, modified = nonce, true
+		}
+		if code := t.statedb.GetCode(addr); !bytes.Equal(code, pre.Code) {
+			post.Code, modified = common.CopyBytes(code), true
+		}
+		preStorage := make(map[common.Hash]common.Hash)
+		for key, val := range pre.Storage {
+			if newVal := t.statedb.GetState(addr, key); newVal != val {
+				preStorage[key] = val
+				post.Storage[key] = newVal
+				modified = true
+			}
+		}
+		if !modified {
+			continue
+		}
+		pre.Storage = preStorage
+		result.Post[addr] = post
+		result.Pre[addr] = pre
+	}
+	return result
+}
+
+// postAccount returns the current state of the given account with the non-empty
+// values of the given storage slots.
+func (t *prestateTracer) postAccount(addr common.Address, slots map[common.Hash]common.Hash) *prestateAccount {
+	post := &prestateAccount{
+		Balance: (*hexutil.Big)(new(big.Int).Set(t.statedb.GetBalance(addr))),
+		Nonce:   t.statedb.GetNonce(addr),
+		Code:    common.CopyBytes(t.statedb.GetCode(addr)),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+	for key := range slots {
+		if val := t.statedb.GetState(addr, key); val != (common.Hash{}) {
+			post.Storage[key] = val
+		}
+	}
+	return post
+}
@@ -0,0 +1,239 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/klaytn/klaytn/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nativeCallTracerTests returns the call tracer tests having an encoded transaction.
+func nativeCallTracerTests(t *testing.T) map[string]*callTracerTest {
+	files, err := ioutil.ReadDir("testdata")
+	require.NoError(t, err)
+
+	testCases := make(map[string]*callTracerTest)
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), "call_tracer_") {
+			continue
+		}
+		blob, err := ioutil.ReadFile(filepath.Join("testdata", file.Name()))
+		require.NoError(t, err)
+
+		test := new(callTracerTest)
+		require.NoError(t, json.Unmarshal(blob, test))
+		if test.Input == "" {
+			continue
+		}
+		testCases[camel(strings.TrimSuffix(strings.TrimPrefix(file.Name(), "call_tracer_"), ".json"))] = test
+	}
+	return testCases
+}
+
+// resultTracer is a tracer returning the JSON-encoded result.
+type resultTracer interface {
+	vm.Tracer
+	GetResult() (json.RawMessage, error)
+}
+
+// runNativeTracer executes the transaction of the given test on top of the given
+// alloc with the native tracer of the given name, and returns the trace result.
+func runNativeTracer(t *testing.T, test *callTracerTest, alloc blockchain.GenesisAlloc, name string, cfg json.RawMessage) (json.RawMessage, *state.StateDB) {
+	return runTracer(t, test, alloc, func(ctx *Context) (resultTracer, error) {
+		return NewNativeTracer(name, ctx, cfg)
+	})
+}
+
+// runTracer executes the transaction of the given test on top of the given alloc
+// with the tracer created by the given function, and returns the trace result.
+func runTracer(t *testing.T, test *callTracerTest, alloc blockchain.GenesisAlloc, newTracer func(ctx *Context) (resultTracer, error)) (json.RawMessage, *state.StateDB) {
+	tx := new(types.Transaction)
+	require.NoError(t, rlp.DecodeBytes(common.FromHex(test.Input), tx))
+
+	signer := types.MakeSigner(test.Genesis.Config, new(big.Int).SetUint64(uint64(test.Context.Number)))
+	origin, err := signer.Sender(tx)
+	require.NoError(t, err)
+
+	context := vm.Context{
+		CanTransfer: blockchain.CanTransfer,
+		Transfer:    blockchain.Transfer,
+		Origin:      origin,
+		BlockNumber: new(big.Int).SetUint64(uint64(test.Context.Number)),
+		Time:        new(big.Int).SetUint64(uint64(test.Context.Time)),
+		BlockScore:  (*big.Int)(test.Context.BlockScore),
+		GasLimit:    uint64(test.Context.GasLimit),
+		GasPrice:    tx.GasPrice(),
+	}
+	statedb := tests.MakePreState(database.NewMemoryDBManager(), alloc)
+
+	msg, err := tx.AsMessageWithAccountKeyPicker(signer, statedb, context.BlockNumber.Uint64())
+	require.NoError(t, err)
+
+	tracer, err := newTracer(&Context{Message: msg, StateDB: statedb})
+	require.NoError(t, err)
+
+	evm := vm.NewEVM(context, statedb, test.Genesis.Config, &vm.Config{Debug: true, Tracer: tracer})
+	_, _, kerr := blockchain.ApplyMessage(evm, msg)
+	require.NoError(t, kerr.ErrTxInvalid)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	return res, statedb
+}
+
+// TestNativePrestateTracer checks if the prestate collected by the native
+// prestate tracer is enough to reproduce the same execution.
+func TestNativePrestateTracer(t *testing.T) {
+	for name, test := range nativeCallTracerTests(t) {
+		test := test // capture range variable
+		t.Run(name, func(t *testing.T) {
+			res, _ := runNativeTracer(t, test, test.Genesis.Alloc, "nativePrestateTracer", nil)
+
+			pre := make(prestate)
+			require.NoError(t, json.Unmarshal(res, &pre))
+
+			alloc := make(blockchain.GenesisAlloc)
+			for addr, account := range pre {
+				alloc[addr] = blockchain.GenesisAccount{
+					Balance: account.Balance.ToInt(),
+					Nonce:   account.Nonce,
+					Code:    account.Code,
+					Storage: account.Storage,
+				}
+			}
+			res, _ = runTracer(t, test, alloc, func(*Context) (resultTracer, error) { return New("callTracer") })
+
+			ret := new(callTrace)
+			require.NoError(t, json.Unmarshal(res, ret))
+			if !reflect.DeepEqual(ret, test.Result) {
+				t.Fatalf("trace mismatch on prestate: \nhave %+v, \nwant %+v", ret, test.Result)
+			}
+		})
+	}
+}
+
+func TestNativePrestateTracer_DiffMode(t *testing.T) {
+	test := nativeCallTracerTests(t)["simple"]
+	require.NotNil(t, test)
+
+	res, statedb := runNativeTracer(t, test, test.Genesis.Alloc, "nativePrestateTracer", json.RawMessage(`{"diffMode": true}`))
+
+	diff := new(prestateDiff)
+	require.NoError(t, json.Unmarshal(res, diff))
+
+	tx := new(types.Transaction)
+	require.NoError(t, rlp.DecodeBytes(common.FromHex(test.Input), tx))
+	sender := common.Address(*test.Result.From)
+
+	// The sender pays the value and the fee and increases its nonce
+	require.Contains(t, diff.Pre, sender)
+	require.Contains(t, diff.Post, sender)
+	assert.Equal(t, test.Genesis.Alloc[sender].Balance, diff.Pre[sender].Balance.ToInt())
+	assert.Equal(t, test.Genesis.Alloc[sender].Nonce, diff.Pre[sender].Nonce)
+	assert.Equal(t, statedb.GetBalance(sender), diff.Post[sender].Balance.ToInt())
+	assert.Equal(t, tx.Nonce()+1, diff.Post[sender].Nonce)
+	assert.Nil(t, diff.Post[sender].Code)
+
+	// Every reported field should be changed
+	for addr, post := range diff.Post {
+		pre, ok := diff.Pre[addr]
+		if !ok {
+			continue
+		}
+		if post.Balance != nil {
+			assert.NotEqual(t, pre.Balance.ToInt(), post.Balance.ToInt())
+		}
+		for key, val := range post.Storage {
+			assert.NotEqual(t, pre.Storage[key], val)
+		}
+	}
+}
+
+func TestNativePrestateTracer_DiffModeCreate(t *testing.T) {
+	test := nativeCallTracerTests(t)["create"]
+	require.NotNil(t, test)
+
+	res, statedb := runNativeTracer(t, test, test.Genesis.Alloc, "nativePrestateTracer", json.RawMessage(`{"diffMode": true}`))
+
+	diff := new(prestateDiff)
+	require.NoError(t, json.Unmarshal(res, diff))
+
+	// The created contract has no prestate and its whole state is the post state
+	created := *test.Result.To
+	assert.NotContains(t, diff.Pre, created)
+	require.Contains(t, diff.Post, created)
+	require.NotNil(t, diff.Post[created].Balance)
+	assert.Zero(t, statedb.GetBalance(created).Cmp(diff.Post[created].Balance.ToInt()))
+	assert.Equal(t, statedb.GetNonce(created), diff.Post[created].Nonce)
+	assert.Equal(t, uint64(1), diff.Post[created].Nonce)
+	assert.Equal(t, statedb.GetCode(created), []byte(diff.Post[created].Code))
+}
+
+func TestNativeFourByteTracer(t *testing.T) {
+	test := nativeCallTracerTests(t)["deepCalls"]
+	require.NotNil(t, test)
+
+	res, _ := runNativeTracer(t, test, test.Genesis.Alloc, "native4byteTracer", nil)
+
+	ids := make(map[string]int)
+	require.NoError(t, json.Unmarshal(res, &ids))
+
+	// Collect the expected counts from the call trace
+	expected := make(map[string]int)
+	var collect func(call *callTrace)
+	collect = func(call *callTrace) {
+		if len(call.Input) >= 4 && call.Type != "CREATE" && call.Type != "CREATE2" {
+			expected[fmt.Sprintf("%s-%d", hexutil.Bytes(call.Input[:4]), len(call.Input)-4)]++
+		}
+		for i := range call.Calls {
+			collect(&call.Calls[i])
+		}
+	}
+	collect(test.Result)
+	assert.Equal(t, expected, ids)
+}
+
+func TestNewNativeTracer(t *testing.T) {
+	for name := range nativeTracers {
+		assert.True(t, IsNativeTracer(name))
+	}
+	assert.False(t, IsNativeTracer("unigramTracer"))
+
+	_, err := NewNativeTracer("unigramTracer", &Context{}, nil)
+	assert.Error(t, err)
+
+	_, err = NewNativeTracer("nativePrestateTracer", &Context{}, json.RawMessage(`{"diffMode": 1}`))
+	assert.Error(t, err)
+}
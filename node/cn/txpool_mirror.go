@@ -0,0 +1,138 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/klaytn/klaytn/api"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/work"
+)
+
+const (
+	txPoolMirrorChanSize    = 1024             // Size of the channel receiving the notifications of the mirrored pool
+	txPoolMirrorRetryDelay  = 5 * time.Second  // Delay before reconnecting to the source node
+	txPoolMirrorDialTimeout = 10 * time.Second // Timeout for connecting to and subscribing the source node
+)
+
+// errTxPoolMirrorDropped is returned when the source node dropped some transactions
+// of the mirrored pool, so it should be subscribed again for a fresh snapshot.
+var errTxPoolMirrorDropped = errors.New("transactions of the mirrored pool were dropped")
+
+// txPoolMirror keeps the transaction pool of a standby node in sync with the pool
+// of a source node, so that no transaction is lost when the standby takes over.
+// It subscribes "txpool_mirror" of the source node through a websocket or IPC
+// endpoint and hands the received transactions to the local pool as if they came from a peer.
+type txPoolMirror struct {
+	source string
+	txPool work.TxPool
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newTxPoolMirror(source string, txPool work.TxPool) *txPoolMirror {
+	return &txPoolMirror{
+		source: source,
+		txPool: txPool,
+		quit:   make(chan struct{}),
+	}
+}
+
+// start starts mirroring the transaction pool of the source node.
+func (m *txPoolMirror) start() {
+	m.wg.Add(1)
+	go m.loop()
+}
+
+// stop terminates mirroring and waits until the mirroring goroutine exits.
+func (m *txPoolMirror) stop() {
+	close(m.quit)
+	m.wg.Wait()
+}
+
+// loop keeps a subscription to the source node, reconnecting if it is lost.
+func (m *txPoolMirror) loop() {
+	defer m.wg.Done()
+
+	for {
+		err := m.mirror()
+		if err == errTxPoolMirrorDropped {
+			// The source node is alive, so get a fresh snapshot at once.
+			logger.Warn("Resubscribing the mirrored transaction pool", "source", m.source, "err", err)
+			continue
+		}
+		if err != nil {
+			logger.Warn("Lost the mirrored transaction pool, reconnecting", "source", m.source, "err", err)
+		}
+		select {
+		case <-time.After(txPoolMirrorRetryDelay):
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// mirror subscribes to the source node and adds the received transactions to the
+// local pool until the subscription fails or the mirror is stopped.
+func (m *txPoolMirror) mirror() error {
+	ctx, cancel := context.WithTimeout(context.Background(), txPoolMirrorDialTimeout)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, m.source)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ch := make(chan *api.TxPoolMirrorBatch, txPoolMirrorChanSize)
+	sub, err := client.Subscribe(ctx, "txpool", ch, "mirror")
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	logger.Info("Started mirroring the transaction pool", "source", m.source)
+
+	for {
+		select {
+		case batch := <-ch:
+			if batch.Dropped {
+				return errTxPoolMirrorDropped
+			}
+			txs := make(types.Transactions, 0, len(batch.Txs))
+			for _, enc := range batch.Txs {
+				tx := new(types.Transaction)
+				if err := rlp.DecodeBytes(enc, tx); err != nil {
+					logger.Debug("Failed to decode mirrored transaction", "err", err)
+					continue
+				}
+				txs = append(txs, tx)
+			}
+			m.txPool.HandleTxMsg(txs)
+		case err := <-sub.Err():
+			return err
+		case <-m.quit:
+			return nil
+		}
+	}
+}
@@ -22,12 +22,16 @@ package node
 
 import (
 	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/accounts/keystore"
@@ -46,6 +50,7 @@ const (
 	datadirStaticNodes     = "static-nodes.json"  // Path within the datadir to the static node list
 	datadirTrustedNodes    = "trusted-nodes.json" // Path within the datadir to the trusted node list
 	datadirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
+	datadirJWTSecret       = "jwtsecret"          // Path within the datadir to the secret of the authenticated RPC server
 )
 
 // Config represents a small collection of configuration values to fine tune the
@@ -166,6 +171,64 @@ type Config struct {
 	// ephemeral nodes).
 	GRPCPort int `toml:",omitempty"`
 
+	// GRPCModules is a list of API modules to expose via the gRPC interface.
+	// If the module list is empty, all RPC API endpoints designated public will be
+	// exposed. The typed KlayAPI service is served only if "klay" is exposed.
+	GRPCModules []string `toml:",omitempty"`
+
+	// AuthHost is the host interface on which to start the authenticated RPC server
+	// serving HTTP and websocket requests. If this field is empty, no authenticated
+	// API endpoint will be started.
+	AuthHost string `toml:",omitempty"`
+
+	// AuthPort is the TCP port number on which to start the authenticated RPC server.
+	AuthPort int `toml:",omitempty"`
+
+	// AuthModules is a list of API modules to expose via the authenticated RPC interface.
+	// Each token can call only the modules listed in its "namespaces" claim among them.
+	AuthModules []string `toml:",omitempty"`
+
+	// AuthVirtualHosts is the list of virtual hostnames which are allowed on incoming
+	// requests of the authenticated RPC server.
+	AuthVirtualHosts []string `toml:",omitempty"`
+
+	// JWTSecret is the path to the file holding the hex-encoded secret which signs the
+	// tokens of the authenticated RPC server. If it is empty, the secret is kept in the
+	// data directory and a new one is generated when it does not exist.
+	JWTSecret string `toml:",omitempty"`
+
+	// GraphQLHost is the host interface on which to start the GraphQL server. If this
+	// field is empty, no GraphQL API endpoint will be started.
+	GraphQLHost string `toml:",omitempty"`
+
+	// GraphQLPort is the TCP port number on which to start the GraphQL server.
+	GraphQLPort int `toml:",omitempty"`
+
+	// GraphQLCors is the Cross-Origin Resource Sharing header to send to requesting
+	// clients. Please be aware that CORS is a browser enforced security, it's fully
+	// useless for custom HTTP clients.
+	GraphQLCors []string `toml:",omitempty"`
+
+	// GraphQLVirtualHosts is the list of virtual hostnames which are allowed on incoming
+	// requests of the GraphQL server.
+	GraphQLVirtualHosts []string `toml:",omitempty"`
+
+	// GraphQLMaxDepth is the maximum depth of the queries of the GraphQL server.
+	GraphQLMaxDepth int `toml:",omitempty"`
+
+	// GraphQLMaxParallelism is the maximum number of resolvers running in parallel
+	// for a query of the GraphQL server.
+	GraphQLMaxParallelism int `toml:",omitempty"`
+
+	// GraphQLTimeout is the maximum duration of a query of the GraphQL server.
+	GraphQLTimeout time.Duration `toml:",omitempty"`
+
+	// GraphQLMaxBlockSpan is the maximum number of blocks queried by a query of the GraphQL server.
+	GraphQLMaxBlockSpan uint64 `toml:",omitempty"`
+
+	// GraphQLMaxLogs is the maximum number of logs returned by a query of the GraphQL server.
+	GraphQLMaxLogs int `toml:",omitempty"`
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 }
@@ -253,6 +316,24 @@ func (c *Config) GRPCEndpoint() string {
 	return fmt.Sprintf("%s:%d", c.GRPCHost, c.GRPCPort)
 }
 
+// AuthEndpoint resolves an authenticated RPC endpoint based on the configured host
+// interface and port parameters.
+func (c *Config) AuthEndpoint() string {
+	if c.AuthHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.AuthHost, c.AuthPort)
+}
+
+// GraphQLEndpoint resolves a GraphQL endpoint based on the configured host
+// interface and port parameters.
+func (c *Config) GraphQLEndpoint() string {
+	if c.GraphQLHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.GraphQLHost, c.GraphQLPort)
+}
+
 // DefaultGRPCEndpoint returns the gRPC endpoint used by default.
 func DefaultGRPCEndpoint() string {
 	config := &Config{GRPCHost: DefaultGRPCHost, GRPCPort: DefaultGRPCPort}
@@ -364,6 +445,43 @@ func (c *Config) NodeKey() *ecdsa.PrivateKey {
 	return key
 }
 
+// AuthSecret returns the secret which signs the tokens of the authenticated RPC server.
+// A new secret is generated and stored if no secret file is configured nor found.
+func (c *Config) AuthSecret() ([]byte, error) {
+	path := c.JWTSecret
+	if path == "" {
+		path = c.ResolvePath(datadirJWTSecret)
+	}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT secret in %s: %v", path, err)
+		}
+		if len(secret) < 32 {
+			return nil, fmt.Errorf("JWT secret in %s is shorter than 32 bytes", path)
+		}
+		return secret, nil
+	} else if c.JWTSecret != "" {
+		return nil, err
+	}
+	if path == "" {
+		return nil, errors.New("no JWT secret for an ephemeral node")
+	}
+	// No persistent secret found, generate and store a new one.
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(secret)), 0600); err != nil {
+		return nil, err
+	}
+	logger.Info("Generated JWT secret", "path", path)
+	return secret, nil
+}
+
 // StaticNodes returns a list of node enode URLs configured as static nodes.
 func (c *Config) StaticNodes() []*discover.Node {
 	return c.parsePersistentNodes(c.ResolvePath(datadirStaticNodes))
@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/klaytn/klaytn/crypto"
@@ -155,3 +156,46 @@ func TestNodeKeyPersistency(t *testing.T) {
 		}
 	*/
 }
+
+// Tests that the secret of the authenticated RPC server is generated, persisted and
+// loaded, and that a configured secret file is used as it is.
+func TestAuthSecretPersistency(t *testing.T) {
+	dir := t.TempDir()
+
+	// Configure a node with no secret file and ensure a secret is persisted
+	config := &Config{Name: "unit-test", DataDir: dir}
+	secret1, err := config.AuthSecret()
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "unit-test", datadirJWTSecret)); err != nil {
+		t.Fatalf("secret not persisted to data directory: %v", err)
+	}
+	secret2, err := config.AuthSecret()
+	if err != nil {
+		t.Fatalf("failed to load secret: %v", err)
+	}
+	if !bytes.Equal(secret1, secret2) {
+		t.Fatalf("persisted secret mismatch: have %x, want %x", secret2, secret1)
+	}
+
+	// Configure a node with a secret file
+	secretFile := filepath.Join(dir, "secret")
+	if err := ioutil.WriteFile(secretFile, []byte("0x"+strings.Repeat("ab", 32)+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+	config = &Config{Name: "unit-test", DataDir: dir, JWTSecret: secretFile}
+	secret, err := config.AuthSecret()
+	if err != nil {
+		t.Fatalf("failed to load secret: %v", err)
+	}
+	if !bytes.Equal(secret, bytes.Repeat([]byte{0xab}, 32)) {
+		t.Fatalf("secret mismatch: have %x", secret)
+	}
+
+	// A missing secret file is not generated
+	config = &Config{Name: "unit-test", DataDir: dir, JWTSecret: filepath.Join(dir, "missing")}
+	if _, err := config.AuthSecret(); err == nil {
+		t.Fatalf("missing secret file loaded")
+	}
+}
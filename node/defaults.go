@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/klaytn/klaytn/networks/rpc"
 
@@ -43,22 +44,43 @@ const (
 	DefaultWSPort                 = 8552        // Default TCP port for the websocket RPC server
 	DefaultGRPCHost               = "localhost" // Default host interface for the gRPC server
 	DefaultGRPCPort               = 8553        // Default TCP port for the gRPC server
+	DefaultAuthHost               = "localhost" // Default host interface for the authenticated RPC server
+	DefaultAuthPort               = 8554        // Default TCP port for the authenticated RPC server
+	DefaultGraphQLHost            = "localhost" // Default host interface for the GraphQL server
+	DefaultGraphQLPort            = 8555        // Default TCP port for the GraphQL server
 	DefaultP2PPort                = 32323
 	DefaultP2PSubPort             = 32324
 	DefaultMaxPhysicalConnections = 10 // Default the max number of node's physical connections
 )
 
+const (
+	DefaultGraphQLMaxDepth       = 16               // Default maximum depth of a GraphQL query
+	DefaultGraphQLMaxParallelism = 10               // Default maximum number of resolvers running in parallel for a GraphQL query
+	DefaultGraphQLTimeout        = 10 * time.Second // Default timeout of a GraphQL query
+	DefaultGraphQLMaxBlockSpan   = 1000             // Default maximum number of blocks queried by a GraphQL query
+	DefaultGraphQLMaxLogs        = 10000            // Default maximum number of logs returned by a GraphQL query
+)
+
 // DefaultConfig contains reasonable default settings.
 var DefaultConfig = Config{
-	DBType:           DefaultDBType(),
-	DataDir:          DefaultDataDir(),
-	HTTPPort:         DefaultHTTPPort,
-	HTTPModules:      []string{"net", "web3"},
-	HTTPVirtualHosts: []string{"localhost"},
-	HTTPTimeouts:     rpc.DefaultHTTPTimeouts,
-	WSPort:           DefaultWSPort,
-	WSModules:        []string{"net", "web3"},
-	GRPCPort:         DefaultGRPCPort,
+	DBType:                DefaultDBType(),
+	DataDir:               DefaultDataDir(),
+	HTTPPort:              DefaultHTTPPort,
+	HTTPModules:           []string{"net", "web3"},
+	HTTPVirtualHosts:      []string{"localhost"},
+	HTTPTimeouts:          rpc.DefaultHTTPTimeouts,
+	WSPort:                DefaultWSPort,
+	WSModules:             []string{"net", "web3"},
+	GRPCPort:              DefaultGRPCPort,
+	AuthPort:              DefaultAuthPort,
+	AuthVirtualHosts:      []string{"localhost"},
+	GraphQLPort:           DefaultGraphQLPort,
+	GraphQLVirtualHosts:   []string{"localhost"},
+	GraphQLMaxDepth:       DefaultGraphQLMaxDepth,
+	GraphQLMaxParallelism: DefaultGraphQLMaxParallelism,
+	GraphQLTimeout:        DefaultGraphQLTimeout,
+	GraphQLMaxBlockSpan:   DefaultGraphQLMaxBlockSpan,
+	GraphQLMaxLogs:        DefaultGraphQLMaxLogs,
 	P2P: p2p.Config{
 		ListenAddr:             fmt.Sprintf(":%d", DefaultP2PPort),
 		MaxPhysicalConnections: DefaultMaxPhysicalConnections,
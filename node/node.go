@@ -81,6 +81,10 @@ type Node struct {
 	grpcListener *grpc.Listener // gRPC listener socket to server API requests
 	grpcHandler  *rpc.Server    // gRPC request handler to process the API requests
 
+	authEndpoint string       // Authenticated RPC endpoint (interface + port) to listen at (empty = disabled)
+	authListener net.Listener // Authenticated RPC listener socket to server API requests
+	authHandler  *rpc.Server  // Authenticated RPC request handler to process the API requests
+
 	stop chan struct{} // Channel to wait for termination notifications
 	lock sync.RWMutex
 
@@ -134,6 +138,7 @@ func New(conf *Config) (*Node, error) {
 		httpEndpoint:      conf.HTTPEndpoint(),
 		wsEndpoint:        conf.WSEndpoint(),
 		grpcEndpoint:      conf.GRPCEndpoint(),
+		authEndpoint:      conf.AuthEndpoint(),
 		eventmux:          new(event.TypeMux),
 		logger:            conf.Logger,
 	}, nil
@@ -351,7 +356,15 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 		}
 	}
 	// start gRPC server
-	if err := n.startgRPC(apis); err != nil {
+	if err := n.startgRPC(apis, services); err != nil {
+		n.stopHTTP()
+		n.stopIPC()
+		n.stopInProc()
+		return err
+	}
+	if err := n.startAuth(apis); err != nil {
+		n.stopgRPC()
+		n.stopWS()
 		n.stopHTTP()
 		n.stopIPC()
 		n.stopInProc()
@@ -415,17 +428,28 @@ func (n *Node) stopIPC() {
 }
 
 // startgRPC initializes and starts the gRPC endpoint.
-func (n *Node) startgRPC(apis []rpc.API) error {
+// The typed KlayAPI service is served if the klay module is exposed and
+// a service provides an api.Backend.
+func (n *Node) startgRPC(apis []rpc.API, services map[reflect.Type]Service) error {
 	if n.grpcEndpoint == "" {
 		return nil
 	}
 
+	// Generate the whitelist based on the allowed modules
+	whitelist := make(map[string]bool)
+	for _, module := range n.config.GRPCModules {
+		whitelist[module] = true
+	}
 	handler := rpc.NewServer()
+	klayExposed := false
 	for _, api := range apis {
-		if api.Public {
+		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
 				return err
 			}
+			if api.Namespace == "klay" {
+				klayExposed = true
+			}
 			n.logger.Debug("gRPC registered", "namespace", api.Namespace)
 		}
 	}
@@ -434,6 +458,14 @@ func (n *Node) startgRPC(apis []rpc.API) error {
 	n.grpcHandler = handler
 	n.grpcListener = listener
 	listener.SetRPCServer(handler)
+	if klayExposed {
+		for _, service := range services {
+			if provider, ok := service.(grpc.APIBackendProvider); ok {
+				listener.SetAPIBackend(provider.KlayAPIBackend())
+				break
+			}
+		}
+	}
 
 	go listener.Start()
 	n.logger.Info("gRPC endpoint opened", "url", n.grpcEndpoint)
@@ -544,6 +576,39 @@ func (n *Node) stopWS() {
 	}
 }
 
+// startAuth initializes and starts the authenticated RPC endpoint.
+func (n *Node) startAuth(apis []rpc.API) error {
+	if n.authEndpoint == "" {
+		return nil
+	}
+	secret, err := n.config.AuthSecret()
+	if err != nil {
+		return err
+	}
+	listener, handler, err := rpc.StartAuthEndpoint(n.authEndpoint, apis, n.config.AuthModules, n.config.AuthVirtualHosts, n.config.HTTPTimeouts, secret)
+	if err != nil {
+		return err
+	}
+	n.logger.Info("Authenticated RPC endpoint opened", "url", fmt.Sprintf("http://%s", n.authEndpoint), "vhosts", strings.Join(n.config.AuthVirtualHosts, ","))
+	n.authListener = listener
+	n.authHandler = handler
+	return nil
+}
+
+// stopAuth terminates the authenticated RPC endpoint.
+func (n *Node) stopAuth() {
+	if n.authListener != nil {
+		n.authListener.Close()
+		n.authListener = nil
+
+		n.logger.Info("Authenticated RPC endpoint closed", "url", fmt.Sprintf("http://%s", n.authEndpoint))
+	}
+	if n.authHandler != nil {
+		n.authHandler.Stop()
+		n.authHandler = nil
+	}
+}
+
 func (n *Node) stopgRPC() {
 	if n.grpcListener != nil {
 		n.grpcListener.Stop()
@@ -574,6 +639,7 @@ func (n *Node) Stop() error {
 	n.stopHTTP()
 	n.stopIPC()
 	n.stopgRPC()
+	n.stopAuth()
 	n.rpcAPIs = nil
 	failure := &StopError{
 		Services: make(map[reflect.Type]error),
@@ -162,6 +162,7 @@ type ChainConfig struct {
 	ChainID *big.Int `json:"chainId"` // chainId identifies the current chain and is used for replay protection
 
 	IstanbulCompatibleBlock *big.Int `json:"istanbulCompatibleBlock,omitempty"` // IstanbulCompatibleBlock switch block (nil = no fork, 0 = already on istanbul)
+	MagmaCompatibleBlock    *big.Int `json:"magmaCompatibleBlock,omitempty"`    // MagmaCompatibleBlock switch block (nil = no fork, 0 = already on magma)
 
 	// Various consensus engines
 	Gxhash   *GxhashConfig   `json:"gxhash,omitempty"` // (deprecated) not supported engine
@@ -175,15 +176,26 @@ type ChainConfig struct {
 
 // GovernanceConfig stores governance information for a network
 type GovernanceConfig struct {
-	GoverningNode  common.Address `json:"governingNode"`
-	GovernanceMode string         `json:"governanceMode"`
-	Reward         *RewardConfig  `json:"reward,omitempty"`
+	GoverningNode    common.Address `json:"governingNode"`
+	GovernanceMode   string         `json:"governanceMode"`
+	GovParamContract common.Address `json:"govParamContract,omitempty"` // Optional contract which overrides governance parameters
+	Reward           *RewardConfig  `json:"reward,omitempty"`
+	KIP71            *KIP71Config   `json:"kip71,omitempty"`
 }
 
 func (g *GovernanceConfig) DeferredTxFee() bool {
 	return g.Reward.DeferredTxFee
 }
 
+// KIP71Config returns the dynamic base fee configuration. The default one is
+// returned if it is not configured.
+func (g *GovernanceConfig) KIP71Config() *KIP71Config {
+	if g == nil || g.KIP71 == nil {
+		return GetDefaultKIP71Config()
+	}
+	return g.KIP71
+}
+
 // RewardConfig stores information about the network's token economy
 type RewardConfig struct {
 	MintingAmount          *big.Int `json:"mintingAmount"`
@@ -195,6 +207,17 @@ type RewardConfig struct {
 	MinimumStake           *big.Int `json:"minimumStake"`           // Minimum amount of peb to join CCO
 }
 
+// KIP71Config stores the parameters of the dynamic base fee applied after the magma fork.
+// The base fee of a block is adjusted from the base fee of its parent by the gas usage
+// of the parent compared with GasTarget, and bounded by LowerBoundBaseFee and UpperBoundBaseFee.
+type KIP71Config struct {
+	LowerBoundBaseFee         uint64 `json:"lowerBoundBaseFee"`         // Minimum base fee in peb
+	UpperBoundBaseFee         uint64 `json:"upperBoundBaseFee"`         // Maximum base fee in peb
+	GasTarget                 uint64 `json:"gasTarget"`                 // Block gas usage at which the base fee stays the same
+	MaxBlockGasUsedForBaseFee uint64 `json:"maxBlockGasUsedForBaseFee"` // Block gas usage cap used to calculate the next base fee
+	BaseFeeDenominator        uint64 `json:"baseFeeDenominator"`        // Bound divisor of the base fee change between blocks
+}
+
 // IstanbulConfig is the consensus engine configs for Istanbul based sealing.
 type IstanbulConfig struct {
 	Epoch          uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
@@ -241,18 +264,20 @@ func (c *ChainConfig) String() string {
 		engine = "unknown"
 	}
 	if c.Istanbul != nil {
-		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v SubGroupSize: %d UnitPrice: %d DeriveShaImpl: %d Engine: %v}",
+		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v MagmaCompatibleBlock: %v SubGroupSize: %d UnitPrice: %d DeriveShaImpl: %d Engine: %v}",
 			c.ChainID,
 			c.IstanbulCompatibleBlock,
+			c.MagmaCompatibleBlock,
 			c.Istanbul.SubGroupSize,
 			c.UnitPrice,
 			c.DeriveShaImpl,
 			engine,
 		)
 	} else {
-		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v UnitPrice: %d DeriveShaImpl: %d Engine: %v }",
+		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v MagmaCompatibleBlock: %v UnitPrice: %d DeriveShaImpl: %d Engine: %v }",
 			c.ChainID,
 			c.IstanbulCompatibleBlock,
+			c.MagmaCompatibleBlock,
 			c.UnitPrice,
 			c.DeriveShaImpl,
 			engine,
@@ -265,6 +290,12 @@ func (c *ChainConfig) IsIstanbul(num *big.Int) bool {
 	return isForked(c.IstanbulCompatibleBlock, num)
 }
 
+// IsMagma returns whether num is either equal to the magma block or greater.
+// After the magma fork, the gas price of a block is its dynamic base fee.
+func (c *ChainConfig) IsMagma(num *big.Int) bool {
+	return isForked(c.MagmaCompatibleBlock, num)
+}
+
 // GasTable returns the gas table corresponding to the current phase.
 //
 // The returned GasTable's fields shouldn't, under any circumstances, be changed.
@@ -294,6 +325,9 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.IstanbulCompatibleBlock, newcfg.IstanbulCompatibleBlock, head) {
 		return newCompatError("Istanbul Block", c.IstanbulCompatibleBlock, newcfg.IstanbulCompatibleBlock)
 	}
+	if isForkIncompatible(c.MagmaCompatibleBlock, newcfg.MagmaCompatibleBlock, head) {
+		return newCompatError("Magma Block", c.MagmaCompatibleBlock, newcfg.MagmaCompatibleBlock)
+	}
 	return nil
 }
 
@@ -407,6 +441,7 @@ func (err *ConfigCompatError) Error() string {
 type Rules struct {
 	ChainID    *big.Int
 	IsIstanbul bool
+	IsMagma    bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -418,6 +453,7 @@ func (c *ChainConfig) Rules(num *big.Int) Rules {
 	return Rules{
 		ChainID:    new(big.Int).Set(chainID),
 		IsIstanbul: c.IsIstanbul(num),
+		IsMagma:    c.IsMagma(num),
 	}
 }
 
@@ -432,6 +468,11 @@ func (g *GovernanceConfig) Copy() *GovernanceConfig {
 	newConfig.Reward.UseGiniCoeff = g.Reward.UseGiniCoeff
 	newConfig.Reward.DeferredTxFee = g.Reward.DeferredTxFee
 	newConfig.GoverningNode = g.GoverningNode
+	newConfig.GovParamContract = g.GovParamContract
+	if g.KIP71 != nil {
+		kip71 := *g.KIP71
+		newConfig.KIP71 = &kip71
+	}
 
 	return newConfig
 }
@@ -476,6 +517,16 @@ func GetDefaultRewardConfig() *RewardConfig {
 	}
 }
 
+func GetDefaultKIP71Config() *KIP71Config {
+	return &KIP71Config{
+		LowerBoundBaseFee:         DefaultLowerBoundBaseFee,
+		UpperBoundBaseFee:         DefaultUpperBoundBaseFee,
+		GasTarget:                 DefaultGasTarget,
+		MaxBlockGasUsedForBaseFee: DefaultMaxBlockGasUsedForBaseFee,
+		BaseFeeDenominator:        DefaultBaseFeeDenominator,
+	}
+}
+
 func GetDefaultCliqueConfig() *CliqueConfig {
 	return &CliqueConfig{
 		Epoch:  DefaultEpoch,
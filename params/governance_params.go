@@ -89,6 +89,12 @@ const (
 	ConstTxGasHumanReadable
 	CliqueEpoch
 	Timeout
+	LowerBoundBaseFee
+	UpperBoundBaseFee
+	GasTarget
+	MaxBlockGasUsedForBaseFee
+	BaseFeeDenominator
+	GovParamContract
 )
 
 const (
@@ -119,6 +125,13 @@ const (
 	DefaultDefferedTxFee  = false
 	DefaultUnitPrice      = uint64(250000000000)
 	DefaultPeriod         = 1
+
+	// Default values of the dynamic base fee (KIP71Config)
+	DefaultLowerBoundBaseFee         = uint64(25000000000)
+	DefaultUpperBoundBaseFee         = uint64(750000000000)
+	DefaultGasTarget                 = uint64(30000000)
+	DefaultMaxBlockGasUsedForBaseFee = uint64(60000000)
+	DefaultBaseFeeDenominator        = uint64(20)
 )
 
 func IsStakingUpdateInterval(blockNum uint64) bool {
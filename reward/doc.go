@@ -71,7 +71,7 @@ All configurations are saved as rewardConfig on every epoch block (default 604,8
 
 A proposer which has made a current block will get the reward of the block.
 A block reward is calculated by following steps.
-First, calculate totalReward by adding mintingAmount and totalTxFee (unitPrice * gasUsed).
+First, calculate totalReward by adding mintingAmount and totalTxFee (unitPrice * gasUsed, or baseFee * gasUsed after the magma hard fork).
 Second, divide totalReward by ratio (default 34/54/12 - proposer/PoC/KIR).
 Last, distribute reward to each address (proposer, PoC, KIR).
 
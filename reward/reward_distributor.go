@@ -51,9 +51,14 @@ func NewRewardDistributor(gh governanceHelper) *RewardDistributor {
 }
 
 // getTotalTxFee returns the total transaction gas fee of the block.
+// After the magma hard fork, every transaction pays the base fee of the block.
 func (rd *RewardDistributor) getTotalTxFee(header *types.Header, rewardConfig *rewardConfig) *big.Int {
+	gasPrice := rewardConfig.unitPrice
+	if header.BaseFee != nil {
+		gasPrice = header.BaseFee
+	}
 	totalGasUsed := big.NewInt(0).SetUint64(header.GasUsed)
-	totalTxFee := totalGasUsed.Mul(totalGasUsed, rewardConfig.unitPrice)
+	totalTxFee := totalGasUsed.Mul(totalGasUsed, gasPrice)
 	return totalTxFee
 }
 
@@ -165,6 +165,9 @@ type DBManager interface {
 	WriteSectionHead(encodedSection []byte, hash common.Hash)
 	DeleteSectionHead(encodedSection []byte)
 
+	ReadTxPoolSnapshot() types.Transactions
+	WriteTxPoolSnapshot(txs types.Transactions) error
+
 	// from accessors_metadata.go
 	ReadDatabaseVersion() *uint64
 	WriteDatabaseVersion(version uint64)
@@ -213,6 +216,14 @@ type DBManager interface {
 	ReadGovernanceAtNumber(num uint64, epoch uint64) (uint64, map[string]interface{}, error)
 	WriteGovernanceState(b []byte) error
 	ReadGovernanceState() ([]byte, error)
+	WriteGovernanceVoteRecord(num uint64, record []byte) error
+	ReadGovernanceVoteRecords(start, end uint64) [][]byte
+	WriteGovernanceEpochRecord(num uint64, record []byte) error
+	ReadGovernanceEpochRecords(start, end uint64) [][]byte
+
+	// Istanbul evidence related functions
+	WriteIstanbulEvidence(sequence uint64, hash common.Hash, evidence []byte) error
+	ReadIstanbulEvidences(start, end uint64) [][]byte
 
 	// StakingInfo related functions
 	ReadStakingInfo(blockNum uint64) ([]byte, error)
@@ -224,6 +235,50 @@ type DBManager interface {
 	// ChainDataFetcher checkpoint function
 	WriteChainDataFetcherCheckpoint(checkpoint uint64) error
 	ReadChainDataFetcherCheckpoint() (uint64, error)
+
+	// Flat state snapshot related functions
+	ReadSnapshotRoot() common.Hash
+	WriteSnapshotRoot(root common.Hash)
+	DeleteSnapshotRoot()
+
+	ReadAccountSnapshot(hash common.Hash) []byte
+	WriteAccountSnapshot(hash common.Hash, entry []byte)
+	PutAccountSnapshotToBatch(batch Batch, hash common.Hash, entry []byte)
+	DeleteAccountSnapshot(hash common.Hash)
+
+	ReadStorageSnapshot(accountHash, storageHash common.Hash) []byte
+	WriteStorageSnapshot(accountHash, storageHash common.Hash, entry []byte)
+	PutStorageSnapshotToBatch(batch Batch, accountHash, storageHash common.Hash, entry []byte)
+	DeleteStorageSnapshot(accountHash, storageHash common.Hash)
+
+	NewSnapshotDBIterator(prefix []byte, start []byte) Iterator
+
+	ReadSnapshotJournal() []byte
+	WriteSnapshotJournal(journal []byte)
+	DeleteSnapshotJournal()
+
+	ReadSnapshotGenerator() []byte
+	WriteSnapshotGenerator(generator []byte)
+	DeleteSnapshotGenerator()
+
+	ReadSnapshotSyncStatus() []byte
+	WriteSnapshotSyncStatus(status []byte)
+	DeleteSnapshotSyncStatus()
+
+	// Ancient (freezer) store related functions
+	Ancients() uint64
+	TruncateAncients(items uint64) error
+
+	// Live pruning related functions
+	ReadPruningEnabled() bool
+	WritePruningEnabled()
+
+	ReadTrieNodeRecord(hash common.Hash) []byte
+
+	WritePruningMark(number uint64, root common.Hash)
+	HasPruningMark(number uint64, root common.Hash) bool
+	ReadPruningMarks(start, end uint64) []PruningMark
+	DeletePruningMark(mark PruningMark)
 }
 
 type DBEntryType uint8
@@ -237,6 +292,7 @@ const (
 	StateTrieMigrationDB
 	TxLookUpEntryDB
 	bridgeServiceDB
+	SnapshotDB
 	// databaseEntryTypeSize should be the last item in this list!!
 	databaseEntryTypeSize
 )
@@ -257,6 +313,7 @@ var dbBaseDirs = [databaseEntryTypeSize]string{
 	"statetrie_migrated", // "statetrie_migrated_#N" path will be used. (#N is a migrated block number.)
 	"txlookup",
 	"bridgeservice",
+	"snapshot",
 }
 
 // Sum of dbConfigRatio should be 100.
@@ -266,10 +323,11 @@ var dbConfigRatio = [databaseEntryTypeSize]int{
 	5,  // headerDB
 	5,  // BodyDB
 	5,  // ReceiptsDB
-	40, // StateTrieDB
+	37, // StateTrieDB
 	40, // StateTrieMigrationDB
 	2,  // TXLookUpEntryDB
 	1,  // bridgeServiceDB
+	3,  // SnapshotDB
 }
 
 // checkDBEntryConfigRatio checks if sum of dbConfigRatio is 100.
@@ -315,6 +373,13 @@ type databaseManager struct {
 	lockInMigration      sync.RWMutex
 	inMigration          bool
 	migrationBlockNumber uint64
+
+	// ancient is the append-only store holding the canonical blocks older than
+	// AncientThreshold. It is nil if the ancient store is disabled.
+	ancient     *freezer
+	ancientLock sync.Mutex
+	quitFreezer chan struct{}
+	freezerWg   sync.WaitGroup
 }
 
 func NewMemoryDBManager() DBManager {
@@ -348,13 +413,19 @@ type DBConfig struct {
 
 	// DynamoDB related configurations
 	DynamoDBConfig *DynamoDBConfig
+
+	// Ancient store related configurations.
+	// AncientThreshold is the number of recent blocks kept in the key-value
+	// databases. Older canonical blocks are moved to the ancient store.
+	// The ancient store is disabled if it is 0.
+	AncientThreshold uint64
 }
 
 const dbMetricPrefix = "klay/db/chaindata/"
 
 // singleDatabaseDBManager returns DBManager which handles one single Database.
 // Each Database will share one common Database.
-func singleDatabaseDBManager(dbc *DBConfig) (DBManager, error) {
+func singleDatabaseDBManager(dbc *DBConfig) (*databaseManager, error) {
 	dbm := newDatabaseManager(dbc)
 	db, err := newDatabase(dbc, 0)
 	if err != nil {
@@ -460,6 +531,7 @@ func NewDBManager(dbc *DBConfig) DBManager {
 		if dbm, err := singleDatabaseDBManager(dbc); err != nil {
 			logger.Crit("Failed to create a single database", "DBType", dbc.DBType, "err", err)
 		} else {
+			dbm.openAncient()
 			return dbm
 		}
 	} else {
@@ -478,6 +550,7 @@ func NewDBManager(dbc *DBConfig) DBManager {
 				dbm.migrationBlockNumber = migrationBlockNum
 			}
 		}
+		dbm.openAncient()
 		return dbm
 	}
 	logger.Crit("Must not reach here!")
@@ -729,7 +802,7 @@ func removeDB(dbPath string, endCheck chan struct{}) {
 }
 
 func (dbm *databaseManager) GetStateTrieDB() Database {
-	return dbm.dbs[StateTrieDB]
+	return dbm.getDatabase(StateTrieDB)
 }
 
 func (dbm *databaseManager) GetStateTrieMigrationDB() Database {
@@ -767,6 +840,8 @@ func (dbm *databaseManager) getDatabase(dbEntryType DBEntryType) Database {
 }
 
 func (dbm *databaseManager) Close() {
+	dbm.closeAncient()
+
 	// If single DB, only close the first database.
 	if dbm.config.SingleDB {
 		dbm.dbs[0].Close()
@@ -791,6 +866,9 @@ func (dbm *databaseManager) ReadCanonicalHash(number uint64) common.Hash {
 
 	db := dbm.getDatabase(headerDB)
 	data, _ := db.Get(headerHashKey(number))
+	if len(data) == 0 {
+		data = dbm.readAncient(freezerHashTable, number)
+	}
 	if len(data) == 0 {
 		return common.Hash{}
 	}
@@ -904,7 +982,7 @@ func (dbm *databaseManager) HasHeader(hash common.Hash, number uint64) bool {
 
 	db := dbm.getDatabase(headerDB)
 	if has, err := db.Has(headerKey(number, hash)); !has || err != nil {
-		return false
+		return dbm.hasAncient(freezerHeaderTable, hash, number)
 	}
 	return true
 }
@@ -934,6 +1012,9 @@ func (dbm *databaseManager) ReadHeader(hash common.Hash, number uint64) *types.H
 func (dbm *databaseManager) ReadHeaderRLP(hash common.Hash, number uint64) rlp.RawValue {
 	db := dbm.getDatabase(headerDB)
 	data, _ := db.Get(headerKey(number, hash))
+	if len(data) == 0 {
+		data = dbm.readAncientByHash(freezerHeaderTable, hash, number)
+	}
 	return data
 }
 
@@ -1005,7 +1086,7 @@ func (dbm *databaseManager) ReadHeaderNumber(hash common.Hash) *uint64 {
 func (dbm *databaseManager) HasBody(hash common.Hash, number uint64) bool {
 	db := dbm.getDatabase(BodyDB)
 	if has, err := db.Has(blockBodyKey(number, hash)); !has || err != nil {
-		return false
+		return dbm.hasAncient(freezerBodiesTable, hash, number)
 	}
 	return true
 }
@@ -1055,6 +1136,9 @@ func (dbm *databaseManager) ReadBodyRLP(hash common.Hash, number uint64) rlp.Raw
 	// not found in cache, find body in database
 	db := dbm.getDatabase(BodyDB)
 	data, _ := db.Get(blockBodyKey(number, hash))
+	if len(data) == 0 {
+		data = dbm.readAncientByHash(freezerBodiesTable, hash, number)
+	}
 
 	// Write to cache at the end of successful read.
 	dbm.cm.writeBodyRLPCache(hash, data)
@@ -1084,6 +1168,9 @@ func (dbm *databaseManager) ReadBodyRLPByHash(hash common.Hash) rlp.RawValue {
 
 	db := dbm.getDatabase(BodyDB)
 	data, _ := db.Get(blockBodyKey(*number, hash))
+	if len(data) == 0 {
+		data = dbm.readAncientByHash(freezerBodiesTable, hash, *number)
+	}
 
 	// Write to cache at the end of successful read.
 	dbm.cm.writeBodyRLPCache(hash, data)
@@ -1203,6 +1290,9 @@ func (dbm *databaseManager) ReadReceipts(blockHash common.Hash, number uint64) t
 	db := dbm.getDatabase(ReceiptsDB)
 	// Retrieve the flattened receipt slice
 	data, _ := db.Get(blockReceiptsKey(number, blockHash))
+	if len(data) == 0 {
+		data = dbm.readAncientByHash(freezerReceiptTable, blockHash, number)
+	}
 	if len(data) == 0 {
 		return nil
 	}
@@ -1733,6 +1823,34 @@ func (dbm *databaseManager) DeleteSectionHead(encodedSection []byte) {
 	db.Delete(sectionHeadKey(encodedSection))
 }
 
+// TxPool snapshot operations.
+// ReadTxPoolSnapshot retrieves the transactions of the transaction pool stored
+// by WriteTxPoolSnapshot. It returns nil if no snapshot exists.
+func (dbm *databaseManager) ReadTxPoolSnapshot() types.Transactions {
+	db := dbm.getDatabase(MiscDB)
+	enc, _ := db.Get(txPoolSnapshotKey)
+	if len(enc) == 0 {
+		return nil
+	}
+
+	var txs types.Transactions
+	if err := rlp.DecodeBytes(enc, &txs); err != nil {
+		logger.Error("Failed to decode txpool snapshot", "err", err)
+		return nil
+	}
+	return txs
+}
+
+// WriteTxPoolSnapshot replaces the stored transactions of the transaction pool.
+func (dbm *databaseManager) WriteTxPoolSnapshot(txs types.Transactions) error {
+	enc, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		return err
+	}
+	db := dbm.getDatabase(MiscDB)
+	return db.Put(txPoolSnapshotKey, enc)
+}
+
 // ReadDatabaseVersion retrieves the version number of the database.
 func (dbm *databaseManager) ReadDatabaseVersion() *uint64 {
 	db := dbm.getDatabase(MiscDB)
@@ -2078,6 +2196,60 @@ func (dbm *databaseManager) ReadGovernanceState() ([]byte, error) {
 	return db.Get(governanceStateKey)
 }
 
+// WriteGovernanceVoteRecord stores the record of the governance vote in the given block.
+func (dbm *databaseManager) WriteGovernanceVoteRecord(num uint64, record []byte) error {
+	db := dbm.getDatabase(MiscDB)
+	return db.Put(govRecordKey(govVoteRecordPrefix, num), record)
+}
+
+// ReadGovernanceVoteRecords retrieves the records of the governance votes in the blocks in the range [start, end).
+func (dbm *databaseManager) ReadGovernanceVoteRecords(start, end uint64) [][]byte {
+	return dbm.readNumberedRecords(govVoteRecordPrefix, 0, start, end)
+}
+
+// WriteGovernanceEpochRecord stores the record of the governance tally and changes at the given epoch block.
+func (dbm *databaseManager) WriteGovernanceEpochRecord(num uint64, record []byte) error {
+	db := dbm.getDatabase(MiscDB)
+	return db.Put(govRecordKey(govEpochRecordPrefix, num), record)
+}
+
+// ReadGovernanceEpochRecords retrieves the records of the epoch blocks in the range [start, end).
+func (dbm *databaseManager) ReadGovernanceEpochRecords(start, end uint64) [][]byte {
+	return dbm.readNumberedRecords(govEpochRecordPrefix, 0, start, end)
+}
+
+// WriteIstanbulEvidence stores the evidence of a misbehaving validator found in the given sequence.
+func (dbm *databaseManager) WriteIstanbulEvidence(sequence uint64, hash common.Hash, evidence []byte) error {
+	db := dbm.getDatabase(MiscDB)
+	return db.Put(istanbulEvidenceKey(sequence, hash), evidence)
+}
+
+// ReadIstanbulEvidences retrieves the evidences found in the sequences in the range [start, end).
+func (dbm *databaseManager) ReadIstanbulEvidences(start, end uint64) [][]byte {
+	return dbm.readNumberedRecords(istanbulEvidencePrefix, common.HashLength, start, end)
+}
+
+// readNumberedRecords retrieves the records whose keys are the prefix, a number in the range [start, end)
+// and a suffix of the given length.
+func (dbm *databaseManager) readNumberedRecords(prefix []byte, suffixLen int, start, end uint64) [][]byte {
+	db := dbm.getDatabase(MiscDB)
+	it := db.NewIterator(prefix, common.Int64ToByteBigEndian(start))
+	defer it.Release()
+
+	var records [][]byte
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+suffixLen {
+			continue
+		}
+		if binary.BigEndian.Uint64(key[len(prefix):len(prefix)+8]) >= end {
+			break
+		}
+		records = append(records, common.CopyBytes(it.Value()))
+	}
+	return records
+}
+
 func (dbm *databaseManager) WriteChainDataFetcherCheckpoint(checkpoint uint64) error {
 	db := dbm.getDatabase(MiscDB)
 	return db.Put(chaindatafetcherCheckpointKey, common.Int64ToByteBigEndian(checkpoint))
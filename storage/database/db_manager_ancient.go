@@ -0,0 +1,244 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+const (
+	// freezerRecheckInterval is the frequency to check the key-value databases for
+	// chain progression that might permit new blocks to be frozen into the ancient store.
+	freezerRecheckInterval = time.Minute
+
+	// freezerBatchLimit is the maximum number of blocks to freeze in one batch
+	// before doing an fsync and deleting them from the key-value databases.
+	freezerBatchLimit = 30000
+
+	// ancientDirName is the name of the directory of the ancient store under
+	// the chain data directory.
+	ancientDirName = "ancient"
+)
+
+// openAncient opens the ancient store and starts moving old canonical blocks
+// into it if DBConfig.AncientThreshold is set.
+func (dbm *databaseManager) openAncient() {
+	if dbm.config.AncientThreshold == 0 {
+		return
+	}
+	if dbm.config.DBType == MemoryDB || dbm.config.DBType == DynamoDB {
+		logger.Warn("Ancient store is not supported for the database type", "DBType", dbm.config.DBType)
+		return
+	}
+	ancient, err := newFreezer(filepath.Join(dbm.config.Dir, ancientDirName))
+	if err != nil {
+		logger.Crit("Failed to open ancient database", "err", err)
+	}
+	dbm.ancient = ancient
+	dbm.quitFreezer = make(chan struct{})
+
+	dbm.freezerWg.Add(1)
+	go dbm.freeze()
+}
+
+// closeAncient stops moving blocks into the ancient store and closes it.
+func (dbm *databaseManager) closeAncient() {
+	if dbm.ancient == nil {
+		return
+	}
+	close(dbm.quitFreezer)
+	dbm.freezerWg.Wait()
+
+	if err := dbm.ancient.Close(); err != nil {
+		logger.Error("Failed to close ancient database", "err", err)
+	}
+}
+
+// Ancients returns the number of blocks stored in the ancient store.
+func (dbm *databaseManager) Ancients() uint64 {
+	if dbm.ancient == nil {
+		return 0
+	}
+	return dbm.ancient.Ancients()
+}
+
+// TruncateAncients discards the blocks in the ancient store whose numbers are
+// equal to or greater than items. It is used when the chain is rewound.
+func (dbm *databaseManager) TruncateAncients(items uint64) error {
+	if dbm.ancient == nil {
+		return nil
+	}
+	dbm.ancientLock.Lock()
+	defer dbm.ancientLock.Unlock()
+
+	return dbm.ancient.TruncateAncients(items)
+}
+
+// readAncient retrieves the data of the given kind from the ancient store.
+// It returns nil if the data is not stored in the ancient store.
+func (dbm *databaseManager) readAncient(kind string, number uint64) []byte {
+	if dbm.ancient == nil {
+		return nil
+	}
+	data, _ := dbm.ancient.Ancient(kind, number)
+	return data
+}
+
+// readAncientByHash retrieves the data of the given kind from the ancient store
+// only if the canonical block at the given number has the given hash.
+func (dbm *databaseManager) readAncientByHash(kind string, hash common.Hash, number uint64) []byte {
+	if !dbm.isAncientHash(hash, number) {
+		return nil
+	}
+	return dbm.readAncient(kind, number)
+}
+
+// hasAncient returns true if the data of the given kind of the block with the
+// given hash and number is stored in the ancient store.
+func (dbm *databaseManager) hasAncient(kind string, hash common.Hash, number uint64) bool {
+	if !dbm.isAncientHash(hash, number) {
+		return false
+	}
+	has, err := dbm.ancient.HasAncient(kind, number)
+	return has && err == nil
+}
+
+// isAncientHash returns true if the block with the given hash and number is
+// stored in the ancient store.
+func (dbm *databaseManager) isAncientHash(hash common.Hash, number uint64) bool {
+	data := dbm.readAncient(freezerHashTable, number)
+	return len(data) != 0 && common.BytesToHash(data) == hash
+}
+
+// freeze is a background thread that periodically checks the blockchain for any
+// import progress and moves ancient data from the key-value databases into the
+// ancient store.
+func (dbm *databaseManager) freeze() {
+	defer dbm.freezerWg.Done()
+
+	for {
+		frozen, err := dbm.freezeAncients()
+		if err != nil {
+			logger.Error("Failed to freeze ancient blocks", "err", err)
+		}
+		// Keep freezing without waiting if a full batch has been frozen.
+		if err == nil && frozen == freezerBatchLimit {
+			select {
+			case <-dbm.quitFreezer:
+				return
+			default:
+				continue
+			}
+		}
+		select {
+		case <-dbm.quitFreezer:
+			return
+		case <-time.After(freezerRecheckInterval):
+		}
+	}
+}
+
+// freezeAncients moves the canonical blocks older than AncientThreshold from
+// the header, body and receipts databases into the ancient store. The data is
+// deleted from the key-value databases only after the ancient store is flushed.
+// It returns the number of blocks frozen.
+func (dbm *databaseManager) freezeAncients() (int, error) {
+	dbm.ancientLock.Lock()
+	defer dbm.ancientLock.Unlock()
+
+	headHash := dbm.ReadHeadBlockHash()
+	if common.EmptyHash(headHash) {
+		return 0, nil
+	}
+	head := dbm.ReadHeaderNumber(headHash)
+	if head == nil || *head <= dbm.config.AncientThreshold {
+		return 0, nil
+	}
+	var (
+		first = dbm.ancient.Ancients()
+		limit = *head - dbm.config.AncientThreshold
+	)
+	if limit <= first {
+		return 0, nil
+	}
+	if limit-first > freezerBatchLimit {
+		limit = first + freezerBatchLimit
+	}
+
+	var (
+		hdb    = dbm.getDatabase(headerDB)
+		bdb    = dbm.getDatabase(BodyDB)
+		rdb    = dbm.getDatabase(ReceiptsDB)
+		start  = time.Now()
+		hashes = make([]common.Hash, 0, limit-first)
+	)
+	for number := first; number < limit; number++ {
+		hash := dbm.ReadCanonicalHash(number)
+		if common.EmptyHash(hash) {
+			return 0, fmt.Errorf("canonical hash missing, can't freeze block %d", number)
+		}
+		header, _ := hdb.Get(headerKey(number, hash))
+		if len(header) == 0 {
+			return 0, fmt.Errorf("block header missing, can't freeze block %d", number)
+		}
+		body, _ := bdb.Get(blockBodyKey(number, hash))
+		if len(body) == 0 {
+			return 0, fmt.Errorf("block body missing, can't freeze block %d", number)
+		}
+		receipts, _ := rdb.Get(blockReceiptsKey(number, hash))
+		if len(receipts) == 0 {
+			return 0, fmt.Errorf("block receipts missing, can't freeze block %d", number)
+		}
+		if err := dbm.ancient.AppendAncient(number, hash.Bytes(), header, body, receipts); err != nil {
+			return 0, err
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := dbm.ancient.Sync(); err != nil {
+		logger.Crit("Failed to flush frozen tables", "err", err)
+	}
+
+	// Wipe out the frozen blocks from the key-value databases. The genesis block
+	// is kept since it is directly accessed in many places.
+	var (
+		headerBatch   = dbm.NewBatch(headerDB)
+		bodyBatch     = dbm.NewBatch(BodyDB)
+		receiptsBatch = dbm.NewBatch(ReceiptsDB)
+	)
+	for i, hash := range hashes {
+		number := first + uint64(i)
+		if number == 0 {
+			continue
+		}
+		headerBatch.Delete(headerHashKey(number))
+		headerBatch.Delete(headerKey(number, hash))
+		bodyBatch.Delete(blockBodyKey(number, hash))
+		receiptsBatch.Delete(blockReceiptsKey(number, hash))
+	}
+	for _, batch := range []Batch{headerBatch, bodyBatch, receiptsBatch} {
+		if err := batch.Write(); err != nil {
+			logger.Crit("Failed to delete frozen blocks", "err", err)
+		}
+	}
+	logger.Info("Moved blocks into the ancient store", "from", first, "to", limit-1,
+		"frozen", limit, "elapsed", common.PrettyDuration(time.Since(start)))
+	return len(hashes), nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDBManager_Ancient tests that old canonical blocks are moved into the
+// ancient store, are still readable through DBManager after being deleted from
+// the key-value databases, and are discarded by TruncateAncients.
+func TestDBManager_Ancient(t *testing.T) {
+	for _, single := range []bool{false, true} {
+		dir, err := ioutil.TempDir(os.TempDir(), "test-db-manager-ancient")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		dbc := &DBConfig{Dir: dir, DBType: LevelDB, SingleDB: single, NumStateTrieShards: 1, AncientThreshold: 2}
+		dbm := NewDBManager(dbc)
+
+		// 1. Write 10 canonical blocks with a receipt each.
+		var blocks []*types.Block
+		for i := 0; i < 10; i++ {
+			header := &types.Header{Number: big.NewInt(int64(i)), Extra: []byte{byte(i)}}
+			if i > 0 {
+				header.ParentHash = blocks[i-1].Hash()
+			}
+			tx, err := genTransaction(uint64(i))
+			assert.NoError(t, err)
+
+			block := types.NewBlockWithHeader(header).WithBody(types.Transactions{tx})
+			dbm.WriteBlock(block)
+			dbm.WriteCanonicalHash(block.Hash(), block.NumberU64())
+			dbm.WriteReceipts(block.Hash(), block.NumberU64(), types.Receipts{genReceipt(i)})
+			blocks = append(blocks, block)
+		}
+		dbm.WriteHeadBlockHash(blocks[9].Hash())
+
+		// 2. Freeze the blocks older than the threshold.
+		_, err = dbm.(*databaseManager).freezeAncients()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), dbm.Ancients())
+
+		// 3. Frozen blocks are deleted from the key-value databases but still readable.
+		checkAncient := func(dbm *databaseManager, frozen uint64) {
+			dbm.ClearHeaderChainCache()
+			dbm.ClearBlockChainCache()
+			for _, block := range blocks {
+				hash, number := block.Hash(), block.NumberU64()
+				if number >= frozen {
+					assert.Nil(t, dbm.readAncient(freezerHashTable, number))
+				} else if number > 0 {
+					data, _ := dbm.getDatabase(headerDB).Get(headerKey(number, hash))
+					assert.Empty(t, data)
+					data, _ = dbm.getDatabase(BodyDB).Get(blockBodyKey(number, hash))
+					assert.Empty(t, data)
+				}
+				if number >= frozen && number < 7 {
+					// Truncated blocks are gone.
+					assert.Nil(t, dbm.ReadBlock(hash, number))
+					continue
+				}
+				assert.Equal(t, hash, dbm.ReadCanonicalHash(number))
+				assert.True(t, dbm.HasHeader(hash, number))
+				assert.True(t, dbm.HasBody(hash, number))
+				assert.Equal(t, hash, dbm.ReadBlock(hash, number).Hash())
+				assert.Equal(t, hash, dbm.ReadBlockByHash(hash).Hash())
+				assert.Equal(t, 1, len(dbm.ReadReceipts(hash, number)))
+
+				// A block with a wrong hash should not be found.
+				assert.Nil(t, dbm.ReadHeaderRLP(blocks[(number+1)%10].Hash(), number))
+			}
+		}
+		checkAncient(dbm.(*databaseManager), 7)
+
+		// 4. The ancient store is reopened with the database.
+		dbm.Close()
+		dbm = NewDBManager(dbc)
+		assert.Equal(t, uint64(7), dbm.Ancients())
+		checkAncient(dbm.(*databaseManager), 7)
+
+		// 5. Truncated blocks are not readable anymore.
+		assert.NoError(t, dbm.TruncateAncients(3))
+		assert.Equal(t, uint64(3), dbm.Ancients())
+		checkAncient(dbm.(*databaseManager), 3)
+		dbm.Close()
+	}
+}
+
+// TestFreezerTable_Repair tests that a freezer table is truncated to the last
+// complete item if its data file is out of sync with its index file.
+func TestFreezerTable_Repair(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test-freezer-table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Use a small file size to spread the items over several data files.
+	table, err := newTable(dir, "test", 50, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, table.Append(uint64(i), []byte{byte(i), byte(i), byte(i), byte(i), byte(i)}))
+	}
+	assert.Error(t, table.Append(100, []byte{100}))
+	assert.NoError(t, table.Sync())
+
+	// Cut off the tail of the head data file.
+	head := table.head.Name()
+	assert.NoError(t, table.Close())
+	stat, err := os.Stat(head)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Truncate(head, stat.Size()-1))
+
+	table, err = newTable(dir, "test", 50, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Close()
+	assert.Equal(t, uint64(9), table.items)
+	for i := 0; i < 9; i++ {
+		blob, err := table.Retrieve(uint64(i))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{byte(i), byte(i), byte(i), byte(i), byte(i)}, blob)
+	}
+	_, err = table.Retrieve(9)
+	assert.Equal(t, errOutOfBounds, err)
+
+	// Truncating and appending again should work across data files.
+	assert.NoError(t, table.truncate(2))
+	assert.NoError(t, table.Append(2, []byte{42}))
+	blob, err := table.Retrieve(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{42}, blob)
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"encoding/binary"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// PruningMark is a root of a state trie retained for the live pruning. The trie
+// is pruned when the block of the mark gets older than the retention window.
+type PruningMark struct {
+	Number uint64
+	Root   common.Hash
+}
+
+// ReadPruningEnabled returns true if the live pruning is enabled for the database.
+func (dbm *databaseManager) ReadPruningEnabled() bool {
+	db := dbm.getDatabase(MiscDB)
+	enabled, _ := db.Has(pruningEnabledKey)
+	return enabled
+}
+
+// WritePruningEnabled records that the live pruning is enabled for the database.
+// Once enabled, the reference counts of trie nodes have to be maintained thereafter.
+func (dbm *databaseManager) WritePruningEnabled() {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Put(pruningEnabledKey, []byte{1}); err != nil {
+		logger.Crit("Failed to store pruning enabled flag", "err", err)
+	}
+}
+
+// ReadTrieNodeRecord retrieves the pruning record of a trie node.
+func (dbm *databaseManager) ReadTrieNodeRecord(hash common.Hash) []byte {
+	db := dbm.getDatabase(StateTrieDB)
+	data, _ := db.Get(TrieNodeRecordKey(hash))
+	return data
+}
+
+// WritePruningMark stores a root of a state trie retained at the given block.
+func (dbm *databaseManager) WritePruningMark(number uint64, root common.Hash) {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Put(pruningMarkKey(number, root), nil); err != nil {
+		logger.Crit("Failed to store pruning mark", "err", err)
+	}
+}
+
+// HasPruningMark returns true if the root of a state trie is retained at the given block.
+func (dbm *databaseManager) HasPruningMark(number uint64, root common.Hash) bool {
+	db := dbm.getDatabase(MiscDB)
+	has, _ := db.Has(pruningMarkKey(number, root))
+	return has
+}
+
+// ReadPruningMarks retrieves the pruning marks of the blocks in the range [start, end).
+func (dbm *databaseManager) ReadPruningMarks(start, end uint64) []PruningMark {
+	db := dbm.getDatabase(MiscDB)
+	it := db.NewIterator(pruningMarkPrefix, common.Int64ToByteBigEndian(start))
+	defer it.Release()
+
+	var marks []PruningMark
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(pruningMarkPrefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(pruningMarkPrefix):])
+		if number >= end {
+			break
+		}
+		marks = append(marks, PruningMark{
+			Number: number,
+			Root:   common.BytesToHash(key[len(pruningMarkPrefix)+8:]),
+		})
+	}
+	return marks
+}
+
+// DeletePruningMark removes a pruning mark.
+func (dbm *databaseManager) DeletePruningMark(mark PruningMark) {
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Delete(pruningMarkKey(mark.Number, mark.Root)); err != nil {
+		logger.Crit("Failed to delete pruning mark", "err", err)
+	}
+}
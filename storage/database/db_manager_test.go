@@ -439,6 +439,43 @@ func TestDBManager_TrieNode(t *testing.T) {
 	}
 }
 
+// TestDBManager_StateTrieIterator tests that the items of a state trie database,
+// including a sharded one, are iterated in the order of their keys.
+func TestDBManager_StateTrieIterator(t *testing.T) {
+	configs := []*DBConfig{
+		{DBType: LevelDB, SingleDB: false, NumStateTrieShards: 1},
+		{DBType: LevelDB, SingleDB: false, NumStateTrieShards: 4},
+		{DBType: MemoryDB, SingleDB: false, NumStateTrieShards: 4},
+	}
+	for _, dbm := range createDBManagers(configs) {
+		hashes := []common.Hash{hash1, hash2, hash3, hash4, {0xff}, {0x01}, {0x02}, {0x81}}
+		batch := dbm.NewBatch(StateTrieDB)
+		for _, hash := range hashes {
+			if err := batch.Put(hash[:], hash[:]); err != nil {
+				t.Fatal("Failed putting a row into the batch", "err", err)
+			}
+		}
+		if _, err := WriteBatches(batch); err != nil {
+			t.Fatal("Failed writing batch", "err", err)
+		}
+
+		var keys [][]byte
+		it := dbm.GetStateTrieDB().NewIterator(nil, nil)
+		for it.Next() {
+			assert.Equal(t, it.Key(), it.Value())
+			keys = append(keys, common.CopyBytes(it.Key()))
+		}
+		assert.NoError(t, it.Error())
+		it.Release()
+
+		assert.Equal(t, len(hashes), len(keys))
+		for i := 1; i < len(keys); i++ {
+			assert.True(t, strings.Compare(string(keys[i-1]), string(keys[i])) < 0)
+		}
+		dbm.Close()
+	}
+}
+
 // TestDBManager_TxLookupEntry tests read, write and delete operations of TxLookupEntries.
 func TestDBManager_TxLookupEntry(t *testing.T) {
 	tx, err := genTransaction(num1)
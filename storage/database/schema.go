@@ -50,6 +50,9 @@ var (
 
 	snapshotKeyPrefix = []byte("snapshot")
 
+	// txPoolSnapshotKey tracks the pending and queued transactions of the transaction pool.
+	txPoolSnapshotKey = []byte("TxPoolSnapshot")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -89,12 +92,38 @@ var (
 	governanceHistoryKey = []byte("governanceIdxHistory")
 	governanceStateKey   = []byte("governanceState")
 
+	govVoteRecordPrefix  = []byte("govVoteRecord")  // govVoteRecordPrefix + num (uint64 big endian) -> governance vote record
+	govEpochRecordPrefix = []byte("govEpochRecord") // govEpochRecordPrefix + num (uint64 big endian) -> governance epoch record
+
+	istanbulEvidencePrefix = []byte("istanbulEvidence") // istanbulEvidencePrefix + sequence (uint64 big endian) + hash -> istanbul evidence
+
 	databaseDirPrefix  = []byte("databaseDirectory")
 	migrationStatusKey = []byte("migrationStatus")
 
 	stakingInfoPrefix = []byte("stakingInfo")
 
 	chaindatafetcherCheckpointKey = []byte("chaindatafetcherCheckpoint")
+
+	// snapshotRootKey tracks the hash of the last snapshot.
+	snapshotRootKey = []byte("SnapshotRoot")
+
+	// snapshotSyncStatusKey tracks the snapshot sync status across restarts.
+	snapshotSyncStatusKey = []byte("SnapshotSyncStatus")
+
+	// snapshotJournalKey tracks the in-memory diff layers across restarts.
+	snapshotJournalKey = []byte("SnapshotJournal")
+
+	// snapshotGeneratorKey tracks the snapshot generation marker across restarts.
+	snapshotGeneratorKey = []byte("SnapshotGenerator")
+
+	SnapshotAccountPrefix = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
+	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
+
+	// pruningEnabledKey tracks whether the live pruning is enabled for the database.
+	pruningEnabledKey = []byte("PruningEnabled")
+
+	pruningMarkPrefix    = []byte("PruningMark") // pruningMarkPrefix + num (uint64 big endian) + root -> nil
+	trieNodeRecordSuffix = []byte("-pr")         // hash + trieNodeRecordSuffix -> pruning record of a trie node
 )
 
 // TxLookupEntry is a positional metadata to help looking up the data content of
@@ -162,6 +191,40 @@ func snapshotKey(hash common.Hash) []byte {
 	return append(snapshotKeyPrefix, hash[:]...)
 }
 
+// AccountSnapshotKey = SnapshotAccountPrefix + hash
+func AccountSnapshotKey(hash common.Hash) []byte {
+	return append(SnapshotAccountPrefix, hash.Bytes()...)
+}
+
+// StorageSnapshotKey = SnapshotStoragePrefix + account hash + storage hash
+func StorageSnapshotKey(accountHash, storageHash common.Hash) []byte {
+	return append(append(SnapshotStoragePrefix, accountHash.Bytes()...), storageHash.Bytes()...)
+}
+
+// pruningMarkKey = pruningMarkPrefix + num (uint64 big endian) + root
+func pruningMarkKey(number uint64, root common.Hash) []byte {
+	return append(append(pruningMarkPrefix, common.Int64ToByteBigEndian(number)...), root.Bytes()...)
+}
+
+// govRecordKey = prefix + num (uint64 big endian)
+func govRecordKey(prefix []byte, number uint64) []byte {
+	return append(append([]byte{}, prefix...), common.Int64ToByteBigEndian(number)...)
+}
+
+func istanbulEvidenceKey(sequence uint64, hash common.Hash) []byte {
+	return append(govRecordKey(istanbulEvidencePrefix, sequence), hash.Bytes()...)
+}
+
+// TrieNodeRecordKey = hash + trieNodeRecordSuffix
+func TrieNodeRecordKey(hash common.Hash) []byte {
+	return append(hash.Bytes(), trieNodeRecordSuffix...)
+}
+
+// StorageSnapshotsKey = SnapshotStoragePrefix + account hash
+func StorageSnapshotsKey(accountHash common.Hash) []byte {
+	return append(SnapshotStoragePrefix, accountHash.Bytes()...)
+}
+
 func childChainTxHashKey(ccBlockHash common.Hash) []byte {
 	return append(append(childChainTxHashPrefix, ccBlockHash.Bytes()...))
 }
@@ -17,6 +17,7 @@
 package database
 
 import (
+	"bytes"
 	"fmt"
 	"path"
 	"strconv"
@@ -156,74 +157,76 @@ func (db *shardedDB) Close() {
 }
 
 type shardedDBIterator struct {
-	// TODO-Klaytn implement this later.
 	iterators []Iterator
-	key       []byte
-	value     []byte
-
-	//numBatches uint
-	//
-	//taskCh   chan pdbBatchTask
-	//resultCh chan pdbBatchResult
+	valid     []bool // Whether each iterator has a current item
+	current   int    // Index of the iterator holding the current item, or -1
+	err       error
 }
 
 // NewIterator creates a binary-alphabetical iterator over a subset
 // of database content with a particular key prefix, starting at a particular
 // initial key (or after, if it does not exist).
+// The items of the shards are merged in the order of their keys.
 func (pdb *shardedDB) NewIterator(prefix []byte, start []byte) Iterator {
-	// TODO-Klaytn implement this later.
-	return nil
+	it := &shardedDBIterator{
+		iterators: make([]Iterator, len(pdb.shards)),
+		valid:     make([]bool, len(pdb.shards)),
+		current:   -1,
+	}
+	for i, shard := range pdb.shards {
+		it.iterators[i] = shard.NewIterator(prefix, start)
+		it.valid[i] = it.iterators[i].Next()
+	}
+	return it
 }
 
+// Next moves the iterator to the item with the smallest key among the shards.
 func (pdi *shardedDBIterator) Next() bool {
-	// TODO-Klaytn implement this later.
-	//var minIter Iterator
-	//minIdx := -1
-	//minKey := []byte{0}
-	//minKeyValue := []byte{0}
-	//
-	//for idx, iter := range pdi.iterators {
-	//	if iter != nil {
-	//		if bytes.Compare(minKey, iter.Key()) >= 0 {
-	//			minIdx = idx
-	//			minIter = iter
-	//			minKey = iter.Key()
-	//			minKeyValue = iter.Value()
-	//		}
-	//	}
-	//}
-	//
-	//if minIter == nil {
-	//	return false
-	//}
-	//
-	//pdi.key = minKey
-	//pdi.value = minKeyValue
-	//
-	//if !minIter.Next() {
-	//	pdi.iterators[minIdx] = nil
-	//}
-	//
-	return true
+	if pdi.err != nil {
+		return false
+	}
+	// Step the iterator which provided the previous item
+	if pdi.current >= 0 {
+		pdi.valid[pdi.current] = pdi.iterators[pdi.current].Next()
+	}
+	pdi.current = -1
+	for i, iter := range pdi.iterators {
+		if !pdi.valid[i] {
+			if err := iter.Error(); err != nil {
+				pdi.err = err
+				return false
+			}
+			continue
+		}
+		if pdi.current < 0 || bytes.Compare(iter.Key(), pdi.iterators[pdi.current].Key()) < 0 {
+			pdi.current = i
+		}
+	}
+	return pdi.current >= 0
 }
 
 func (pdi *shardedDBIterator) Error() error {
-	// TODO-Klaytn implement this later.
-	return nil
+	return pdi.err
 }
 
 func (pdi *shardedDBIterator) Key() []byte {
-	// TODO-Klaytn implement this later.
-	return nil
+	if pdi.current < 0 {
+		return nil
+	}
+	return pdi.iterators[pdi.current].Key()
 }
 
 func (pdi *shardedDBIterator) Value() []byte {
-	// TODO-Klaytn implement this later.
-	return nil
+	if pdi.current < 0 {
+		return nil
+	}
+	return pdi.iterators[pdi.current].Value()
 }
 
 func (pdi *shardedDBIterator) Release() {
-	// TODO-Klaytn implement this later.
+	for _, iter := range pdi.iterators {
+		iter.Release()
+	}
 }
 
 func (db *shardedDB) NewBatch() Batch {
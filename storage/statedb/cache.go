@@ -52,6 +52,7 @@ type TrieNodeCache interface {
 	Set(k, v []byte)
 	Get(k []byte) []byte
 	Has(k []byte) ([]byte, bool)
+	Delete(k []byte)
 	UpdateStats() interface{}
 	SaveToFile(filePath string, concurrency int) error
 	Close() error
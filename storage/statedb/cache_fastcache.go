@@ -80,6 +80,10 @@ func (cache *FastCache) Has(k []byte) ([]byte, bool) {
 	return cache.fast.HasGet(nil, k)
 }
 
+func (cache *FastCache) Delete(k []byte) {
+	cache.fast.Del(k)
+}
+
 func (cache *FastCache) UpdateStats() interface{} {
 	var stats fastcache.Stats
 	cache.fast.UpdateStats(&stats)
@@ -64,6 +64,11 @@ func (cache *HybridCache) Has(k []byte) ([]byte, bool) {
 	return cache.remote.Has(k)
 }
 
+func (cache *HybridCache) Delete(k []byte) {
+	cache.local.Delete(k)
+	cache.remote.Delete(k)
+}
+
 func (cache *HybridCache) UpdateStats() interface{} {
 	type stats struct {
 		local  interface{}
@@ -144,6 +144,12 @@ func (cache *RedisCache) Has(k []byte) ([]byte, bool) {
 	return val, true
 }
 
+func (cache *RedisCache) Delete(k []byte) {
+	if err := cache.client.Del(hexutil.Encode(k)).Err(); err != nil {
+		logger.Warn("failed to delete an item from redis cache", "err", err, "key", hexutil.Encode(k))
+	}
+}
+
 func (cache *RedisCache) publish(channel string, msg string) error {
 	return cache.client.Publish(channel, msg).Err()
 }
@@ -64,6 +64,9 @@ var (
 
 	// metric of total node number
 	memcacheNodesGauge = metrics.NewRegisteredGauge("trie/memcache/nodes", nil)
+
+	// metric of live pruning
+	prunedNodesMeter = metrics.NewRegisteredMeter("trie/pruning/nodes", nil)
 )
 
 // secureKeyPrefix is the database key prefix used to store trie node preimages.
@@ -119,6 +122,12 @@ type Database struct {
 	trieNodeCache                TrieNodeCache        // GC friendly memory cache of trie node RLPs
 	trieNodeCacheConfig          *TrieNodeCacheConfig // Configuration of trieNodeCache
 	savingTrieNodeCacheTriggered bool                 // Whether saving trie node cache has been triggered or not
+
+	pruning         bool                     // Whether the live pruning is enabled
+	pruningLock     sync.Mutex               // Lock for serializing the updates of pruning records and the deletion of nodes
+	pendingRefs     map[common.Hash]uint64   // References to in-memory nodes from persisted nodes
+	diskRefs        map[common.Hash]uint64   // References to persisted nodes from in-memory nodes
+	pruneCandidates map[common.Hash]struct{} // Persisted nodes which may have lost their last reference
 }
 
 // rawNode is a simple binary blob used to differentiate between collapsed trie
@@ -450,6 +459,8 @@ func (db *Database) insert(hash common.Hash, lenEncoded uint16, node node) {
 	for _, child := range entry.childs() {
 		if c := db.nodes[child]; c != nil {
 			c.parents++
+		} else if db.pruning {
+			db.diskRefs[child]++
 		}
 	}
 	db.nodes[hash] = entry
@@ -663,6 +674,9 @@ func (db *Database) reference(child common.Hash, parent common.Hash) {
 	// If the node does not exist, it's a node pulled from disk, skip
 	node, ok := db.nodes[child]
 	if !ok {
+		if db.pruning {
+			db.referenceDisk(child, parent)
+		}
 		return
 	}
 	// If the reference already exists, only duplicate for roots
@@ -718,6 +732,9 @@ func (db *Database) dereference(child common.Hash, parent common.Hash) {
 	// If the node does not exist, it's a previously committed node.
 	node, ok := db.nodes[child]
 	if !ok {
+		if db.pruning {
+			db.dereferenceDisk(child)
+		}
 		return
 	}
 	// If there are no more references to the child, delete it and cascade
@@ -737,6 +754,11 @@ func (db *Database) dereference(child common.Hash, parent common.Hash) {
 		}
 		delete(db.nodes, child)
 		db.nodesSize -= common.StorageSize(common.HashLength + int(node.size))
+
+		// The node might have been persisted before without any reference left.
+		if db.pruning {
+			db.pruneCandidates[child] = struct{}{}
+		}
 	}
 }
 
@@ -767,13 +789,19 @@ func (db *Database) Cap(limit common.StorageSize) error {
 		}
 	}
 	// Keep committing nodes from the flush-list until we're below allowance
+	var flushed []common.Hash
 	oldest := db.oldest
 	batch := db.diskDB.NewBatch(database.StateTrieDB)
+	db.lockPruning()
 	for size > limit && oldest != (common.Hash{}) {
 		// Fetch the oldest referenced node and push into the batch
 		node := db.nodes[oldest]
+		if db.pruning {
+			flushed = append(flushed, oldest)
+		}
 		enc := node.rlp()
 		if err := database.PutAndWriteBatchesOverThreshold(batch, oldest[:], enc); err != nil {
+			db.unlockPruning()
 			db.lock.RUnlock()
 			return err
 		}
@@ -791,10 +819,20 @@ func (db *Database) Cap(limit common.StorageSize) error {
 	// Flush out any remainder data from the last batch
 	if _, err := database.WriteBatches(batch); err != nil {
 		logger.Error("Failed to write flush list to disk", "err", err)
+		db.unlockPruning()
 		db.lock.RUnlock()
 		return err
 	}
+	if db.pruning {
+		if err := db.writeNodeRecords(false, flushed...); err != nil {
+			logger.Error("Failed to write pruning records to disk", "err", err)
+			db.unlockPruning()
+			db.lock.RUnlock()
+			return err
+		}
+	}
 
+	db.unlockPruning()
 	db.lock.RUnlock()
 
 	// Write successful, clear out the flushed data
@@ -808,6 +846,9 @@ func (db *Database) Cap(limit common.StorageSize) error {
 	for db.oldest != oldest {
 		node := db.nodes[db.oldest]
 		delete(db.nodes, db.oldest)
+		if db.pruning {
+			db.moveRefsToDisk(db.oldest, node)
+		}
 		db.oldest = node.flushNext
 
 		db.nodesSize -= common.StorageSize(common.HashLength + int(node.size))
@@ -949,11 +990,22 @@ func (db *Database) Commit(node common.Hash, report bool, blockNum uint64) error
 
 	// Move the trie itself into the batch, flushing if enough data is accumulated
 	numNodes, nodesSize := len(db.nodes), db.nodesSize
+	db.lockPruning()
 	if err := db.writeBatchNodes(node); err != nil {
+		db.unlockPruning()
 		db.lock.RUnlock()
 		return err
 	}
+	if db.pruning {
+		if err := db.writeNodeRecords(true, node); err != nil {
+			logger.Error("Failed to write pruning records to disk", "err", err)
+			db.unlockPruning()
+			db.lock.RUnlock()
+			return err
+		}
+	}
 
+	db.unlockPruning()
 	db.lock.RUnlock()
 
 	// Write successful, clear out the flushed data
@@ -1016,6 +1068,9 @@ func (db *Database) uncache(hash common.Hash) {
 	}
 	// Node still exists, remove it from the flush-list
 	db.removeNodeInFlushList(hash)
+	if db.pruning {
+		db.moveRefsToDisk(hash, node)
+	}
 	// Uncache the node's subtries and remove the node itself too
 	for _, child := range node.childs() {
 		db.uncache(child)
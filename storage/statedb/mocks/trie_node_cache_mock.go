@@ -47,6 +47,18 @@ func (mr *MockTrieNodeCacheMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTrieNodeCache)(nil).Close))
 }
 
+// Delete mocks base method
+func (m *MockTrieNodeCache) Delete(arg0 []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", arg0)
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockTrieNodeCacheMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTrieNodeCache)(nil).Delete), arg0)
+}
+
 // Get mocks base method
 func (m *MockTrieNodeCache) Get(arg0 []byte) []byte {
 	m.ctrl.T.Helper()
@@ -0,0 +1,371 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+// pruningRecord is the persisted reference counting information of a trie node
+// used by the live pruning. The existence of a record means that the node is
+// persisted and the references to its children are counted.
+type pruningRecord struct {
+	Refs     uint64        // Number of persisted nodes and retained roots referencing this one
+	Blob     bool          // Whether the node is a raw blob (e.g. contract code) without trie children
+	Children []common.Hash // External children (e.g. storage roots, code) referenced by this node
+}
+
+// pruningRecords caches the pruning records read from the database and collects
+// the modified ones to write them at once.
+type pruningRecords struct {
+	diskDB  database.DBManager
+	records map[common.Hash]*pruningRecord
+	dirty   map[common.Hash]struct{}
+}
+
+func newPruningRecords(diskDB database.DBManager) *pruningRecords {
+	return &pruningRecords{
+		diskDB:  diskDB,
+		records: make(map[common.Hash]*pruningRecord),
+		dirty:   make(map[common.Hash]struct{}),
+	}
+}
+
+// get returns the pruning record of a node, or nil if it does not exist.
+func (r *pruningRecords) get(hash common.Hash) *pruningRecord {
+	if record, ok := r.records[hash]; ok {
+		return record
+	}
+	var record *pruningRecord
+	if enc := r.diskDB.ReadTrieNodeRecord(hash); len(enc) > 0 {
+		record = new(pruningRecord)
+		if err := rlp.DecodeBytes(enc, record); err != nil {
+			logger.Error("Failed to decode pruning record", "hash", hash, "err", err)
+			record = nil
+		}
+	}
+	r.records[hash] = record
+	return record
+}
+
+// set stores a new pruning record of a node.
+func (r *pruningRecords) set(hash common.Hash, record *pruningRecord) {
+	r.records[hash] = record
+	r.dirty[hash] = struct{}{}
+}
+
+// markDirty marks the pruning record of a node as modified.
+func (r *pruningRecords) markDirty(hash common.Hash) {
+	r.dirty[hash] = struct{}{}
+}
+
+// remove removes the pruning record of a node. The caller has to delete the
+// record from the database by itself.
+func (r *pruningRecords) remove(hash common.Hash) {
+	r.records[hash] = nil
+	delete(r.dirty, hash)
+}
+
+// write puts the modified pruning records into the given batch.
+func (r *pruningRecords) write(batch database.Batch) error {
+	for hash := range r.dirty {
+		record := r.records[hash]
+		if record == nil {
+			continue
+		}
+		enc, err := rlp.EncodeToBytes(record)
+		if err != nil {
+			return err
+		}
+		if err := database.PutAndWriteBatchesOverThreshold(batch, database.TrieNodeRecordKey(hash), enc); err != nil {
+			return err
+		}
+	}
+	r.dirty = make(map[common.Hash]struct{})
+	return nil
+}
+
+// EnablePruning enables the live pruning of the database. It has to be called
+// before any trie node is inserted into the database.
+func (db *Database) EnablePruning() {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.pruning = true
+	db.pendingRefs = make(map[common.Hash]uint64)
+	db.diskRefs = make(map[common.Hash]uint64)
+	db.pruneCandidates = make(map[common.Hash]struct{})
+}
+
+// PruningEnabled returns true if the live pruning is enabled.
+func (db *Database) PruningEnabled() bool {
+	return db.pruning
+}
+
+// lockPruning acquires pruningLock if the live pruning is enabled. It is held
+// while persisting nodes, so that the pruning does not delete a node between
+// writing it and writing its pruning record.
+func (db *Database) lockPruning() {
+	if db.pruning {
+		db.pruningLock.Lock()
+	}
+}
+
+// unlockPruning releases pruningLock acquired by lockPruning.
+func (db *Database) unlockPruning() {
+	if db.pruning {
+		db.pruningLock.Unlock()
+	}
+}
+
+// writeNodeRecords creates the pruning records of the given persisted nodes and
+// writes them to the database. If recursive is true, the records of their
+// in-memory descendants are created as well. The caller has to hold pruningLock.
+func (db *Database) writeNodeRecords(recursive bool, hashes ...common.Hash) error {
+	records := newPruningRecords(db.diskDB)
+	for _, hash := range hashes {
+		db.recordNode(records, hash, recursive)
+	}
+	batch := db.diskDB.NewBatch(database.StateTrieDB)
+	if err := records.write(batch); err != nil {
+		return err
+	}
+	_, err := database.WriteBatches(batch)
+	return err
+}
+
+// recordNode creates the pruning record of an in-memory node which is being
+// persisted, and increases the reference counts of its children.
+func (db *Database) recordNode(records *pruningRecords, hash common.Hash, recursive bool) {
+	node, ok := db.nodes[hash]
+	if !ok || hash == (common.Hash{}) || records.get(hash) != nil {
+		return
+	}
+	children := node.childs()
+	if recursive {
+		for _, child := range children {
+			db.recordNode(records, child, true)
+		}
+	}
+	_, blob := node.node.(rawNode)
+	record := &pruningRecord{Refs: db.pendingRefs[hash], Blob: blob}
+	for child := range node.children {
+		record.Children = append(record.Children, child)
+	}
+	delete(db.pendingRefs, hash)
+	records.set(hash, record)
+
+	for _, child := range children {
+		if childRecord := records.get(child); childRecord != nil {
+			childRecord.Refs++
+			records.markDirty(child)
+		} else if _, ok := db.nodes[child]; ok {
+			db.pendingRefs[child]++
+		}
+	}
+}
+
+// referenceDisk adds a reference from an in-memory node to a persisted node.
+func (db *Database) referenceDisk(child common.Hash, parent common.Hash) {
+	p := db.nodes[parent]
+	if p.children == nil {
+		p.children = make(map[common.Hash]uint64)
+	} else if _, ok := p.children[child]; ok && parent != (common.Hash{}) {
+		return
+	}
+	p.children[child]++
+	db.diskRefs[child]++
+}
+
+// dereferenceDisk removes a reference from an in-memory node to a persisted node.
+func (db *Database) dereferenceDisk(child common.Hash) {
+	if db.diskRefs[child] == 0 {
+		return
+	}
+	db.diskRefs[child]--
+	if db.diskRefs[child] == 0 {
+		delete(db.diskRefs, child)
+		db.pruneCandidates[child] = struct{}{}
+	}
+}
+
+// moveRefsToDisk converts the references around a node, which is being removed
+// from the memory after persisted, into the references to/from a persisted node.
+// The references from the node to its children are kept in the pruning record.
+func (db *Database) moveRefsToDisk(hash common.Hash, node *cachedNode) {
+	if node.parents > 0 {
+		db.diskRefs[hash] += node.parents
+	}
+	for _, child := range node.childs() {
+		if c, ok := db.nodes[child]; ok && child != hash {
+			if c.parents > 0 {
+				c.parents--
+			}
+		} else {
+			db.dereferenceDisk(child)
+		}
+	}
+}
+
+// RetainRoot increases the reference count of a persisted state trie root to keep
+// the trie until the block gets older than the retention window.
+func (db *Database) RetainRoot(root common.Hash, blockNum uint64) {
+	if !db.pruning || db.diskDB.HasPruningMark(blockNum, root) {
+		return
+	}
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	db.pruningLock.Lock()
+	defer db.pruningLock.Unlock()
+
+	records := newPruningRecords(db.diskDB)
+	record := records.get(root)
+	if record == nil {
+		logger.Warn("Failed to retain a state trie without a pruning record", "blockNum", blockNum, "root", root)
+		return
+	}
+	record.Refs++
+	records.markDirty(root)
+
+	batch := db.diskDB.NewBatch(database.StateTrieDB)
+	if err := records.write(batch); err != nil {
+		logger.Error("Failed to write pruning record", "root", root, "err", err)
+		return
+	}
+	if _, err := database.WriteBatches(batch); err != nil {
+		logger.Error("Failed to write pruning record", "root", root, "err", err)
+		return
+	}
+	db.diskDB.WritePruningMark(blockNum, root)
+}
+
+// PruneRoots releases the state trie roots retained at the blocks older than the
+// given limit, and deletes the trie nodes which are not referenced anymore.
+// It returns the number of deleted nodes.
+//
+// The nodes to delete are collected under the lock of the database, but they are
+// deleted after the lock is released not to block the readers. Only the writes of
+// persisted nodes wait for the deletion by pruningLock.
+func (db *Database) PruneRoots(limit uint64) (int, error) {
+	if !db.pruning {
+		return 0, nil
+	}
+	db.lock.Lock()
+	db.pruningLock.Lock()
+	defer db.pruningLock.Unlock()
+
+	records := newPruningRecords(db.diskDB)
+	queue := make([]common.Hash, 0, len(db.pruneCandidates))
+	for hash := range db.pruneCandidates {
+		queue = append(queue, hash)
+	}
+	db.pruneCandidates = make(map[common.Hash]struct{})
+
+	marks := db.diskDB.ReadPruningMarks(0, limit)
+	for _, mark := range marks {
+		if record := records.get(mark.Root); record != nil && record.Refs > 0 {
+			record.Refs--
+			records.markDirty(mark.Root)
+		}
+		queue = append(queue, mark.Root)
+	}
+	hashes := db.collectUnreferenced(records, queue)
+	db.lock.Unlock()
+
+	if err := db.deleteNodes(records, hashes); err != nil {
+		return 0, err
+	}
+	for _, mark := range marks {
+		db.diskDB.DeletePruningMark(mark)
+	}
+	prunedNodesMeter.Mark(int64(len(hashes)))
+	return len(hashes), nil
+}
+
+// collectUnreferenced returns the persisted nodes in the queue and their
+// descendants which are not referenced by any persisted or in-memory node.
+// The pruning records of the returned nodes are removed from the given
+// records, and the reference counts of their children are decreased.
+func (db *Database) collectUnreferenced(records *pruningRecords, queue []common.Hash) []common.Hash {
+	var hashes []common.Hash
+	for len(queue) > 0 {
+		hash := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		record := records.get(hash)
+		if record == nil || record.Refs > 0 || db.diskRefs[hash] > 0 {
+			continue
+		}
+		if _, ok := db.nodes[hash]; ok {
+			continue
+		}
+		children := record.Children
+		if !record.Blob {
+			enc, _ := db.diskDB.ReadCachedTrieNode(hash)
+			if len(enc) == 0 {
+				logger.Error("Missing trie node while pruning", "hash", hash)
+			} else if n, err := decodeNode(hash[:], enc); err != nil {
+				logger.Error("Failed to decode trie node while pruning", "hash", hash, "err", err)
+			} else {
+				gatherChildren(simplifyNode(n), &children)
+			}
+		}
+		records.remove(hash)
+		hashes = append(hashes, hash)
+
+		for _, child := range children {
+			if childRecord := records.get(child); childRecord != nil && childRecord.Refs > 0 {
+				childRecord.Refs--
+				records.markDirty(child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return hashes
+}
+
+// deleteNodes deletes the given nodes and their pruning records in batches, and
+// writes the modified pruning records. The deleted nodes are evicted from the
+// trie node cache as well.
+func (db *Database) deleteNodes(records *pruningRecords, hashes []common.Hash) error {
+	batch := db.diskDB.NewBatch(database.StateTrieDB)
+	for _, hash := range hashes {
+		if err := batch.Delete(hash[:]); err != nil {
+			return err
+		}
+		if err := batch.Delete(database.TrieNodeRecordKey(hash)); err != nil {
+			return err
+		}
+		if _, err := database.WriteBatchesOverThreshold(batch); err != nil {
+			return err
+		}
+	}
+	if err := records.write(batch); err != nil {
+		return err
+	}
+	if _, err := database.WriteBatches(batch); err != nil {
+		return err
+	}
+	if db.trieNodeCache != nil {
+		for _, hash := range hashes {
+			db.trieNodeCache.Delete(hash[:])
+		}
+	}
+	return nil
+}
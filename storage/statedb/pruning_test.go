@@ -0,0 +1,156 @@
+// Copyright 2021 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// commitPruningTestTrie commits a trie to the database and retains its root as
+// the blockchain does with the live pruning enabled.
+func commitPruningTestTrie(t *testing.T, db *Database, trie *Trie, blockNum uint64) common.Hash {
+	root, err := trie.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Reference(root, common.Hash{})
+	if err := db.Commit(root, false, blockNum); err != nil {
+		t.Fatal(err)
+	}
+	db.RetainRoot(root, blockNum)
+	db.Dereference(root)
+	return root
+}
+
+func TestDatabase_LivePruning(t *testing.T) {
+	memDB := database.NewMemoryDBManager()
+	db := NewDatabase(memDB)
+	db.EnablePruning()
+
+	trie, _ := NewTrie(common.Hash{}, db)
+	for i := 0; i < 100; i++ {
+		trie.Update([]byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%03d", i)))
+	}
+	root1 := commitPruningTestTrie(t, db, trie, 1)
+
+	trie, _ = NewTrie(root1, db)
+	trie.Update([]byte("key000"), []byte("updated"))
+	root2 := commitPruningTestTrie(t, db, trie, 2)
+	assert.True(t, memDB.HasPruningMark(1, root1))
+	assert.True(t, memDB.HasPruningMark(2, root2))
+
+	// Nothing is pruned before the roots get out of the retention window.
+	deleted, err := db.PruneRoots(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = db.PruneRoots(2)
+	assert.NoError(t, err)
+	assert.True(t, deleted > 0)
+	assert.False(t, memDB.HasPruningMark(1, root1))
+
+	has, _ := memDB.HasStateTrieNode(root1[:])
+	assert.False(t, has)
+	assert.Nil(t, memDB.ReadTrieNodeRecord(root1))
+
+	// The nodes shared with the retained trie should not be pruned.
+	trie, err = NewTrie(root2, NewDatabase(memDB))
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		expected := []byte(fmt.Sprintf("value%03d", i))
+		if i == 0 {
+			expected = []byte("updated")
+		}
+		val, err := trie.TryGet([]byte(fmt.Sprintf("key%03d", i)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+
+	// All nodes are pruned after the last root is released.
+	deleted, err = db.PruneRoots(3)
+	assert.NoError(t, err)
+	assert.True(t, deleted > 0)
+	has, _ = memDB.HasStateTrieNode(root2[:])
+	assert.False(t, has)
+	assert.Empty(t, memDB.ReadPruningMarks(0, 3))
+}
+
+func TestDatabase_LivePruningInMemoryReference(t *testing.T) {
+	memDB := database.NewMemoryDBManager()
+	db := NewDatabase(memDB)
+	db.EnablePruning()
+
+	trie, _ := NewTrie(common.Hash{}, db)
+	for i := 0; i < 100; i++ {
+		trie.Update([]byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%03d", i)))
+	}
+	root1 := commitPruningTestTrie(t, db, trie, 1)
+
+	// A trie in memory referencing the persisted nodes keeps them from pruning.
+	trie, _ = NewTrie(root1, db)
+	trie.Update([]byte("key000"), []byte("updated"))
+	root2, err := trie.Commit(nil)
+	assert.NoError(t, err)
+	db.Reference(root2, common.Hash{})
+
+	_, err = db.PruneRoots(2)
+	assert.NoError(t, err)
+	has, _ := memDB.HasStateTrieNode(root1[:])
+	assert.False(t, has)
+
+	trie, _ = NewTrie(root2, db)
+	for i := 1; i < 100; i++ {
+		val, err := trie.TryGet([]byte(fmt.Sprintf("key%03d", i)))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("value%03d", i)), val)
+	}
+}
+
+func TestDatabase_LivePruningEvictsCache(t *testing.T) {
+	memDB := database.NewMemoryDBManager()
+	db := NewDatabaseWithNewCache(memDB, &TrieNodeCacheConfig{CacheType: CacheTypeLocal, LocalCacheSizeMiB: 10})
+	db.EnablePruning()
+
+	trie, _ := NewTrie(common.Hash{}, db)
+	for i := 0; i < 100; i++ {
+		trie.Update([]byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%03d", i)))
+	}
+	root1 := commitPruningTestTrie(t, db, trie, 1)
+	_, ok := db.TrieNodeCache().Has(root1[:])
+	assert.True(t, ok)
+
+	trie, _ = NewTrie(root1, db)
+	trie.Update([]byte("key000"), []byte("updated"))
+	root2 := commitPruningTestTrie(t, db, trie, 2)
+
+	deleted, err := db.PruneRoots(2)
+	assert.NoError(t, err)
+	assert.True(t, deleted > 0)
+
+	// The pruned nodes should not be served from the cache.
+	_, ok = db.TrieNodeCache().Has(root1[:])
+	assert.False(t, ok)
+	_, ok = db.TrieNodeCache().Has(root2[:])
+	assert.True(t, ok)
+	_, err = NewTrie(root1, db)
+	assert.Error(t, err)
+}
@@ -172,7 +172,7 @@ func (t *StateTest) gasLimit(subtest StateSubtest) uint64 {
 
 func MakePreState(db database.DBManager, accounts blockchain.GenesisAlloc) *state.StateDB {
 	sdb := state.NewDatabase(db)
-	statedb, _ := state.New(common.Hash{}, sdb)
+	statedb, _ := state.New(common.Hash{}, sdb, nil)
 	for addr, a := range accounts {
 		if len(a.Code) != 0 {
 			statedb.SetCode(addr, a.Code)
@@ -185,7 +185,7 @@ func MakePreState(db database.DBManager, accounts blockchain.GenesisAlloc) *stat
 	}
 	// Commit and re-open to start with a clean state.
 	root, _ := statedb.Commit(false)
-	statedb, _ = state.New(root, sdb)
+	statedb, _ = state.New(root, sdb, nil)
 	return statedb
 }
 
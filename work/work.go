@@ -67,6 +67,15 @@ type TxPool interface {
 	Get(hash common.Hash) *types.Transaction
 	Stats() (int, int)
 	Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	ContentFrom(addr common.Address) (types.Transactions, types.Transactions)
+
+	// DropTx and DropTxsFrom remove transactions from the pool without executing them.
+	DropTx(hash common.Hash) bool
+	DropTxsFrom(addr common.Address) int
+
+	// SubscribeTxPoolEvent should return an event subscription of
+	// TxPoolEvent and send events to the given channel.
+	SubscribeTxPoolEvent(chan<- blockchain.TxPoolEvent) event.Subscription
 }
 
 // Backend wraps all methods required for mining.
@@ -81,6 +81,49 @@ func (mr *MockTxPoolMockRecorder) Content() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Content", reflect.TypeOf((*MockTxPool)(nil).Content))
 }
 
+// ContentFrom mocks base method
+func (m *MockTxPool) ContentFrom(arg0 common.Address) (types.Transactions, types.Transactions) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContentFrom", arg0)
+	ret0, _ := ret[0].(types.Transactions)
+	ret1, _ := ret[1].(types.Transactions)
+	return ret0, ret1
+}
+
+// ContentFrom indicates an expected call of ContentFrom
+func (mr *MockTxPoolMockRecorder) ContentFrom(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContentFrom", reflect.TypeOf((*MockTxPool)(nil).ContentFrom), arg0)
+}
+
+// DropTx mocks base method
+func (m *MockTxPool) DropTx(arg0 common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropTx", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DropTx indicates an expected call of DropTx
+func (mr *MockTxPoolMockRecorder) DropTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropTx", reflect.TypeOf((*MockTxPool)(nil).DropTx), arg0)
+}
+
+// DropTxsFrom mocks base method
+func (m *MockTxPool) DropTxsFrom(arg0 common.Address) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropTxsFrom", arg0)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// DropTxsFrom indicates an expected call of DropTxsFrom
+func (mr *MockTxPoolMockRecorder) DropTxsFrom(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropTxsFrom", reflect.TypeOf((*MockTxPool)(nil).DropTxsFrom), arg0)
+}
+
 // GasPrice mocks base method
 func (m *MockTxPool) GasPrice() *big.Int {
 	m.ctrl.T.Helper()
@@ -202,3 +245,17 @@ func (mr *MockTxPoolMockRecorder) SubscribeNewTxsEvent(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNewTxsEvent", reflect.TypeOf((*MockTxPool)(nil).SubscribeNewTxsEvent), arg0)
 }
+
+// SubscribeTxPoolEvent mocks base method
+func (m *MockTxPool) SubscribeTxPoolEvent(arg0 chan<- blockchain.TxPoolEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeTxPoolEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeTxPoolEvent indicates an expected call of SubscribeTxPoolEvent
+func (mr *MockTxPoolMockRecorder) SubscribeTxPoolEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeTxPoolEvent", reflect.TypeOf((*MockTxPool)(nil).SubscribeTxPoolEvent), arg0)
+}
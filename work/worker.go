@@ -67,6 +67,7 @@ var (
 	nonceTooLowTxsGauge     = metrics.NewRegisteredGauge("miner/nonce/low/txs", nil)
 	nonceTooHighTxsGauge    = metrics.NewRegisteredGauge("miner/nonce/high/txs", nil)
 	gasLimitReachedTxsGauge = metrics.NewRegisteredGauge("miner/limitreached/gas/txs", nil)
+	belowBaseFeeTxsGauge    = metrics.NewRegisteredGauge("miner/belowbasefee/txs", nil)
 	strangeErrorTxsCounter  = metrics.NewRegisteredCounter("miner/strangeerror/txs", nil)
 
 	blockMiningTimer          = klaytnmetrics.NewRegisteredHybridTimer("miner/block/mining/time", nil)
@@ -664,6 +665,7 @@ func (env *Task) ApplyTransactions(txs *types.TransactionsByPriceAndNonce, bc Bl
 	var numTxsNonceTooLow int64 = 0
 	var numTxsNonceTooHigh int64 = 0
 	var numTxsGasLimitReached int64 = 0
+	var numTxsGasPriceBelowBaseFee int64 = 0
 CommitTransactionLoop:
 	for atomic.LoadInt32(&abort) == 0 {
 		// Retrieve the next transaction and abort if all done
@@ -709,6 +711,12 @@ CommitTransactionLoop:
 			numTxsNonceTooLow++
 			txs.Shift()
 
+		case blockchain.ErrGasPriceBelowBaseFee:
+			// The base fee has risen above the gas price of the account, skip the account
+			logger.Trace("Skipping account with gas price below the base fee", "sender", from, "gasPrice", tx.GasPrice())
+			numTxsGasPriceBelowBaseFee++
+			txs.Pop()
+
 		case blockchain.ErrNonceTooHigh:
 			// Reorg notification data race between the transaction pool and miner, skip account =
 			logger.Trace("Skipping account with high nonce", "sender", from, "nonce", tx.Nonce())
@@ -745,6 +753,7 @@ CommitTransactionLoop:
 	nonceTooLowTxsGauge.Update(numTxsNonceTooLow)
 	nonceTooHighTxsGauge.Update(numTxsNonceTooHigh)
 	gasLimitReachedTxsGauge.Update(numTxsGasLimitReached)
+	belowBaseFeeTxsGauge.Update(numTxsGasPriceBelowBaseFee)
 
 	// Stop the goroutine that has been handling the timer.
 	chDone <- true